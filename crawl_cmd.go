@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"go-story/internal/data"
+	"go-story/internal/probe"
+)
+
+// runCrawlCommand implements `go-story crawl`, returning the process exit code.
+func runCrawlCommand(args []string, stdout io.Writer) int {
+	fs := flag.NewFlagSet("crawl", flag.ContinueOnError)
+	target := fs.String("target", "", "reference GQL endpoint (required)")
+	self := fs.String("self", "http://127.0.0.1:8080/api/graphql", "go-story GQL endpoint")
+	dsn := fs.String("database-url", os.Getenv("DATABASE_URL"), "Postgres connection string used to enumerate slugs (default: $DATABASE_URL)")
+	posts := fs.Int("posts", 200, "number of most recently published posts to crawl")
+	externals := fs.Int("externals", 50, "number of most recently published externals to crawl")
+	topics := fs.Int("topics", 50, "number of most recently created published topics to crawl")
+	concurrency := fs.Int("concurrency", 4, "queries in flight per endpoint")
+	ignore := fs.String("ignore", "", "comma-separated JSON paths stripped before comparing, e.g. data.post.updatedAt")
+	reportPath := fs.String("report", "", "write the full report as JSON to this file")
+	headers := headerFlags{}
+	fs.Var(headers, "header", "header forwarded to both endpoints, e.g. \"Authorization: Bearer ...\" (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *target == "" || *dsn == "" {
+		fmt.Fprintln(os.Stderr, "crawl: --target and --database-url (or DATABASE_URL) are required")
+		fs.Usage()
+		return 2
+	}
+
+	db, err := data.NewDB(*dsn, data.DBOptions{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "crawl: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	seeds, err := crawlSeeds(ctx, data.NewRepo(db, "", nil, data.RepoOptions{}), *posts, *externals, *topics)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "crawl: %v\n", err)
+		return 1
+	}
+
+	var ignorePaths []string
+	for _, p := range strings.Split(*ignore, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			ignorePaths = append(ignorePaths, p)
+		}
+	}
+	tests := probe.CrawlSuite(seeds, ignorePaths)
+	fmt.Fprintf(stdout, "crawling %d posts, %d externals, %d topics (target %s, self %s)\n",
+		len(seeds.Posts), len(seeds.Externals), len(seeds.Topics), *target, *self)
+	report := probe.Crawl(*target, *self, tests, headers, *concurrency, 5)
+
+	for _, m := range report.Mismatches {
+		fmt.Fprintf(stdout, "[FAIL] %s: %s (target status %d, self status %d)\n", m.Name, m.Note, m.TargetStatus, m.SelfStatus)
+		if m.TargetError != "" {
+			fmt.Fprintf(stdout, "    target error: %s\n", m.TargetError)
+		}
+		if m.SelfError != "" {
+			fmt.Fprintf(stdout, "    self error: %s\n", m.SelfError)
+		}
+		for _, d := range m.Diff {
+			fmt.Fprintf(stdout, "    %s\n", d)
+		}
+	}
+	fmt.Fprintln(stdout)
+	for _, k := range report.Kinds {
+		fmt.Fprintf(stdout, "%-10s %d/%d matched (%.1f%%)\n", k.Kind, k.Matched, k.Total, percent(k.Matched, k.Total))
+	}
+	fmt.Fprintf(stdout, "%-10s %d/%d matched (%.1f%%)\n", "total", report.Matched, report.Total, percent(report.Matched, report.Total))
+
+	if *reportPath != "" {
+		b, _ := json.MarshalIndent(report, "", "  ")
+		if err := os.WriteFile(*reportPath, b, 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "crawl: %v\n", err)
+			return 1
+		}
+	}
+	if report.Matched < report.Total {
+		return 1
+	}
+	return 0
+}
+
+// crawlSeeds 從資料庫取出最近發布的文章、外稿與專題 slug
+func crawlSeeds(ctx context.Context, repo *data.Repo, posts, externals, topics int) (probe.Seeds, error) {
+	var seeds probe.Seeds
+	published := func() *data.StringFilter {
+		s := "published"
+		return &data.StringFilter{Equals: &s}
+	}
+
+	if posts > 0 {
+		list, err := repo.QueryPosts(ctx, &data.PostWhereInput{State: published()}, []data.OrderRule{{Field: "publishedDate", Direction: "desc"}}, posts, 0)
+		if err != nil {
+			return seeds, fmt.Errorf("list posts: %w", err)
+		}
+		for _, p := range list {
+			seeds.Posts = append(seeds.Posts, p.Slug)
+		}
+	}
+	if externals > 0 {
+		list, err := repo.QueryExternals(ctx, &data.ExternalWhereInput{State: published()}, []data.OrderRule{{Field: "publishedDate", Direction: "desc"}}, externals, 0)
+		if err != nil {
+			return seeds, fmt.Errorf("list externals: %w", err)
+		}
+		for _, e := range list {
+			seeds.Externals = append(seeds.Externals, e.Slug)
+		}
+	}
+	if topics > 0 {
+		list, err := repo.QueryTopics(ctx, &data.TopicWhereInput{State: published()}, []data.OrderRule{{Field: "createdAt", Direction: "desc"}}, topics, 0)
+		if err != nil {
+			return seeds, fmt.Errorf("list topics: %w", err)
+		}
+		for _, t := range list {
+			seeds.Topics = append(seeds.Topics, t.Slug)
+		}
+	}
+	return seeds, nil
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(n) * 100 / float64(total)
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"go-story/internal/config"
+)
+
+// parseFlags exposes every config key as a flag (REDIS_URL -> --redis-url)
+// and returns the values explicitly set on the command line.
+func parseFlags(args []string) map[string]string {
+	fs := flag.NewFlagSet("go-story", flag.ExitOnError)
+	keyByFlag := map[string]string{}
+	for _, key := range config.Keys {
+		name := strings.ReplaceAll(strings.ToLower(key), "_", "-")
+		keyByFlag[name] = key
+		fs.String(name, "", "overrides $"+key)
+	}
+	_ = fs.Parse(args)
+
+	overrides := map[string]string{}
+	fs.Visit(func(f *flag.Flag) {
+		overrides[keyByFlag[f.Name]] = f.Value.String()
+	})
+	return overrides
+}
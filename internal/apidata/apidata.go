@@ -0,0 +1,361 @@
+// Package apidata converts stored draft-js raw content into the apiData block
+// format consumed by the mobile app.
+package apidata
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// Block is one apiData entry.
+type Block struct {
+	ID        string         `json:"id"`
+	Type      string         `json:"type"`
+	Alignment string         `json:"alignment"`
+	Content   []any          `json:"content"`
+	Styles    map[string]any `json:"styles"`
+}
+
+const defaultAlignment = "center"
+
+// textBlockTypes 直接輸出 HTML 字串的 draft-js block
+var textBlockTypes = map[string]bool{
+	"unstyled":     true,
+	"paragraph":    true,
+	"header-one":   true,
+	"header-two":   true,
+	"header-three": true,
+	"header-four":  true,
+	"header-five":  true,
+	"header-six":   true,
+	"blockquote":   true,
+	"code-block":   true,
+}
+
+// inlineStyleTags 對應 draft-js inline style 的 HTML tag
+var inlineStyleTags = map[string]string{
+	"BOLD":          "strong",
+	"ITALIC":        "em",
+	"UNDERLINE":     "u",
+	"CODE":          "code",
+	"STRIKETHROUGH": "s",
+}
+
+type rawBlock struct {
+	Key               string
+	Text              string
+	Type              string
+	Data              map[string]any
+	InlineStyleRanges []styleRange
+	EntityRanges      []entityRange
+}
+
+type styleRange struct {
+	Offset int
+	Length int
+	Style  string
+}
+
+type entityRange struct {
+	Offset int
+	Length int
+	Key    string
+}
+
+type entity struct {
+	Type string
+	Data map[string]any
+}
+
+// FromDraft converts a draft-js raw content object ({blocks, entityMap}) into
+// apiData blocks. A nil or malformed input yields an empty slice.
+//
+// Text blocks become HTML strings with inline styles and links applied,
+// consecutive list items are grouped into one block, and atomic blocks
+// become a block typed after their entity (image, video, slideshow, ...)
+// carrying the entity data.
+func FromDraft(raw map[string]any) []Block {
+	result := []Block{}
+	if raw == nil {
+		return result
+	}
+	entities := parseEntityMap(raw["entityMap"])
+	rawBlocks, _ := raw["blocks"].([]any)
+
+	for _, item := range rawBlocks {
+		b, ok := parseBlock(item)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case b.Type == "ordered-list-item" || b.Type == "unordered-list-item":
+			htmlText := renderText(b, entities)
+			// 連續的同類 list item 合併成同一個 block
+			if n := len(result); n > 0 && result[n-1].Type == b.Type {
+				items := result[n-1].Content[0].([]string)
+				result[n-1].Content[0] = append(items, htmlText)
+				continue
+			}
+			result = append(result, Block{
+				ID:        b.Key,
+				Type:      b.Type,
+				Alignment: alignmentOf(b.Data, defaultAlignment),
+				Content:   []any{[]string{htmlText}},
+				Styles:    map[string]any{},
+			})
+
+		case b.Type == "atomic":
+			ent, ok := atomicEntity(b, entities)
+			if !ok {
+				continue
+			}
+			data := ent.Data
+			if data == nil {
+				data = map[string]any{}
+			}
+			result = append(result, Block{
+				ID:        b.Key,
+				Type:      strings.ToLower(ent.Type),
+				Alignment: alignmentOf(data, alignmentOf(b.Data, defaultAlignment)),
+				Content:   []any{data},
+				Styles:    map[string]any{},
+			})
+
+		case textBlockTypes[b.Type]:
+			// 空白段落不輸出
+			if strings.TrimSpace(b.Text) == "" {
+				continue
+			}
+			blockType := b.Type
+			if blockType == "paragraph" {
+				blockType = "unstyled"
+			}
+			result = append(result, Block{
+				ID:        b.Key,
+				Type:      blockType,
+				Alignment: alignmentOf(b.Data, defaultAlignment),
+				Content:   []any{renderText(b, entities)},
+				Styles:    map[string]any{},
+			})
+
+		default:
+			// 未知的 block 類型以純文字保留，避免內容遺失
+			if strings.TrimSpace(b.Text) == "" {
+				continue
+			}
+			result = append(result, Block{
+				ID:        b.Key,
+				Type:      b.Type,
+				Alignment: alignmentOf(b.Data, defaultAlignment),
+				Content:   []any{renderText(b, entities)},
+				Styles:    map[string]any{},
+			})
+		}
+	}
+	return result
+}
+
+func parseEntityMap(v any) map[string]entity {
+	result := map[string]entity{}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return result
+	}
+	for key, raw := range m {
+		e, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		ent := entity{Type: toString(e["type"])}
+		ent.Data, _ = e["data"].(map[string]any)
+		result[key] = ent
+	}
+	return result
+}
+
+func parseBlock(v any) (rawBlock, bool) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return rawBlock{}, false
+	}
+	b := rawBlock{
+		Key:  toString(m["key"]),
+		Text: toString(m["text"]),
+		Type: toString(m["type"]),
+	}
+	if b.Type == "" {
+		b.Type = "unstyled"
+	}
+	b.Data, _ = m["data"].(map[string]any)
+	if list, ok := m["inlineStyleRanges"].([]any); ok {
+		for _, item := range list {
+			r, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			b.InlineStyleRanges = append(b.InlineStyleRanges, styleRange{
+				Offset: toInt(r["offset"]),
+				Length: toInt(r["length"]),
+				Style:  toString(r["style"]),
+			})
+		}
+	}
+	if list, ok := m["entityRanges"].([]any); ok {
+		for _, item := range list {
+			r, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			b.EntityRanges = append(b.EntityRanges, entityRange{
+				Offset: toInt(r["offset"]),
+				Length: toInt(r["length"]),
+				Key:    toString(r["key"]),
+			})
+		}
+	}
+	return b, true
+}
+
+func atomicEntity(b rawBlock, entities map[string]entity) (entity, bool) {
+	for _, r := range b.EntityRanges {
+		if ent, ok := entities[r.Key]; ok && ent.Type != "" {
+			return ent, true
+		}
+	}
+	return entity{}, false
+}
+
+// renderText 依 inline style 與 LINK entity 將文字轉為 HTML。
+// draft-js 的 offset 以 UTF-16 code unit 計算，因此先轉成 UTF-16 再切割。
+func renderText(b rawBlock, entities map[string]entity) string {
+	units := utf16.Encode([]rune(b.Text))
+	n := len(units)
+	if n == 0 {
+		return ""
+	}
+
+	// 每個位置的 style 與 link
+	styles := make([][]string, n)
+	links := make([]string, n)
+	for _, r := range b.InlineStyleRanges {
+		tag, ok := inlineStyleTags[r.Style]
+		if !ok {
+			continue
+		}
+		for i := clamp(r.Offset, n); i < clamp(r.Offset+r.Length, n); i++ {
+			styles[i] = append(styles[i], tag)
+		}
+	}
+	for _, r := range b.EntityRanges {
+		ent, ok := entities[r.Key]
+		if !ok || strings.ToUpper(ent.Type) != "LINK" {
+			continue
+		}
+		href := toString(ent.Data["url"])
+		if href == "" {
+			href = toString(ent.Data["href"])
+		}
+		// 不允許的 scheme（例如 javascript:）只輸出文字
+		href = safeHref(href)
+		for i := clamp(r.Offset, n); i < clamp(r.Offset+r.Length, n); i++ {
+			links[i] = href
+		}
+	}
+	for i := range styles {
+		sort.Strings(styles[i])
+	}
+
+	var sb strings.Builder
+	start := 0
+	for i := 1; i <= n; i++ {
+		if i < n && links[i] == links[start] && equalStrings(styles[i], styles[start]) {
+			continue
+		}
+		segment := html.EscapeString(string(utf16.Decode(units[start:i])))
+		segment = strings.ReplaceAll(segment, "\n", "<br>")
+		for _, tag := range styles[start] {
+			segment = "<" + tag + ">" + segment + "</" + tag + ">"
+		}
+		if links[start] != "" {
+			segment = fmt.Sprintf(`<a href="%s" target="_blank" rel="noopener noreferrer">%s</a>`, html.EscapeString(links[start]), segment)
+		}
+		sb.WriteString(segment)
+		start = i
+	}
+	return sb.String()
+}
+
+// allowedSchemes 為 LINK entity 可輸出的 URL scheme
+var allowedSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+// safeHref 回傳可放進 href 的 URL；scheme 不在 allowedSchemes 或無法解析時回傳空字串。
+// 沒有 scheme 的相對網址也不輸出，避免瀏覽器以其他方式解讀
+func safeHref(href string) string {
+	href = strings.TrimSpace(href)
+	u, err := url.Parse(href)
+	if err != nil || !allowedSchemes[strings.ToLower(u.Scheme)] {
+		return ""
+	}
+	return href
+}
+
+func alignmentOf(data map[string]any, def string) string {
+	if a := toString(data["alignment"]); a != "" {
+		return a
+	}
+	return def
+}
+
+func clamp(v, n int) int {
+	if v < 0 {
+		return 0
+	}
+	if v > n {
+		return n
+	}
+	return v
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func toString(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+func toInt(v any) int {
+	switch val := v.(type) {
+	case float64:
+		return int(val)
+	case int:
+		return val
+	case int64:
+		return int(val)
+	}
+	return 0
+}
@@ -0,0 +1,325 @@
+package apidata
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// draft 將 JSON 字串解析為 FromDraft 的輸入，與從資料庫讀出的 content 相同型別
+func draft(t *testing.T, raw string) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		t.Fatalf("invalid draft fixture: %v", err)
+	}
+	return m
+}
+
+func textBlock(id, typ, content string) Block {
+	return Block{ID: id, Type: typ, Alignment: defaultAlignment, Content: []any{content}, Styles: map[string]any{}}
+}
+
+func TestFromDraftBlockTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []Block
+	}{
+		{
+			name: "nil input",
+			raw:  `null`,
+			want: []Block{},
+		},
+		{
+			name: "unstyled",
+			raw:  `{"blocks":[{"key":"a","type":"unstyled","text":"hello"}]}`,
+			want: []Block{textBlock("a", "unstyled", "hello")},
+		},
+		{
+			name: "missing type defaults to unstyled",
+			raw:  `{"blocks":[{"key":"a","text":"hello"}]}`,
+			want: []Block{textBlock("a", "unstyled", "hello")},
+		},
+		{
+			name: "paragraph becomes unstyled",
+			raw:  `{"blocks":[{"key":"a","type":"paragraph","text":"hello"}]}`,
+			want: []Block{textBlock("a", "unstyled", "hello")},
+		},
+		{
+			name: "headers",
+			raw: `{"blocks":[
+				{"key":"a","type":"header-one","text":"h1"},
+				{"key":"b","type":"header-two","text":"h2"},
+				{"key":"c","type":"header-three","text":"h3"},
+				{"key":"d","type":"header-four","text":"h4"},
+				{"key":"e","type":"header-five","text":"h5"},
+				{"key":"f","type":"header-six","text":"h6"}]}`,
+			want: []Block{
+				textBlock("a", "header-one", "h1"),
+				textBlock("b", "header-two", "h2"),
+				textBlock("c", "header-three", "h3"),
+				textBlock("d", "header-four", "h4"),
+				textBlock("e", "header-five", "h5"),
+				textBlock("f", "header-six", "h6"),
+			},
+		},
+		{
+			name: "blockquote and code-block",
+			raw: `{"blocks":[
+				{"key":"a","type":"blockquote","text":"quote"},
+				{"key":"b","type":"code-block","text":"x < y"}]}`,
+			want: []Block{
+				textBlock("a", "blockquote", "quote"),
+				textBlock("b", "code-block", "x &lt; y"),
+			},
+		},
+		{
+			name: "blank text blocks are dropped",
+			raw: `{"blocks":[
+				{"key":"a","type":"unstyled","text":"  "},
+				{"key":"b","type":"custom","text":""}]}`,
+			want: []Block{},
+		},
+		{
+			name: "unknown type keeps its text",
+			raw:  `{"blocks":[{"key":"a","type":"custom","text":"kept"}]}`,
+			want: []Block{textBlock("a", "custom", "kept")},
+		},
+		{
+			name: "alignment from block data",
+			raw:  `{"blocks":[{"key":"a","type":"unstyled","text":"x","data":{"alignment":"left"}}]}`,
+			want: []Block{{ID: "a", Type: "unstyled", Alignment: "left", Content: []any{"x"}, Styles: map[string]any{}}},
+		},
+		{
+			name: "newlines become br",
+			raw:  `{"blocks":[{"key":"a","type":"unstyled","text":"a\nb"}]}`,
+			want: []Block{textBlock("a", "unstyled", "a<br>b")},
+		},
+		{
+			name: "malformed blocks are skipped",
+			raw:  `{"blocks":["oops",{"key":"a","type":"unstyled","text":"ok"}]}`,
+			want: []Block{textBlock("a", "unstyled", "ok")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromDraft(draft(t, tt.raw))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromDraft() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromDraftLists(t *testing.T) {
+	listBlock := func(id, typ string, items ...string) Block {
+		return Block{ID: id, Type: typ, Alignment: defaultAlignment, Content: []any{items}, Styles: map[string]any{}}
+	}
+	tests := []struct {
+		name string
+		raw  string
+		want []Block
+	}{
+		{
+			name: "consecutive items are grouped",
+			raw: `{"blocks":[
+				{"key":"a","type":"unordered-list-item","text":"one"},
+				{"key":"b","type":"unordered-list-item","text":"two"},
+				{"key":"c","type":"unordered-list-item","text":"three"}]}`,
+			want: []Block{listBlock("a", "unordered-list-item", "one", "two", "three")},
+		},
+		{
+			name: "different list types are separate",
+			raw: `{"blocks":[
+				{"key":"a","type":"ordered-list-item","text":"one"},
+				{"key":"b","type":"unordered-list-item","text":"two"}]}`,
+			want: []Block{
+				listBlock("a", "ordered-list-item", "one"),
+				listBlock("b", "unordered-list-item", "two"),
+			},
+		},
+		{
+			name: "a paragraph splits the list",
+			raw: `{"blocks":[
+				{"key":"a","type":"ordered-list-item","text":"one"},
+				{"key":"b","type":"unstyled","text":"between"},
+				{"key":"c","type":"ordered-list-item","text":"two"}]}`,
+			want: []Block{
+				listBlock("a", "ordered-list-item", "one"),
+				textBlock("b", "unstyled", "between"),
+				listBlock("c", "ordered-list-item", "two"),
+			},
+		},
+		{
+			name: "items keep inline styles",
+			raw: `{"blocks":[
+				{"key":"a","type":"unordered-list-item","text":"bold","inlineStyleRanges":[{"offset":0,"length":4,"style":"BOLD"}]},
+				{"key":"b","type":"unordered-list-item","text":"plain"}]}`,
+			want: []Block{listBlock("a", "unordered-list-item", "<strong>bold</strong>", "plain")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromDraft(draft(t, tt.raw))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromDraft() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromDraftAtomic(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []Block
+	}{
+		{
+			name: "image",
+			raw: `{"blocks":[{"key":"a","type":"atomic","text":" ","entityRanges":[{"offset":0,"length":1,"key":0}]}],
+				"entityMap":{"0":{"type":"IMAGE","data":{"id":"12","name":"photo"}}}}`,
+			want: []Block{{ID: "a", Type: "image", Alignment: defaultAlignment,
+				Content: []any{map[string]any{"id": "12", "name": "photo"}}, Styles: map[string]any{}}},
+		},
+		{
+			name: "video with alignment in entity data",
+			raw: `{"blocks":[{"key":"a","type":"atomic","text":" ","data":{"alignment":"left"},"entityRanges":[{"offset":0,"length":1,"key":0}]}],
+				"entityMap":{"0":{"type":"VIDEO","data":{"id":"3","alignment":"right"}}}}`,
+			want: []Block{{ID: "a", Type: "video", Alignment: "right",
+				Content: []any{map[string]any{"id": "3", "alignment": "right"}}, Styles: map[string]any{}}},
+		},
+		{
+			name: "embed falls back to block alignment",
+			raw: `{"blocks":[{"key":"a","type":"atomic","text":" ","data":{"alignment":"left"},"entityRanges":[{"offset":0,"length":1,"key":0}]}],
+				"entityMap":{"0":{"type":"EMBEDDEDCODE","data":{"embeddedCode":"<iframe></iframe>"}}}}`,
+			want: []Block{{ID: "a", Type: "embeddedcode", Alignment: "left",
+				Content: []any{map[string]any{"embeddedCode": "<iframe></iframe>"}}, Styles: map[string]any{}}},
+		},
+		{
+			name: "entity without data",
+			raw: `{"blocks":[{"key":"a","type":"atomic","text":" ","entityRanges":[{"offset":0,"length":1,"key":0}]}],
+				"entityMap":{"0":{"type":"DIVIDER"}}}`,
+			want: []Block{{ID: "a", Type: "divider", Alignment: defaultAlignment,
+				Content: []any{map[string]any{}}, Styles: map[string]any{}}},
+		},
+		{
+			name: "missing entity is dropped",
+			raw:  `{"blocks":[{"key":"a","type":"atomic","text":" ","entityRanges":[{"offset":0,"length":1,"key":9}]}],"entityMap":{}}`,
+			want: []Block{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromDraft(draft(t, tt.raw))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromDraft() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	tests := []struct {
+		name     string
+		block    string
+		entities string
+		want     string
+	}{
+		{
+			name:  "html is escaped",
+			block: `{"text":"<b>&</b>"}`,
+			want:  "&lt;b&gt;&amp;&lt;/b&gt;",
+		},
+		{
+			name:  "CJK offsets",
+			block: `{"text":"鏡週刊報導","inlineStyleRanges":[{"offset":0,"length":3,"style":"BOLD"}]}`,
+			want:  "<strong>鏡週刊</strong>報導",
+		},
+		{
+			name:  "emoji counts as two UTF-16 units",
+			block: `{"text":"😀好消息","inlineStyleRanges":[{"offset":2,"length":1,"style":"ITALIC"}]}`,
+			want:  "😀<em>好</em>消息",
+		},
+		{
+			name:  "style covering an emoji",
+			block: `{"text":"a😀b","inlineStyleRanges":[{"offset":1,"length":2,"style":"BOLD"}]}`,
+			want:  "a<strong>😀</strong>b",
+		},
+		{
+			name: "overlapping styles",
+			block: `{"text":"abcdef","inlineStyleRanges":[
+				{"offset":0,"length":4,"style":"BOLD"},
+				{"offset":2,"length":4,"style":"ITALIC"}]}`,
+			want: "<strong>ab</strong><strong><em>cd</em></strong><em>ef</em>",
+		},
+		{
+			name:  "unknown style and out of range offsets",
+			block: `{"text":"abc","inlineStyleRanges":[{"offset":0,"length":1,"style":"COLOR-RED"},{"offset":1,"length":99,"style":"UNDERLINE"}]}`,
+			want:  "a<u>bc</u>",
+		},
+		{
+			name:     "link",
+			block:    `{"text":"see here","entityRanges":[{"offset":4,"length":4,"key":0}]}`,
+			entities: `{"0":{"type":"LINK","data":{"url":"https://www.mirrormedia.mg/?a=1&b=2"}}}`,
+			want:     `see <a href="https://www.mirrormedia.mg/?a=1&amp;b=2" target="_blank" rel="noopener noreferrer">here</a>`,
+		},
+		{
+			name:     "link with href and style",
+			block:    `{"text":"mail","inlineStyleRanges":[{"offset":0,"length":4,"style":"BOLD"}],"entityRanges":[{"offset":0,"length":4,"key":0}]}`,
+			entities: `{"0":{"type":"link","data":{"href":"mailto:editor@mirrormedia.mg"}}}`,
+			want:     `<a href="mailto:editor@mirrormedia.mg" target="_blank" rel="noopener noreferrer"><strong>mail</strong></a>`,
+		},
+		{
+			name:     "link after emoji",
+			block:    `{"text":"😀連結","entityRanges":[{"offset":2,"length":2,"key":0}]}`,
+			entities: `{"0":{"type":"LINK","data":{"url":"http://example.com"}}}`,
+			want:     `😀<a href="http://example.com" target="_blank" rel="noopener noreferrer">連結</a>`,
+		},
+		{
+			name:     "javascript link is dropped",
+			block:    `{"text":"click","entityRanges":[{"offset":0,"length":5,"key":0}]}`,
+			entities: `{"0":{"type":"LINK","data":{"url":" JavaScript:alert(1)"}}}`,
+			want:     "click",
+		},
+		{
+			name:     "data link is dropped",
+			block:    `{"text":"click","entityRanges":[{"offset":0,"length":5,"key":0}]}`,
+			entities: `{"0":{"type":"LINK","data":{"url":"data:text/html,<script>alert(1)</script>"}}}`,
+			want:     "click",
+		},
+		{
+			name:     "relative link is dropped",
+			block:    `{"text":"click","entityRanges":[{"offset":0,"length":5,"key":0}]}`,
+			entities: `{"0":{"type":"LINK","data":{"url":"/story/abc"}}}`,
+			want:     "click",
+		},
+		{
+			name:     "non-link entity is ignored",
+			block:    `{"text":"tag","entityRanges":[{"offset":0,"length":3,"key":0}]}`,
+			entities: `{"0":{"type":"IMAGE","data":{"url":"https://example.com/a.jpg"}}}`,
+			want:     "tag",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var blockRaw any
+			if err := json.Unmarshal([]byte(tt.block), &blockRaw); err != nil {
+				t.Fatalf("invalid block fixture: %v", err)
+			}
+			b, ok := parseBlock(blockRaw)
+			if !ok {
+				t.Fatalf("parseBlock(%s) failed", tt.block)
+			}
+			var entityRaw any
+			if tt.entities != "" {
+				if err := json.Unmarshal([]byte(tt.entities), &entityRaw); err != nil {
+					t.Fatalf("invalid entity fixture: %v", err)
+				}
+			}
+			if got := renderText(b, parseEntityMap(entityRaw)); got != tt.want {
+				t.Errorf("renderText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
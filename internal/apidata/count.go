@@ -0,0 +1,118 @@
+package apidata
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// 閱讀速度：中日韓文字以字計算，其他語言以詞計算
+const (
+	cjkCharsPerMinute = 500
+	wordsPerMinute    = 200
+)
+
+// TextStats holds the readable text counted from draft-js content.
+type TextStats struct {
+	// CJKChars 中日韓文字數
+	CJKChars int
+	// Words 非中日韓文字的詞數（以空白或標點分隔）
+	Words int
+}
+
+// entityTextFields 會計入字數的 atomic entity 欄位，其餘 embed（圖片、影片、程式碼等）不計
+var entityTextFields = map[string][]string{
+	"INFOBOX":    {"title", "body"},
+	"BLOCKQUOTE": {"quote", "quoteBy"},
+	"QUOTEBY":    {"quote", "quoteBy"},
+	"COLORBOX":   {"body"},
+}
+
+// CountText counts the text of every text and list block in draft-js content.
+// Atomic blocks count only the text carried by text-like entities such as
+// infoboxes and blockquotes; images and other embeds are skipped.
+func CountText(raw map[string]any) TextStats {
+	var stats TextStats
+	if raw == nil {
+		return stats
+	}
+	entities := parseEntityMap(raw["entityMap"])
+	rawBlocks, _ := raw["blocks"].([]any)
+	for _, item := range rawBlocks {
+		b, ok := parseBlock(item)
+		if !ok {
+			continue
+		}
+		if b.Type != "atomic" {
+			stats.add(b.Text)
+			continue
+		}
+		ent, ok := atomicEntity(b, entities)
+		if !ok {
+			continue
+		}
+		for _, field := range entityTextFields[strings.ToUpper(ent.Type)] {
+			stats.add(stripTags(toString(ent.Data[field])))
+		}
+	}
+	return stats
+}
+
+// WordCount returns CJK characters plus words of other languages.
+func (s TextStats) WordCount() int {
+	return s.CJKChars + s.Words
+}
+
+// add 累加一段文字：CJK 字元逐字計算，其餘連續字母數字視為一個詞
+func (s *TextStats) add(text string) {
+	inWord := false
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			s.CJKChars++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				s.Words++
+				inWord = true
+			}
+		default:
+			inWord = false
+		}
+	}
+}
+
+// ReadingTime returns the estimated reading time in whole minutes,
+// rounded up; content with any text takes at least one minute.
+func ReadingTime(s TextStats) int {
+	if s.CJKChars == 0 && s.Words == 0 {
+		return 0
+	}
+	minutes := float64(s.CJKChars)/cjkCharsPerMinute + float64(s.Words)/wordsPerMinute
+	return int(math.Max(1, math.Ceil(minutes)))
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
+
+// stripTags 移除 HTML tag 只留下文字，tag 以空白取代避免前後文字黏在一起
+func stripTags(s string) string {
+	var sb strings.Builder
+	inTag := false
+	for _, r := range s {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>' && inTag:
+			inTag = false
+			sb.WriteRune(' ')
+		case !inTag:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
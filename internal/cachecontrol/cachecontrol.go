@@ -0,0 +1,90 @@
+// Package cachecontrol computes the cache policy of a response from the
+// @cacheControl hints of the fields it contains: the smallest maxAge wins
+// and a PRIVATE field makes the whole response private.
+package cachecontrol
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// Scope tells whether a response may be stored by shared caches.
+type Scope string
+
+const (
+	Public  Scope = "PUBLIC"
+	Private Scope = "PRIVATE"
+)
+
+// Hint is the cache hint of a field, in seconds.
+type Hint struct {
+	MaxAge int
+	Scope  Scope
+}
+
+// Header returns the Cache-Control value for h, or "" when the response
+// must not be cached.
+func (h Hint) Header() string {
+	if h.MaxAge <= 0 {
+		return ""
+	}
+	if h.Scope == Private {
+		return "private, max-age=" + strconv.Itoa(h.MaxAge)
+	}
+	return "public, max-age=" + strconv.Itoa(h.MaxAge)
+}
+
+// Policy is the cache policy of one response.
+type Policy struct {
+	mu   sync.Mutex
+	hint Hint
+	set  bool
+}
+
+type policyContextKey struct{}
+
+// NewContext returns a context collecting hints into the returned Policy.
+func NewContext(ctx context.Context) (context.Context, *Policy) {
+	p := &Policy{}
+	return context.WithValue(ctx, policyContextKey{}, p), p
+}
+
+// FromContext returns the Policy attached by NewContext, or nil.
+func FromContext(ctx context.Context) *Policy {
+	if ctx == nil {
+		return nil
+	}
+	p, _ := ctx.Value(policyContextKey{}).(*Policy)
+	return p
+}
+
+// Restrict lowers the policy to h.
+func (p *Policy) Restrict(h Hint) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.set || h.MaxAge < p.hint.MaxAge {
+		p.hint.MaxAge = h.MaxAge
+	}
+	if h.Scope == Private {
+		p.hint.Scope = Private
+	}
+	p.set = true
+}
+
+// Result returns the effective hint and whether any field had a hint.
+func (p *Policy) Result() (Hint, bool) {
+	if p == nil {
+		return Hint{}, false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	h := p.hint
+	if h.Scope == "" {
+		h.Scope = Public
+	}
+	return h, p.set
+}
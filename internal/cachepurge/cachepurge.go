@@ -0,0 +1,237 @@
+// Package cachepurge subscribes to the purge topic published by the CMS,
+// clears the Redis entries that may contain the purged entities and, when a
+// CDN is configured, purges its responses by surrogate key. The same purge
+// can also be requested over HTTP with Handler.
+package cachepurge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"go-story/internal/data"
+	"go-story/internal/metrics"
+	"go-story/internal/pubsub"
+)
+
+const (
+	// pullMax 每次 pull 的訊息數上限
+	pullMax = 50
+	// pullWait 單次 pull 等待的時間，需小於 pubsub client 的 HTTP timeout
+	pullWait = 20 * time.Second
+	// retryDelay pull 失敗後等待的時間
+	retryDelay = 5 * time.Second
+)
+
+var purgeCounter = metrics.NewCounter(
+	"go_story_cache_purges_total",
+	"Cache purge messages handled, by entity and result.",
+	"entity", "result",
+)
+
+// Request is the JSON body of a purge message. Entity is "post", "topic",
+// "external", "editorChoice", "audio", "tag", "section", "category" or
+// "all". With an ID and Action "updated", only the entries containing that
+// entity are purged; otherwise (publishing or unpublishing changes which
+// queries contain it) every query that may contain the kind is purged.
+// Slug is accepted for the CMS's convenience but unused. Tenant is the
+// cache_prefix of the site to purge; empty purges every site.
+type Request struct {
+	Entity string `json:"entity"`
+	ID     string `json:"id"`
+	Slug   string `json:"slug"`
+	Action string `json:"action"`
+	Tenant string `json:"tenant"`
+}
+
+// ActionUpdated is the Request action of an edit that leaves the entity in
+// the same lists, the same value as the change feed's event action.
+const ActionUpdated = "updated"
+
+// target 回傳 req 的清除範圍；只有修改單一 entity 時依 id 清除
+func (r Request) target() purgeTarget {
+	t := purgeTarget{tenant: r.Tenant, entity: r.Entity}
+	if r.ID != "" && r.Action == ActionUpdated && r.Entity != "all" {
+		t.id = r.ID
+	}
+	return t
+}
+
+// Caches maps each site to its cache: the main site under "" and tenants
+// under their cache_prefix.
+type Caches map[string]*data.Cache
+
+// Subscriber pulls purge requests from Subscription and applies them to
+// Caches and CDN.
+type Subscriber struct {
+	Client       *pubsub.Client
+	Subscription string
+	Caches       Caches
+	CDN          *CDN
+}
+
+// Start pulls messages until ctx is done. Messages are acknowledged after
+// both purges succeed; malformed ones are acknowledged and dropped.
+func (s *Subscriber) Start(ctx context.Context) {
+	if s.Subscription == "" {
+		return
+	}
+	log.Printf("[CachePurge] Listening for purge requests on %s", s.Subscription)
+	go func() {
+		for ctx.Err() == nil {
+			if err := s.pullOnce(ctx); err != nil {
+				log.Printf("[CachePurge] %v", err)
+				select {
+				case <-ctx.Done():
+				case <-time.After(retryDelay):
+				}
+			}
+		}
+	}()
+}
+
+func (s *Subscriber) pullOnce(ctx context.Context) error {
+	pullCtx, cancel := context.WithTimeout(ctx, pullWait)
+	msgs, err := s.Client.Pull(pullCtx, s.Subscription, pullMax)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	// 同一批中相同的清除範圍只清除一次，CDN 則清除所有訊息的 surrogate key
+	entities := map[purgeTarget]*pending{}
+	var drop []string
+	for _, m := range msgs {
+		var req Request
+		if err := json.Unmarshal(m.Data, &req); err != nil || req.Entity == "" {
+			log.Printf("[CachePurge] dropping malformed message %q", m.Data)
+			purgeCounter.Inc("unknown", "malformed")
+			drop = append(drop, m.AckID)
+			continue
+		}
+		// 未知的種類不會成功，直接 ack 避免重送；metric label 不使用訊息內容以免 cardinality 失控
+		if _, known := knownEntities[req.Entity]; !known {
+			log.Printf("[CachePurge] dropping message with unknown entity %q", req.Entity)
+			purgeCounter.Inc("unknown", "unknown_entity")
+			drop = append(drop, m.AckID)
+			continue
+		}
+		if !s.Caches.has(req.Tenant) {
+			log.Printf("[CachePurge] dropping message with unknown tenant %q", req.Tenant)
+			purgeCounter.Inc(req.Entity, "unknown_tenant")
+			drop = append(drop, m.AckID)
+			continue
+		}
+		target := req.target()
+		p := entities[target]
+		if p == nil {
+			p = &pending{cdnKeys: keySet{}}
+			entities[target] = p
+		}
+		p.ackIDs = append(p.ackIDs, m.AckID)
+		p.cdnKeys.add(surrogateKeys(req))
+	}
+
+	ack := drop
+	for target, p := range entities {
+		n, err := s.Caches.Purge(ctx, target)
+		if err != nil {
+			// 不 ack，讓 Pub/Sub 重送
+			log.Printf("[CachePurge] purge %s failed after %d keys: %v", target, n, err)
+			purgeCounter.Inc(target.entity, "error")
+			continue
+		}
+		// Redis 清除後才 purge CDN，避免 CDN 回源時又取得舊的快取
+		if err := s.CDN.Purge(ctx, p.cdnKeys.sorted()); err != nil {
+			log.Printf("[CachePurge] purge %s: %v", target, err)
+			purgeCounter.Inc(target.entity, "cdn_error")
+			continue
+		}
+		log.Printf("[CachePurge] purged %d keys for %s (%d messages)", n, target, len(p.ackIDs))
+		purgeCounter.Add(float64(len(p.ackIDs)), target.entity, "ok")
+		ack = append(ack, p.ackIDs...)
+	}
+	return s.Client.Acknowledge(ctx, s.Subscription, ack)
+}
+
+// pending 為同一個清除範圍的訊息與其 CDN surrogate key
+type pending struct {
+	ackIDs  []string
+	cdnKeys keySet
+}
+
+// purgeTarget 為一次清除的站台、種類與 id，tenant 為空代表所有站台，id 為空代表該種類的所有查詢
+type purgeTarget struct {
+	tenant, entity, id string
+}
+
+func (t purgeTarget) String() string {
+	s := t.entity
+	if t.id != "" {
+		s = data.EntityKey(t.entity, t.id)
+	}
+	if t.tenant == "" {
+		return s
+	}
+	return s + " (tenant " + t.tenant + ")"
+}
+
+// has 回傳 tenant 是否為可清除的站台；空字串代表所有站台
+func (c Caches) has(tenant string) bool {
+	if tenant == "" {
+		return true
+	}
+	_, ok := c[tenant]
+	return ok
+}
+
+// Purge clears target from the cache of its tenant, or from every site's
+// cache when the tenant is empty, and returns the total number of deleted
+// keys. It stops at the first failing site.
+func (c Caches) Purge(ctx context.Context, target purgeTarget) (int, error) {
+	names := []string{target.tenant}
+	if target.tenant == "" {
+		names = make([]string, 0, len(c))
+		for name := range c {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	deleted := 0
+	for _, name := range names {
+		cache, ok := c[name]
+		if !ok {
+			return deleted, fmt.Errorf("unknown tenant %q", name)
+		}
+		var n int
+		var err error
+		if target.id != "" {
+			n, err = cache.PurgeEntity(ctx, target.entity, target.id)
+		} else {
+			n, err = cache.Purge(ctx, target.entity)
+		}
+		deleted += n
+		if err != nil {
+			if name != "" {
+				err = fmt.Errorf("tenant %s: %w", name, err)
+			}
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// knownEntities 為 Cache.Purge 接受的種類
+var knownEntities = func() map[string]struct{} {
+	m := map[string]struct{}{}
+	for _, e := range data.PurgeEntities() {
+		m[e] = struct{}{}
+	}
+	return m
+}()
@@ -0,0 +1,93 @@
+package cachepurge
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"go-story/internal/data"
+)
+
+// cdnMaxKeys 單次 purge 請求的 surrogate key 數量上限（Fastly 為 256）
+const cdnMaxKeys = 256
+
+// CDN purges the responses cached by a CDN by surrogate key, with a POST to
+// URL carrying the keys in a space-separated Surrogate-Key header (Fastly's
+// https://api.fastly.com/service/<id>/purge) and Token in Fastly-Key. A nil
+// CDN purges nothing.
+type CDN struct {
+	URL    string
+	Token  string
+	Client *http.Client
+}
+
+// Purge sends a purge request for keys, in batches of at most 256 keys.
+func (c *CDN) Purge(ctx context.Context, keys []string) error {
+	if c == nil || c.URL == "" || len(keys) == 0 {
+		return nil
+	}
+	client := c.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	for start := 0; start < len(keys); start += cdnMaxKeys {
+		batch := keys[start:min(start+cdnMaxKeys, len(keys))]
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Surrogate-Key", strings.Join(batch, " "))
+		if c.Token != "" {
+			req.Header.Set("Fastly-Key", c.Token)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("cdn purge: %w", err)
+		}
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+		resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			return fmt.Errorf("cdn purge: status %d", resp.StatusCode)
+		}
+	}
+	return nil
+}
+
+// surrogateKeys 回傳 req 需要在 CDN purge 的 surrogate key（與回應的 Surrogate-Key 相同格式）：
+// 指定 id 時為 post-123；未指定 id 或不是單純修改（新增、下架會改變列表）時加上 post-list。
+// "all" 沒有對應的 key，不在 CDN purge
+func surrogateKeys(req Request) []string {
+	if req.Entity == "all" {
+		return nil
+	}
+	var keys []string
+	if req.ID != "" {
+		keys = append(keys, data.EntityKey(req.Entity, req.ID))
+	}
+	if req.ID == "" || req.Action != ActionUpdated {
+		keys = append(keys, strings.ToLower(req.Entity)+"-list")
+	}
+	return keys
+}
+
+// keySet 為不重複的 surrogate key
+type keySet map[string]bool
+
+func (s keySet) add(keys []string) {
+	for _, k := range keys {
+		s[k] = true
+	}
+}
+
+func (s keySet) sorted() []string {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
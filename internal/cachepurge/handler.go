@@ -0,0 +1,50 @@
+package cachepurge
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// Handler applies a purge request posted as JSON (the same body as a purge
+// message) to caches and cdn, and responds with the number of deleted keys.
+// It lets operators purge without going through Pub/Sub.
+func Handler(caches Caches, cdn *CDN) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil || req.Entity == "" {
+			purgeCounter.Inc("unknown", "malformed")
+			http.Error(w, `body must be {"entity": "<kind>"}`, http.StatusBadRequest)
+			return
+		}
+		if _, known := knownEntities[req.Entity]; !known {
+			purgeCounter.Inc("unknown", "unknown_entity")
+			http.Error(w, "unknown entity "+req.Entity, http.StatusBadRequest)
+			return
+		}
+		if !caches.has(req.Tenant) {
+			purgeCounter.Inc(req.Entity, "unknown_tenant")
+			http.Error(w, "unknown tenant "+req.Tenant, http.StatusBadRequest)
+			return
+		}
+		target := req.target()
+		n, err := caches.Purge(r.Context(), target)
+		if err != nil {
+			log.Printf("[CachePurge] purge %s failed after %d keys: %v", target, n, err)
+			purgeCounter.Inc(req.Entity, "error")
+			http.Error(w, "purge failed", http.StatusInternalServerError)
+			return
+		}
+		if err := cdn.Purge(r.Context(), surrogateKeys(req)); err != nil {
+			log.Printf("[CachePurge] purge %s: %v", target, err)
+			purgeCounter.Inc(req.Entity, "cdn_error")
+			http.Error(w, "cdn purge failed", http.StatusBadGateway)
+			return
+		}
+		log.Printf("[CachePurge] purged %d keys for %s (HTTP)", n, target)
+		purgeCounter.Inc(req.Entity, "ok")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(map[string]any{"entity": req.Entity, "tenant": req.Tenant, "deleted": n})
+	})
+}
@@ -0,0 +1,164 @@
+// Package changefeed polls the repo for changed posts, externals and topics
+// and publishes a content-change event for each one to Pub/Sub, so
+// downstream consumers don't have to poll the API themselves.
+package changefeed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"go-story/internal/data"
+	"go-story/internal/metrics"
+	"go-story/internal/pubsub"
+)
+
+// Actions of an Event.
+const (
+	// ActionPublished 第一次發布（topic 為建立）後的第一個變更
+	ActionPublished = "published"
+	// ActionUpdated 已發布內容的修改
+	ActionUpdated = "updated"
+	// ActionUnpublished 不再是 published（下架、改回草稿等）
+	ActionUnpublished = "unpublished"
+)
+
+// pollBatch 每次查詢的變更數，有更多時會在同一輪繼續查詢
+const pollBatch = 100
+
+var (
+	publishedCounter = metrics.NewCounter(
+		"go_story_change_events_published_total",
+		"Content-change events published to Pub/Sub.",
+		"entity", "action",
+	)
+	failuresCounter = metrics.NewCounter(
+		"go_story_change_events_failures_total",
+		"Change-detection polls that failed to query or publish.",
+		"stage",
+	)
+)
+
+// Event is the JSON body of a published message.
+type Event struct {
+	Entity    string `json:"entity"`
+	ID        string `json:"id"`
+	Slug      string `json:"slug"`
+	Action    string `json:"action"`
+	UpdatedAt string `json:"updatedAt"`
+	// Tenant 為 tenant 的 cache_prefix，主站為空
+	Tenant string `json:"tenant,omitempty"`
+}
+
+// Watcher polls for changes every Interval and publishes them to Topic.
+// Events are at-least-once: a failed publish is retried on the next poll,
+// so consumers should dedupe on (entity, id, updatedAt).
+type Watcher struct {
+	Repo     *data.Repo
+	Client   *pubsub.Client
+	Topic    string
+	Interval time.Duration
+	// Tenant 標記事件來自哪個 tenant（cache_prefix），主站為空
+	Tenant string
+
+	// since 為啟動時的 watermark，cursor 為最後一個成功發送的變更
+	since  time.Time
+	cursor *data.ChangeCursor
+}
+
+// Start begins polling until ctx is done. Changes made before Start (minus
+// one interval, to cover a restart) are not published.
+func (w *Watcher) Start(ctx context.Context) {
+	if w.Interval <= 0 || w.Topic == "" {
+		return
+	}
+	w.since = time.Now().Add(-w.Interval)
+
+	log.Printf("[ChangeFeed] Publishing content changes%s to %s every %v", tenantSuffix(w.Tenant), w.Topic, w.Interval)
+	go func() {
+		ticker := time.NewTicker(w.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := w.poll(ctx); err != nil {
+					log.Printf("[ChangeFeed] %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// poll 發送目前所有未發送的變更；發送成功才前進 cursor
+func (w *Watcher) poll(ctx context.Context) error {
+	for {
+		changes, err := w.Repo.QueryChanges(ctx, w.since, w.cursor, pollBatch)
+		if err != nil {
+			failuresCounter.Inc("query")
+			return fmt.Errorf("query changes: %w", err)
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+
+		msgs := make([]pubsub.Message, 0, len(changes))
+		for _, c := range changes {
+			msg, err := message(c, w.Tenant)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		sent, err := w.Client.Publish(ctx, w.Topic, msgs)
+		// 已接受的部分仍前進 cursor，避免重送
+		if sent > 0 {
+			last, perr := data.ParseChangeCursor(changes[sent-1].Cursor)
+			if perr != nil {
+				return perr
+			}
+			w.cursor = &last
+			for _, m := range msgs[:sent] {
+				publishedCounter.Inc(m.Attributes["entity"], m.Attributes["action"])
+			}
+		}
+		if err != nil {
+			failuresCounter.Inc("publish")
+			return err
+		}
+		if len(changes) < pollBatch {
+			return nil
+		}
+	}
+}
+
+// message 將變更轉為 Pub/Sub 訊息；attributes 重複 entity 與 action，方便以 subscription filter 過濾
+func message(c data.StoryChange, tenant string) (pubsub.Message, error) {
+	action := ActionUpdated
+	switch {
+	case c.Deleted:
+		action = ActionUnpublished
+	case c.Fresh:
+		action = ActionPublished
+	}
+	event := Event{Entity: c.Kind, ID: c.ID, Slug: c.Slug, Action: action, UpdatedAt: c.UpdatedAt, Tenant: tenant}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return pubsub.Message{}, fmt.Errorf("encode change event: %w", err)
+	}
+	attrs := map[string]string{"entity": event.Entity, "action": event.Action}
+	if tenant != "" {
+		attrs["tenant"] = tenant
+	}
+	return pubsub.Message{Data: body, Attributes: attrs}, nil
+}
+
+func tenantSuffix(tenant string) string {
+	if tenant == "" {
+		return ""
+	}
+	return " of tenant " + tenant
+}
@@ -2,92 +2,826 @@ package config
 
 import (
 	"fmt"
+	"math"
+	"net"
+	"net/netip"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"time"
+	// 內嵌時區資料，確保 OUTPUT_TIMEZONE 在沒有 tzdata 的映像檔中也能使用
+	_ "time/tzdata"
 )
 
-// Config holds runtime configuration from environment.
+// Config holds runtime configuration from environment and optional config file.
 type Config struct {
-	// DATABASE_URL: Postgres 連線字串 (必填)
+	// DATABASE_URL: Postgres 連線字串 (必填，MOCK_MODE=true 時不需要)
 	DatabaseURL string
 	// STATICS_HOST: 靜態圖片 host，例如 https://v3-statics-dev.mirrormedia.mg/images (必填)
 	StaticsHost string
 	// PORT: 服務監聽埠，未設定時預設 8080 (選填)
 	Port string
+	// LISTEN: 服務監聽位址，可為 unix:///path/to.sock（Unix domain socket）或 tcp://host:port，未設定時監聽 PORT (選填)
+	Listen string
+	// LISTEN_SOCKET_MODE: Unix domain socket 的檔案權限（八進位），預設為 0660 (選填)
+	ListenSocketMode os.FileMode
+	// TLS_CERT_FILE: HTTPS 憑證檔路徑（PEM，可含中繼憑證），需與 TLS_KEY_FILE 一起設定，設定後 PORT 改為 HTTPS (選填)
+	TLSCertFile string
+	// TLS_KEY_FILE: HTTPS 私鑰檔路徑（PEM），需與 TLS_CERT_FILE 一起設定 (選填)
+	TLSKeyFile string
+	// TLS_AUTOCERT_DOMAINS: 以 Let's Encrypt 自動申請憑證的網域，以逗號分隔，與 TLS_CERT_FILE 擇一，設定後 PORT 改為 HTTPS (選填)
+	TLSAutocertDomains []string
+	// TLS_AUTOCERT_CACHE_DIR: 自動申請的憑證與帳號金鑰的存放目錄，預設為 autocert-cache (選填)
+	TLSAutocertCacheDir string
+	// TLS_AUTOCERT_EMAIL: 向 Let's Encrypt 登記的聯絡信箱，用於到期通知 (選填)
+	TLSAutocertEmail string
+	// TLS_REDIRECT_PORT: 啟用 HTTPS 時另外監聽的 HTTP 埠，將請求轉址到 HTTPS 並回應 ACME HTTP-01 驗證 (選填)
+	TLSRedirectPort string
 	// GO_ENV: 執行環境 (dev/staging/prod)，預設為 dev (選填)
 	GoEnv string
 	// REDIS_ENABLED: 是否啟用 Redis cache，預設為 false (選填)
 	RedisEnabled bool
-	// REDIS_URL: Redis 連線字串，例如 redis://localhost:6379/0 (選填，當 REDIS_ENABLED=true 時建議設定)
+	// REDIS_URL: Redis 連線字串，例如 redis://localhost:6379/0 (選填，當 REDIS_ENABLED=true 時必填)
 	RedisURL string
 	// REDIS_TTL: Cache TTL (秒)，預設為 3600 (選填)
 	RedisTTL int
+	// REDIS_TTL_JITTER: Cache TTL 隨機增減的百分比，預設為 10（即 ±10%），0 為不調整 (選填)
+	RedisTTLJitter int
+	// PROBE_REFERENCE_URL: 定期 parity 檢查的參考 GQL endpoint，設定後才會啟用背景排程 (選填)
+	ProbeReferenceURL string
+	// PROBE_SELF_URL: 定期檢查時本服務的 GQL endpoint，預設為 http://127.0.0.1:{PORT}/api/graphql (選填)
+	ProbeSelfURL string
+	// PROBE_INTERVAL_MINUTES: 定期檢查的間隔 (分鐘)，預設為 10 (選填)
+	ProbeIntervalMinutes int
+	// SECRET_REFRESH_MINUTES: 定期重新讀取 sm:// secret 的間隔 (分鐘)，0 表示不更新，預設為 0 (選填)
+	SecretRefreshMinutes int
+	// DB_MAX_OPEN_CONNS: DB 最大連線數，預設為 10 (選填)
+	DBMaxOpenConns int
+	// DB_MAX_IDLE_CONNS: DB 最大閒置連線數，預設為 5 (選填)
+	DBMaxIdleConns int
+	// DB_CONN_MAX_LIFETIME: DB 連線最長存活時間 (秒)，0 表示不限制，預設為 0 (選填)
+	DBConnMaxLifetime int
+	// DB_QUERY_TIMEOUT: 每個查詢的 timeout (秒)，0 表示使用程式內預設值，預設為 0 (選填)
+	DBQueryTimeout int
+	// DB_PING_TIMEOUT: 啟動時 ping DB 的 timeout (秒)，預設為 5 (選填)
+	DBPingTimeout int
+	// GQL_MAX_TAKE: 單次查詢 take 上限，未指定 take 時也以此為上限，預設為 100 (選填)
+	GQLMaxTake int
+	// GQL_MAX_SKIP: 查詢 skip 上限，預設為 10000 (選填)
+	GQLMaxSkip int
+	// GQL_KEYSTONE_PARITY: 是否重現舊版 Keystone 的 null / [] 回傳語意，預設為 false (選填)
+	GQLKeystoneParity bool
+	// EXTERNAL_SANITIZE_HTML: 是否過濾 external content 中的 script/iframe 等 HTML，預設為 true (選填)
+	ExternalSanitizeHTML bool
+	// EXTERNAL_EMBED_HOSTS: 過濾時保留的 iframe 網域，以逗號分隔，預設為常見影音/社群網域 (選填)
+	ExternalEmbedHosts []string
+	// OG_IMAGE_FALLBACK: og_image 為 null 時是否改回傳 heroImage，預設為 false (選填)
+	OgImageFallback bool
+	// OUTPUT_TIMEZONE: 輸出時間的時區，例如 Asia/Taipei，預設為 UTC (選填)
+	OutputTimezone string
+	// OUTPUT_TIME_LAYOUT: 輸出時間的 Go time layout，預設為 2006-01-02T15:04:05.000Z07:00 (選填)
+	OutputTimeLayout string
+	// HOMEPAGE_SECTIONS: 首頁 bundle 查詢的 section slug，以逗號分隔並依此順序輸出，預設為主要 section (選填)
+	HomepageSections []string
+	// HOMEPAGE_POSTS_PER_SECTION: 首頁每個 section 的文章數，預設為 6 (選填)
+	HomepagePostsPerSection int
+	// SECTION_PARTNERS: sectionPage 併入外稿的 partner，格式為 <section slug>=<partner slug>|<partner slug>,...；未列出的 section 只有文章 (選填)
+	SectionPartners map[string][]string
+	// ERROR_REPORTING_ENABLED: 是否將 GraphQL 錯誤與 panic 以 GCP Error Reporting 格式輸出到 stderr，預設為 false (選填)
+	ErrorReportingEnabled bool
+	// ERROR_REPORTING_SERVICE: Error Reporting 的 service 名稱，預設為 go-story (選填)
+	ErrorReportingService string
+	// GOOGLE_CLOUD_PROJECT: GCP project id，用於 log 中的 trace 欄位（projects/<id>/traces/<trace id>），未設定時只記錄 trace id (選填)
+	GoogleCloudProject string
+	// LOG_FORMAT: 日誌格式，text 或 json（Cloud Logging 的結構化 JSON），預設為 text (選填)
+	LogFormat string
+	// SQL_TRACE_COMMENTS: 是否在 SQL 後附加 /*op=...,req=...*/ 追蹤註解，預設為 false (選填)
+	SQLTraceComments bool
+	// GOMEMLIMIT: Go runtime 的記憶體上限，格式同 Go 的 GOMEMLIMIT（例如 450MiB、off），未設定時取容器記憶體上限的 90% (選填)
+	MemoryLimit int64
+	// GQL_RESOLVER_METRICS: 是否在 /metrics 輸出每個 resolver 的耗時 histogram，預設為 true (選填)
+	GQLResolverMetrics bool
+	// LOAD_SHED_MAX_IN_FLIGHT: 進行中的 DB 查詢數達到此值時，拒絕未驗證的列表查詢，預設為 0（不檢查）(選填)
+	LoadShedMaxInFlight int
+	// LOAD_SHED_MAX_LATENCY_MS: 近期 DB 查詢延遲（毫秒）達到此值時，拒絕未驗證的列表查詢，預設為 0（不檢查）(選填)
+	LoadShedMaxLatencyMS int
+	// LOAD_SHED_RETRY_AFTER: 拒絕時回應的 Retry-After 秒數，預設為 5 (選填)
+	LoadShedRetryAfter int
+	// DB_WARM_CONNS: 啟動時預先建立並測試的 DB 連線數，預設為 0（不預熱）(選填)
+	DBWarmConns int
+	// WARMUP_CACHE: 啟動時是否先執行內建的 probe 查詢以預熱 Redis cache，預設為 false (選填)
+	WarmupCache bool
+	// WARMUP_TIMEOUT: 啟動預熱的時間上限（秒），預設為 30 (選填)
+	WarmupTimeout int
+	// ADMIN_TOKEN: /debug/*、/export/* 端點與 GraphQL admin mutation 需要的 Bearer token，未設定時這些端點一律回應 404 (選填)
+	AdminToken string
+	// ADMIN_ADDR: 管理端點（/metrics、/debug/*、/export/*、/purge 與 pprof）的監聽位址 host:port，設定後這些端點只在此位址提供 (選填)
+	AdminAddr string
+	// ADMIN_ALLOW_CIDRS: 允許存取管理端點與 /probe 的來源 IP 範圍（逗號分隔的 CIDR 或 IP），其他來源回應 403；未設定時不限制來源 (選填)
+	AdminAllowCIDRs []netip.Prefix
+	// TRUSTED_PROXY_CIDRS: 可信任其 X-Forwarded-For 的反向代理 IP 範圍（逗號分隔），未設定時以連線的來源 IP 判斷 (選填)
+	TrustedProxyCIDRs []netip.Prefix
+	// PROBE_REQUIRE_TOKEN: /probe 是否需要 ADMIN_TOKEN，預設為 false；GO_ENV=prod 且未設定 ADMIN_ALLOW_CIDRS 時預設為 true (選填)
+	ProbeRequireToken bool
+	// API_KEYS: 合作夥伴的 API key，格式為 <client 名稱>=<key>,...，請求以 X-API-Key header 帶入；未帶 key 的請求不受配額限制 (選填)
+	APIKeys map[string]string
+	// API_QUOTA_HOURLY: 每個 API client 每小時的請求上限，預設為 0（不限制）(選填)
+	APIQuotaHourly int
+	// API_QUOTA_DAILY: 每個 API client 每天（UTC）的請求上限，預設為 0（不限制）(選填)
+	APIQuotaDaily int
+	// WS_MAX_OPERATIONS: /api/graphql/ws 單一連線同時執行的 operation 上限，預設為 20 (選填)
+	WSMaxOperations int
+	// WS_KEEPALIVE: /api/graphql/ws 送出 ping 的間隔（秒），預設為 15 (選填)
+	WSKeepAlive int
+	// PERSISTED_QUERIES_FILE: persisted query allowlist（Apollo manifest 格式）的本機路徑或 gs://bucket/object (選填)
+	PersistedQueriesFile string
+	// PERSISTED_QUERIES_KEY: 驗證 allowlist 簽章（<檔案>.sig）的 HMAC-SHA256 金鑰，設定 PERSISTED_QUERIES_FILE 時必填
+	PersistedQueriesKey string
+	// PERSISTED_QUERIES_ONLY: 是否只執行 allowlist 中的 operation，僅在 GO_ENV=prod 生效，預設為 false (選填)
+	PersistedQueriesOnly bool
+	// PERSISTED_QUERIES_REFRESH_MINUTES: 重新讀取 allowlist 的間隔（分鐘），0 表示不重新讀取，預設為 5 (選填)
+	PersistedQueriesRefreshMinutes int
+	// SURROGATE_KEYS: 是否在回應加上 Surrogate-Key / Cache-Tag header，列出回應中的 entity id，預設為 false (選填)
+	SurrogateKeys bool
+	// GQL_REQUEST_LOG: 是否為每個 GraphQL 請求記錄一行 operationName、查詢 hash、entity 數量與耗時，預設為 false (選填)
+	GQLRequestLog bool
+	// GQL_REQUEST_LOG_VARIABLES_PERCENT: 請求日誌同時記錄 variables 的比例（%），token 等欄位會遮蔽，預設為 0 (選填)
+	GQLRequestLogVariablesPercent int
+	// GRPC_PORT: gRPC 讀取服務的監聽埠，未設定時不啟動 gRPC (選填)
+	GRPCPort string
+	// PUBSUB_CHANGE_TOPIC: 發送內容變更事件的 Pub/Sub topic（projects/<p>/topics/<t>），未設定時不發送 (選填)
+	PubSubChangeTopic string
+	// CHANGE_POLL_SECONDS: 偵測內容變更的間隔（秒），預設為 30 (選填)
+	ChangePollSeconds int
+	// PUBSUB_PURGE_SUBSCRIPTION: 接收 CMS cache purge 訊息的 Pub/Sub subscription（projects/<p>/subscriptions/<s>），未設定時不訂閱 (選填)
+	PubSubPurgeSubscription string
+	// CDN_PURGE_URL: 清除快取時以 Surrogate-Key header POST 的 CDN purge 端點（例如 https://api.fastly.com/service/<id>/purge），未設定時不清除 CDN (選填)
+	CDNPurgeURL string
+	// CDN_PURGE_TOKEN: CDN purge 的 API token，以 Fastly-Key header 送出 (選填)
+	CDNPurgeToken string
+	// VIEW_COUNTS: 是否提供 recordPostView mutation 與 Post.viewsCount，需要先建立 PostViews table，預設為 false (選填)
+	ViewCounts bool
+	// VIEW_FLUSH_SECONDS: 將 Redis 中累積的瀏覽次數寫入 DB 的間隔（秒），預設為 60 (選填)
+	ViewFlushSeconds int
+	// IMAGE_AVIF: 是否輸出 Photo.resizedAvif（圖片處理流程已產生 AVIF 時開啟），預設為 false (選填)
+	ImageAvif bool
+	// IMAGE_FORMAT_COLUMNS: Image table 中標示衍生格式是否存在的 boolean 欄位，格式為 webp=<欄位>,avif=<欄位>；未列出的格式視為一律存在 (選填)
+	ImageFormatColumns map[string]string
+	// GQL_RESPONSE_CACHE: 整個 GraphQL 回應快取於 Redis 的 operation 與秒數，格式為 <operationName>=<秒數>,...；只快取未帶 Authorization 的 query (選填)
+	GQLResponseCache map[string]int
+	// GQL_COALESCE: 是否將同時進行且相同的匿名 query 合併為一次執行，預設為 false (選填)
+	GQLCoalesce bool
+	// GQL_DOCUMENT_CACHE_SIZE: 快取已解析並驗證的查詢數上限，相同查詢不再重新 parse 與驗證，0 表示停用，預設為 1000 (選填)
+	GQLDocumentCacheSize int
+	// GQL_CACHE_CONTROL: 是否依欄位的 @cacheControl hint 輸出 Cache-Control 並限制回應快取的 TTL，預設為 false (選填)
+	GQLCacheControl bool
+	// GQL_DEFAULT_MAX_AGE: 未設定 hint 的 root 欄位的 maxAge 秒數，預設為 0 (選填)
+	GQLDefaultMaxAge int
+	// MOCK_MODE: 不連線 DB，改以記憶體中的固定示範資料回應所有查詢，供前端本機開發使用，預設為 false (選填)
+	MockMode bool
+	// SHADOW_REFERENCE_URL: 設定後將抽樣的 GraphQL 查詢在背景送往此 endpoint 並比對回應，結果記錄於 log 與 metrics (選填)
+	ShadowReferenceURL string
+	// SHADOW_SAMPLE_PERCENT: 送往 SHADOW_REFERENCE_URL 比對的查詢百分比，預設為 10 (選填)
+	ShadowSamplePercent int
+	// SHADOW_IGNORE_PATHS: 比對前忽略的 JSON 路徑，以逗號分隔，例如 data.posts.*.updatedAt (選填)
+	ShadowIgnorePaths []string
+	// TENANTS_FILE: 依 Host header 切換站台的 tenant 設定檔（YAML/JSON），每個 tenant 有自己的 DB、靜態圖片 host 與 cache key prefix (選填)
+	TenantsFile string
+	// Tenants 為 TENANTS_FILE 的內容
+	Tenants []Tenant
+	// SecretRefs 記錄以 sm:// 參照設定的 key 與其參照
+	SecretRefs map[string]string
 }
 
-// Load reads required environment variables.
+// defaultHomepageSections 首頁預設顯示的主要 section
+const defaultHomepageSections = "news,entertainment,businessmoney,people,international,foodtravel,mafalda,culture,carandwatch"
+
+// Keys lists every configuration key. main exposes each one as a
+// command-line flag (e.g. REDIS_URL -> --redis-url).
+var Keys = []string{
+	"CONFIG_FILE",
+	"DATABASE_URL",
+	"STATICS_HOST",
+	"PORT",
+	"LISTEN",
+	"LISTEN_SOCKET_MODE",
+	"TLS_CERT_FILE",
+	"TLS_KEY_FILE",
+	"TLS_AUTOCERT_DOMAINS",
+	"TLS_AUTOCERT_CACHE_DIR",
+	"TLS_AUTOCERT_EMAIL",
+	"TLS_REDIRECT_PORT",
+	"GO_ENV",
+	"REDIS_ENABLED",
+	"REDIS_URL",
+	"REDIS_TTL",
+	"REDIS_TTL_JITTER",
+	"PROBE_REFERENCE_URL",
+	"PROBE_SELF_URL",
+	"PROBE_INTERVAL_MINUTES",
+	"SECRET_REFRESH_MINUTES",
+	"DB_MAX_OPEN_CONNS",
+	"DB_MAX_IDLE_CONNS",
+	"DB_CONN_MAX_LIFETIME",
+	"DB_QUERY_TIMEOUT",
+	"DB_PING_TIMEOUT",
+	"GQL_MAX_TAKE",
+	"GQL_MAX_SKIP",
+	"GQL_KEYSTONE_PARITY",
+	"EXTERNAL_SANITIZE_HTML",
+	"EXTERNAL_EMBED_HOSTS",
+	"OG_IMAGE_FALLBACK",
+	"OUTPUT_TIMEZONE",
+	"OUTPUT_TIME_LAYOUT",
+	"HOMEPAGE_SECTIONS",
+	"HOMEPAGE_POSTS_PER_SECTION",
+	"SECTION_PARTNERS",
+	"ERROR_REPORTING_ENABLED",
+	"ERROR_REPORTING_SERVICE",
+	"GOOGLE_CLOUD_PROJECT",
+	"LOG_FORMAT",
+	"SQL_TRACE_COMMENTS",
+	"GOMEMLIMIT",
+	"GQL_RESOLVER_METRICS",
+	"LOAD_SHED_MAX_IN_FLIGHT",
+	"LOAD_SHED_MAX_LATENCY_MS",
+	"LOAD_SHED_RETRY_AFTER",
+	"DB_WARM_CONNS",
+	"WARMUP_CACHE",
+	"WARMUP_TIMEOUT",
+	"ADMIN_TOKEN",
+	"ADMIN_ADDR",
+	"ADMIN_ALLOW_CIDRS",
+	"TRUSTED_PROXY_CIDRS",
+	"PROBE_REQUIRE_TOKEN",
+	"API_KEYS",
+	"API_QUOTA_HOURLY",
+	"API_QUOTA_DAILY",
+	"WS_MAX_OPERATIONS",
+	"WS_KEEPALIVE",
+	"PERSISTED_QUERIES_FILE",
+	"PERSISTED_QUERIES_KEY",
+	"PERSISTED_QUERIES_ONLY",
+	"PERSISTED_QUERIES_REFRESH_MINUTES",
+	"SURROGATE_KEYS",
+	"GQL_REQUEST_LOG",
+	"GQL_REQUEST_LOG_VARIABLES_PERCENT",
+	"GRPC_PORT",
+	"PUBSUB_CHANGE_TOPIC",
+	"CHANGE_POLL_SECONDS",
+	"PUBSUB_PURGE_SUBSCRIPTION",
+	"CDN_PURGE_URL",
+	"CDN_PURGE_TOKEN",
+	"VIEW_COUNTS",
+	"VIEW_FLUSH_SECONDS",
+	"IMAGE_AVIF",
+	"IMAGE_FORMAT_COLUMNS",
+	"GQL_RESPONSE_CACHE",
+	"GQL_COALESCE",
+	"GQL_DOCUMENT_CACHE_SIZE",
+	"GQL_CACHE_CONTROL",
+	"GQL_DEFAULT_MAX_AGE",
+	"MOCK_MODE",
+	"SHADOW_REFERENCE_URL",
+	"SHADOW_SAMPLE_PERCENT",
+	"SHADOW_IGNORE_PATHS",
+	"TENANTS_FILE",
+}
+
+// Load reads configuration from environment variables.
+// If CONFIG_FILE points to a YAML/JSON file, its keys (named like the
+// environment variables) are used as defaults and env values override them.
+// Any value may be a Secret Manager reference (sm://projects/.../secrets/...),
+// which is resolved at startup.
 // DATABASE_URL and STATICS_HOST are mandatory.
 // PORT is optional; defaults to "8080".
+// LISTEN is optional; unix:///path or tcp://host:port replaces the PORT listener.
+// LISTEN_SOCKET_MODE is optional; defaults to 0660.
+// TLS_CERT_FILE / TLS_KEY_FILE are optional; PORT serves HTTPS with them.
+// TLS_AUTOCERT_DOMAINS is optional; PORT serves HTTPS with Let's Encrypt certificates for them.
+// TLS_AUTOCERT_CACHE_DIR is optional; defaults to "autocert-cache".
+// TLS_AUTOCERT_EMAIL is optional.
+// TLS_REDIRECT_PORT is optional; requires TLS and redirects plain HTTP to HTTPS.
 // GO_ENV is optional; defaults to "dev".
 // REDIS_ENABLED is optional; defaults to false.
 // REDIS_URL is optional; required if REDIS_ENABLED=true.
 // REDIS_TTL is optional; defaults to 3600 seconds.
+// REDIS_TTL_JITTER is optional; defaults to 10 percent.
+// PROBE_REFERENCE_URL is optional; enables scheduled parity checks.
+// PROBE_SELF_URL is optional; defaults to the local /api/graphql.
+// PROBE_INTERVAL_MINUTES is optional; defaults to 10 minutes.
+// SECRET_REFRESH_MINUTES is optional; defaults to 0 (no refresh).
+// DB_MAX_OPEN_CONNS / DB_MAX_IDLE_CONNS are optional; default to 10 / 5.
+// DB_CONN_MAX_LIFETIME / DB_QUERY_TIMEOUT are optional seconds; 0 keeps the defaults.
+// DB_PING_TIMEOUT is optional; defaults to 5 seconds.
+// GQL_MAX_TAKE / GQL_MAX_SKIP are optional; default to 100 / 10000.
+// GQL_KEYSTONE_PARITY is optional; defaults to false.
+// EXTERNAL_SANITIZE_HTML is optional; defaults to true.
+// EXTERNAL_EMBED_HOSTS is optional; a comma-separated host list.
+// OG_IMAGE_FALLBACK is optional; defaults to false.
+// OUTPUT_TIMEZONE / OUTPUT_TIME_LAYOUT are optional; default to UTC with millisecond precision.
+// HOMEPAGE_SECTIONS is optional; a comma-separated section slug list.
+// HOMEPAGE_POSTS_PER_SECTION is optional; defaults to 6.
+// SECTION_PARTNERS is optional; section pages list only posts without it.
+// ERROR_REPORTING_ENABLED is optional; defaults to false.
+// ERROR_REPORTING_SERVICE is optional; defaults to "go-story".
+// GOOGLE_CLOUD_PROJECT is optional; logs carry only the bare trace id without it.
+// LOG_FORMAT is optional; defaults to "text".
+// SQL_TRACE_COMMENTS is optional; defaults to false.
+// GOMEMLIMIT is optional; defaults to 90% of the container memory limit.
+// GQL_RESOLVER_METRICS is optional; defaults to true.
+// LOAD_SHED_MAX_IN_FLIGHT / LOAD_SHED_MAX_LATENCY_MS are optional; 0 disables each check.
+// LOAD_SHED_RETRY_AFTER is optional; defaults to 5 seconds.
+// DB_WARM_CONNS is optional; defaults to 0 (no warm-up), at most DB_MAX_OPEN_CONNS.
+// WARMUP_CACHE is optional; defaults to false.
+// WARMUP_TIMEOUT is optional; defaults to 30 seconds.
+// ADMIN_TOKEN is optional; the /debug and /export endpoints and admin mutations are disabled without it.
+// ADMIN_ADDR is optional; admin endpoints stay on PORT and pprof is disabled without it.
+// ADMIN_ALLOW_CIDRS / TRUSTED_PROXY_CIDRS are optional; admin endpoints and /probe accept any source IP without them.
+// GO_ENV=prod requires ADMIN_TOKEN or ADMIN_ALLOW_CIDRS.
+// PROBE_REQUIRE_TOKEN is optional; defaults to false, or true in prod without ADMIN_ALLOW_CIDRS.
+// API_KEYS is optional; API_QUOTA_HOURLY / API_QUOTA_DAILY default to 0 (unlimited) and need it.
+// WS_MAX_OPERATIONS / WS_KEEPALIVE are optional; default to 20 / 15 seconds.
+// PERSISTED_QUERIES_FILE is optional; PERSISTED_QUERIES_KEY is required with it.
+// PERSISTED_QUERIES_ONLY is optional; defaults to false and only applies when GO_ENV=prod.
+// PERSISTED_QUERIES_REFRESH_MINUTES is optional; defaults to 5 minutes.
+// SURROGATE_KEYS is optional; defaults to false.
+// GQL_REQUEST_LOG / GQL_REQUEST_LOG_VARIABLES_PERCENT are optional; default to false / 0.
+// GRPC_PORT is optional; the gRPC service is disabled without it.
+// PUBSUB_CHANGE_TOPIC is optional; content-change events are not published without it.
+// CHANGE_POLL_SECONDS is optional; defaults to 30 seconds.
+// PUBSUB_PURGE_SUBSCRIPTION is optional; cache purge messages are not consumed without it.
+// CDN_PURGE_URL / CDN_PURGE_TOKEN are optional; purges don't reach the CDN without CDN_PURGE_URL.
+// VIEW_COUNTS is optional; defaults to false.
+// VIEW_FLUSH_SECONDS is optional; defaults to 60 seconds.
+// IMAGE_AVIF is optional; defaults to false.
+// IMAGE_FORMAT_COLUMNS is optional; every variant is assumed to exist without it.
+// GQL_RESPONSE_CACHE is optional; no response is cached without it.
+// GQL_COALESCE is optional; defaults to false.
+// GQL_DOCUMENT_CACHE_SIZE is optional; defaults to 1000 (0 disables it).
+// GQL_CACHE_CONTROL / GQL_DEFAULT_MAX_AGE are optional; default to false / 0.
+// MOCK_MODE is optional; defaults to false. DATABASE_URL is not required with it.
+// SHADOW_REFERENCE_URL is optional; enables shadow traffic comparison.
+// SHADOW_SAMPLE_PERCENT is optional; defaults to 10.
+// TENANTS_FILE is optional; every request is served from the main site without it.
 func Load() (Config, error) {
-	cfg := Config{
-		DatabaseURL: os.Getenv("DATABASE_URL"),
-		StaticsHost: os.Getenv("STATICS_HOST"),
-		Port:        os.Getenv("PORT"),
-		GoEnv:       os.Getenv("GO_ENV"),
-		RedisURL:    os.Getenv("REDIS_URL"),
+	return LoadWithOverrides(nil)
+}
+
+// LoadWithOverrides is like Load, but values in overrides (keyed like the
+// environment variables, e.g. from command-line flags) take precedence over
+// both environment variables and the config file.
+func LoadWithOverrides(overrides map[string]string) (Config, error) {
+	src := &source{overrides: overrides}
+	if path, _ := src.lookup("CONFIG_FILE"); path != "" {
+		file, err := loadFile(path)
+		if err != nil {
+			return Config{}, err
+		}
+		src.file = file
 	}
 
-	if cfg.DatabaseURL == "" {
-		return Config{}, fmt.Errorf("DATABASE_URL not set")
+	// 收集所有設定問題，一次回報
+	errs := &ValidationError{}
+
+	cfg := Config{
+		DatabaseURL: src.get("DATABASE_URL"),
+		StaticsHost: src.get("STATICS_HOST"),
+		Port:        src.get("PORT"),
+		GoEnv:       src.get("GO_ENV"),
+		RedisURL:    src.get("REDIS_URL"),
+
+		ProbeReferenceURL: src.get("PROBE_REFERENCE_URL"),
+		ProbeSelfURL:      src.get("PROBE_SELF_URL"),
+	}
+	if src.err != nil {
+		return Config{}, src.err
 	}
 
-	// 自動處理 DATABASE_URL 的編碼
-	encodedURL, err := encodeDatabaseURL(cfg.DatabaseURL)
-	if err != nil {
-		return Config{}, fmt.Errorf("failed to encode DATABASE_URL: %w", err)
+	cfg.MockMode = src.boolValue("MOCK_MODE", false, errs)
+	if cfg.DatabaseURL == "" {
+		if !cfg.MockMode {
+			errs.add("DATABASE_URL not set")
+		}
+	} else {
+		// 自動處理 DATABASE_URL 的編碼
+		encodedURL, err := encodeDatabaseURL(cfg.DatabaseURL)
+		if err != nil {
+			errs.add("failed to encode DATABASE_URL: %v", err)
+		} else {
+			cfg.DatabaseURL = encodedURL
+			// keyword/value 格式 (host=... user=...) 不是 URL，不檢查
+			if strings.Contains(cfg.DatabaseURL, "://") {
+				errs.checkURL("DATABASE_URL", cfg.DatabaseURL, "postgres", "postgresql")
+			}
+		}
 	}
-	cfg.DatabaseURL = encodedURL
 
 	if cfg.StaticsHost == "" {
-		return Config{}, fmt.Errorf("STATICS_HOST not set")
+		errs.add("STATICS_HOST not set")
+	} else {
+		errs.checkURL("STATICS_HOST", cfg.StaticsHost, "http", "https")
 	}
 	if cfg.Port == "" {
 		cfg.Port = "8080"
 	}
+	if port, err := strconv.Atoi(cfg.Port); err != nil || port < 1 || port > 65535 {
+		errs.add("invalid PORT value %q: must be 1-65535", cfg.Port)
+	}
+
+	// 監聽位址：Unix domain socket 或指定 host 的 TCP
+	cfg.Listen = src.get("LISTEN")
+	if cfg.Listen != "" {
+		network, address := cfg.ListenAddr()
+		switch {
+		case network == "unix" && address == "":
+			errs.add("invalid LISTEN value %q: unix:// needs a socket path", cfg.Listen)
+		case network == "tcp":
+			if _, port, err := net.SplitHostPort(address); err != nil || port == "" {
+				errs.add("invalid LISTEN value %q: must be unix:///path/to.sock or tcp://host:port", cfg.Listen)
+			}
+		case network == "":
+			errs.add("invalid LISTEN value %q: must be unix:///path/to.sock or tcp://host:port", cfg.Listen)
+		}
+	}
+	cfg.ListenSocketMode = 0o660
+	if raw := src.get("LISTEN_SOCKET_MODE"); raw != "" {
+		mode, err := strconv.ParseUint(raw, 8, 32)
+		if err != nil || mode > 0o777 {
+			errs.add("invalid LISTEN_SOCKET_MODE value %q: must be an octal permission such as 0660", raw)
+		} else {
+			cfg.ListenSocketMode = os.FileMode(mode)
+		}
+	}
+
+	// HTTPS：憑證檔與自動申請擇一
+	cfg.TLSCertFile = src.get("TLS_CERT_FILE")
+	cfg.TLSKeyFile = src.get("TLS_KEY_FILE")
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		errs.add("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	for _, file := range []struct{ key, path string }{{"TLS_CERT_FILE", cfg.TLSCertFile}, {"TLS_KEY_FILE", cfg.TLSKeyFile}} {
+		if file.path == "" {
+			continue
+		}
+		if _, err := os.Stat(file.path); err != nil {
+			errs.add("invalid %s value %q: %v", file.key, file.path, err)
+		}
+	}
+	for _, domain := range strings.Split(src.get("TLS_AUTOCERT_DOMAINS"), ",") {
+		if domain = strings.TrimSpace(domain); domain != "" {
+			cfg.TLSAutocertDomains = append(cfg.TLSAutocertDomains, domain)
+		}
+	}
+	if len(cfg.TLSAutocertDomains) > 0 && cfg.TLSCertFile != "" {
+		errs.add("TLS_AUTOCERT_DOMAINS and TLS_CERT_FILE must not both be set")
+	}
+	cfg.TLSAutocertCacheDir = src.get("TLS_AUTOCERT_CACHE_DIR")
+	if cfg.TLSAutocertCacheDir == "" {
+		cfg.TLSAutocertCacheDir = "autocert-cache"
+	}
+	cfg.TLSAutocertEmail = src.get("TLS_AUTOCERT_EMAIL")
+	cfg.TLSRedirectPort = src.get("TLS_REDIRECT_PORT")
+	if cfg.TLSRedirectPort != "" {
+		if port, err := strconv.Atoi(cfg.TLSRedirectPort); err != nil || port < 1 || port > 65535 {
+			errs.add("invalid TLS_REDIRECT_PORT value %q: must be 1-65535", cfg.TLSRedirectPort)
+		} else if cfg.TLSRedirectPort == cfg.Port {
+			errs.add("TLS_REDIRECT_PORT must differ from PORT (%s)", cfg.Port)
+		} else if !cfg.TLSEnabled() {
+			errs.add("TLS_REDIRECT_PORT requires TLS_CERT_FILE or TLS_AUTOCERT_DOMAINS")
+		}
+	}
+
 	if cfg.GoEnv == "" {
 		cfg.GoEnv = "dev"
 	}
+	if cfg.GoEnv != "dev" && cfg.GoEnv != "staging" && cfg.GoEnv != "prod" {
+		errs.add("invalid GO_ENV value %q: must be dev, staging or prod", cfg.GoEnv)
+	}
 
 	// 解析 REDIS_ENABLED，預設為 false
-	redisEnabledStr := os.Getenv("REDIS_ENABLED")
-	if redisEnabledStr != "" {
-		enabled, err := strconv.ParseBool(redisEnabledStr)
-		if err != nil {
-			return Config{}, fmt.Errorf("invalid REDIS_ENABLED value: %v", err)
-		}
-		cfg.RedisEnabled = enabled
+	cfg.RedisEnabled = src.boolValue("REDIS_ENABLED", false, errs)
+	if cfg.RedisURL != "" {
+		errs.checkURL("REDIS_URL", cfg.RedisURL, "redis", "rediss")
+	} else if cfg.RedisEnabled {
+		errs.add("REDIS_URL must be set when REDIS_ENABLED=true")
 	}
 
-	// 解析 REDIS_TTL，預設為 3600 秒
-	redisTTLStr := os.Getenv("REDIS_TTL")
-	if redisTTLStr != "" {
-		ttl, err := strconv.Atoi(redisTTLStr)
-		if err != nil {
-			return Config{}, fmt.Errorf("invalid REDIS_TTL value: %v", err)
+	// 解析 REDIS_TTL，預設為 3600 秒 (1 小時)
+	cfg.RedisTTL = src.intValue("REDIS_TTL", 3600, 1, 7*24*3600, errs)
+	cfg.RedisTTLJitter = src.intValue("REDIS_TTL_JITTER", 10, 0, 50, errs)
+
+	// 解析 PROBE_INTERVAL_MINUTES，預設為 10 分鐘
+	cfg.ProbeIntervalMinutes = src.intValue("PROBE_INTERVAL_MINUTES", 10, 1, 24*60, errs)
+	if cfg.ProbeReferenceURL != "" {
+		errs.checkURL("PROBE_REFERENCE_URL", cfg.ProbeReferenceURL, "http", "https")
+	}
+	if cfg.ProbeSelfURL == "" {
+		cfg.ProbeSelfURL = fmt.Sprintf("http://127.0.0.1:%s/api/graphql", cfg.Port)
+		// 憑證不涵蓋 127.0.0.1，啟用 HTTPS 或改用 LISTEN 時需自行指定
+		if cfg.ProbeReferenceURL != "" && cfg.TLSEnabled() {
+			errs.add("PROBE_SELF_URL must be set when PROBE_REFERENCE_URL is set and PORT serves HTTPS")
+		} else if cfg.ProbeReferenceURL != "" && cfg.Listen != "" {
+			errs.add("PROBE_SELF_URL must be set when PROBE_REFERENCE_URL and LISTEN are set")
 		}
-		cfg.RedisTTL = ttl
 	} else {
-		cfg.RedisTTL = 3600 // 預設 1 小時
+		errs.checkURL("PROBE_SELF_URL", cfg.ProbeSelfURL, "http", "https")
 	}
 
+	// 解析 SECRET_REFRESH_MINUTES，預設為 0 (不更新)
+	cfg.SecretRefreshMinutes = src.intValue("SECRET_REFRESH_MINUTES", 0, 0, 24*60, errs)
+
+	// DB 連線池與 timeout 設定
+	cfg.DBMaxOpenConns = src.intValue("DB_MAX_OPEN_CONNS", 10, 1, 1000, errs)
+	cfg.DBMaxIdleConns = src.intValue("DB_MAX_IDLE_CONNS", 5, 0, 1000, errs)
+	if cfg.DBMaxIdleConns > cfg.DBMaxOpenConns {
+		errs.add("DB_MAX_IDLE_CONNS (%d) must not exceed DB_MAX_OPEN_CONNS (%d)", cfg.DBMaxIdleConns, cfg.DBMaxOpenConns)
+	}
+	cfg.DBConnMaxLifetime = src.intValue("DB_CONN_MAX_LIFETIME", 0, 0, 24*3600, errs)
+	cfg.DBQueryTimeout = src.intValue("DB_QUERY_TIMEOUT", 0, 0, 300, errs)
+	cfg.DBPingTimeout = src.intValue("DB_PING_TIMEOUT", 5, 1, 120, errs)
+
+	// GraphQL 分頁上限
+	cfg.GQLMaxTake = src.intValue("GQL_MAX_TAKE", 100, 1, 10000, errs)
+	cfg.GQLMaxSkip = src.intValue("GQL_MAX_SKIP", 10000, 0, 1000000, errs)
+	cfg.GQLKeystoneParity = src.boolValue("GQL_KEYSTONE_PARITY", false, errs)
+
+	// External HTML 過濾
+	cfg.ExternalSanitizeHTML = src.boolValue("EXTERNAL_SANITIZE_HTML", true, errs)
+	for _, host := range strings.Split(src.get("EXTERNAL_EMBED_HOSTS"), ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			cfg.ExternalEmbedHosts = append(cfg.ExternalEmbedHosts, host)
+		}
+	}
+
+	cfg.OgImageFallback = src.boolValue("OG_IMAGE_FALLBACK", false, errs)
+
+	// 輸出時間的時區與格式
+	cfg.OutputTimezone = src.get("OUTPUT_TIMEZONE")
+	if cfg.OutputTimezone == "" {
+		cfg.OutputTimezone = "UTC"
+	}
+	if _, err := time.LoadLocation(cfg.OutputTimezone); err != nil {
+		errs.add("invalid OUTPUT_TIMEZONE value %q: %v", cfg.OutputTimezone, err)
+	}
+	cfg.OutputTimeLayout = src.get("OUTPUT_TIME_LAYOUT")
+	if cfg.OutputTimeLayout == "" {
+		cfg.OutputTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+	}
+
+	// 首頁 bundle 查詢
+	homepageSections := src.get("HOMEPAGE_SECTIONS")
+	if homepageSections == "" {
+		homepageSections = defaultHomepageSections
+	}
+	for _, slug := range strings.Split(homepageSections, ",") {
+		if slug = strings.TrimSpace(slug); slug != "" {
+			cfg.HomepageSections = append(cfg.HomepageSections, slug)
+		}
+	}
+	cfg.HomepagePostsPerSection = src.intValue("HOMEPAGE_POSTS_PER_SECTION", 6, 1, 50, errs)
+	for _, pair := range strings.Split(src.get("SECTION_PARTNERS"), ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		section, list, _ := strings.Cut(pair, "=")
+		section = strings.TrimSpace(section)
+		var partners []string
+		for _, partner := range strings.Split(list, "|") {
+			if partner = strings.TrimSpace(partner); partner != "" {
+				partners = append(partners, partner)
+			}
+		}
+		if section == "" || len(partners) == 0 {
+			errs.add("invalid SECTION_PARTNERS entry %q: must be <section slug>=<partner slug>|<partner slug>", pair)
+			continue
+		}
+		if cfg.SectionPartners == nil {
+			cfg.SectionPartners = map[string][]string{}
+		}
+		cfg.SectionPartners[section] = append(cfg.SectionPartners[section], partners...)
+	}
+
+	// Error Reporting
+	cfg.ErrorReportingEnabled = src.boolValue("ERROR_REPORTING_ENABLED", false, errs)
+	cfg.ErrorReportingService = src.get("ERROR_REPORTING_SERVICE")
+	if cfg.ErrorReportingService == "" {
+		cfg.ErrorReportingService = "go-story"
+	}
+	cfg.GoogleCloudProject = src.get("GOOGLE_CLOUD_PROJECT")
+	cfg.LogFormat = strings.ToLower(src.get("LOG_FORMAT"))
+	switch cfg.LogFormat {
+	case "":
+		cfg.LogFormat = "text"
+	case "text", "json":
+	default:
+		errs.add("invalid LOG_FORMAT value %q: must be text or json", cfg.LogFormat)
+	}
+
+	cfg.SQLTraceComments = src.boolValue("SQL_TRACE_COMMENTS", false, errs)
+	cfg.MemoryLimit = src.byteSizeValue("GOMEMLIMIT", errs)
+	cfg.GQLResolverMetrics = src.boolValue("GQL_RESOLVER_METRICS", true, errs)
+
+	// Load shedding
+	cfg.LoadShedMaxInFlight = src.intValue("LOAD_SHED_MAX_IN_FLIGHT", 0, 0, 10000, errs)
+	cfg.LoadShedMaxLatencyMS = src.intValue("LOAD_SHED_MAX_LATENCY_MS", 0, 0, 600000, errs)
+	cfg.LoadShedRetryAfter = src.intValue("LOAD_SHED_RETRY_AFTER", 5, 1, 3600, errs)
+
+	// 啟動預熱
+	cfg.DBWarmConns = src.intValue("DB_WARM_CONNS", 0, 0, cfg.DBMaxOpenConns, errs)
+	cfg.WarmupCache = src.boolValue("WARMUP_CACHE", false, errs)
+	cfg.WarmupTimeout = src.intValue("WARMUP_TIMEOUT", 30, 1, 600, errs)
+
+	cfg.AdminToken = src.get("ADMIN_TOKEN")
+	cfg.AdminAddr = src.get("ADMIN_ADDR")
+	if cfg.AdminAddr != "" {
+		if _, port, err := net.SplitHostPort(cfg.AdminAddr); err != nil || port == "" {
+			errs.add("invalid ADMIN_ADDR value %q: must be host:port, e.g. 10.0.0.5:9090 or :9090", cfg.AdminAddr)
+		} else if port == cfg.Port || port == cfg.TLSRedirectPort {
+			errs.add("ADMIN_ADDR port must differ from PORT and TLS_REDIRECT_PORT")
+		}
+	}
+	cfg.AdminAllowCIDRs = src.prefixListValue("ADMIN_ALLOW_CIDRS", errs)
+	cfg.TrustedProxyCIDRs = src.prefixListValue("TRUSTED_PROXY_CIDRS", errs)
+	// prod 未限制來源 IP 時 /probe 預設需要 ADMIN_TOKEN，管理端點不可對任意來源開放
+	openAdmin := cfg.GoEnv == "prod" && len(cfg.AdminAllowCIDRs) == 0
+	cfg.ProbeRequireToken = src.boolValue("PROBE_REQUIRE_TOKEN", openAdmin, errs)
+	switch {
+	case openAdmin && cfg.AdminToken == "":
+		errs.add("GO_ENV=prod requires ADMIN_TOKEN or ADMIN_ALLOW_CIDRS: /probe and the admin endpoints would be open to any client")
+	case openAdmin && !cfg.ProbeRequireToken:
+		errs.add("PROBE_REQUIRE_TOKEN=false requires ADMIN_ALLOW_CIDRS when GO_ENV=prod")
+	case cfg.ProbeRequireToken && cfg.AdminToken == "":
+		errs.add("PROBE_REQUIRE_TOKEN requires ADMIN_TOKEN")
+	}
+
+	// 合作夥伴 API key 與配額，計數存於 Redis
+	seenKeys := map[string]bool{}
+	for _, pair := range strings.Split(src.get("API_KEYS"), ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, key, ok := strings.Cut(pair, "=")
+		name, key = strings.TrimSpace(name), strings.TrimSpace(key)
+		// 錯誤訊息只列出 client 名稱，不包含 key
+		if !ok || !validColumnName(name) || key == "" {
+			errs.add("invalid API_KEYS entry for %q: must be <client name>=<key> with a name of letters, digits or '_'", name)
+			continue
+		}
+		if _, dup := cfg.APIKeys[name]; dup || seenKeys[key] {
+			errs.add("API_KEYS: client %q or its key is listed more than once", name)
+			continue
+		}
+		if cfg.APIKeys == nil {
+			cfg.APIKeys = map[string]string{}
+		}
+		cfg.APIKeys[name] = key
+		seenKeys[key] = true
+	}
+	cfg.APIQuotaHourly = src.intValue("API_QUOTA_HOURLY", 0, 0, math.MaxInt32, errs)
+	cfg.APIQuotaDaily = src.intValue("API_QUOTA_DAILY", 0, 0, math.MaxInt32, errs)
+	if (cfg.APIQuotaHourly > 0 || cfg.APIQuotaDaily > 0) && len(cfg.APIKeys) == 0 {
+		errs.add("API_QUOTA_HOURLY and API_QUOTA_DAILY require API_KEYS")
+	}
+
+	// graphql-ws
+	cfg.WSMaxOperations = src.intValue("WS_MAX_OPERATIONS", 20, 1, 1000, errs)
+	cfg.WSKeepAlive = src.intValue("WS_KEEPALIVE", 15, 1, 300, errs)
+
+	// Persisted query allowlist
+	cfg.PersistedQueriesFile = src.get("PERSISTED_QUERIES_FILE")
+	cfg.PersistedQueriesKey = src.get("PERSISTED_QUERIES_KEY")
+	cfg.PersistedQueriesOnly = src.boolValue("PERSISTED_QUERIES_ONLY", false, errs)
+	cfg.PersistedQueriesRefreshMinutes = src.intValue("PERSISTED_QUERIES_REFRESH_MINUTES", 5, 0, 24*60, errs)
+	if cfg.PersistedQueriesFile != "" && cfg.PersistedQueriesKey == "" {
+		errs.add("PERSISTED_QUERIES_KEY must be set when PERSISTED_QUERIES_FILE is set")
+	}
+	if cfg.PersistedQueriesOnly && cfg.PersistedQueriesFile == "" {
+		errs.add("PERSISTED_QUERIES_FILE must be set when PERSISTED_QUERIES_ONLY=true")
+	}
+
+	cfg.SurrogateKeys = src.boolValue("SURROGATE_KEYS", false, errs)
+	cfg.GQLRequestLog = src.boolValue("GQL_REQUEST_LOG", false, errs)
+	cfg.GQLRequestLogVariablesPercent = src.intValue("GQL_REQUEST_LOG_VARIABLES_PERCENT", 0, 0, 100, errs)
+
+	cfg.GRPCPort = src.get("GRPC_PORT")
+	if cfg.GRPCPort != "" {
+		if port, err := strconv.Atoi(cfg.GRPCPort); err != nil || port < 1 || port > 65535 {
+			errs.add("invalid GRPC_PORT value %q: must be 1-65535", cfg.GRPCPort)
+		} else if cfg.GRPCPort == cfg.Port {
+			errs.add("GRPC_PORT must differ from PORT (%s)", cfg.Port)
+		} else if cfg.GRPCPort == cfg.TLSRedirectPort {
+			errs.add("GRPC_PORT must differ from TLS_REDIRECT_PORT (%s)", cfg.TLSRedirectPort)
+		} else if _, port, _ := net.SplitHostPort(cfg.AdminAddr); port == cfg.GRPCPort {
+			errs.add("GRPC_PORT must differ from the ADMIN_ADDR port (%s)", port)
+		}
+	}
+
+	cfg.PubSubChangeTopic = src.get("PUBSUB_CHANGE_TOPIC")
+	if cfg.PubSubChangeTopic != "" && !validPubSubName(cfg.PubSubChangeTopic, "topics") {
+		errs.add("invalid PUBSUB_CHANGE_TOPIC value %q: must be projects/<project>/topics/<topic>", cfg.PubSubChangeTopic)
+	}
+	cfg.ChangePollSeconds = src.intValue("CHANGE_POLL_SECONDS", 30, 5, 3600, errs)
+
+	cfg.PubSubPurgeSubscription = src.get("PUBSUB_PURGE_SUBSCRIPTION")
+	if cfg.PubSubPurgeSubscription != "" && !validPubSubName(cfg.PubSubPurgeSubscription, "subscriptions") {
+		errs.add("invalid PUBSUB_PURGE_SUBSCRIPTION value %q: must be projects/<project>/subscriptions/<subscription>", cfg.PubSubPurgeSubscription)
+	}
+	cfg.CDNPurgeURL = src.get("CDN_PURGE_URL")
+	if cfg.CDNPurgeURL != "" {
+		errs.checkURL("CDN_PURGE_URL", cfg.CDNPurgeURL, "https")
+	}
+	cfg.CDNPurgeToken = src.get("CDN_PURGE_TOKEN")
+
+	cfg.ViewCounts = src.boolValue("VIEW_COUNTS", false, errs)
+	cfg.ViewFlushSeconds = src.intValue("VIEW_FLUSH_SECONDS", 60, 5, 3600, errs)
+	cfg.ImageAvif = src.boolValue("IMAGE_AVIF", false, errs)
+	for _, pair := range strings.Split(src.get("IMAGE_FORMAT_COLUMNS"), ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		format, column, _ := strings.Cut(pair, "=")
+		format, column = strings.TrimSpace(format), strings.TrimSpace(column)
+		if (format != "webp" && format != "avif") || !validColumnName(column) {
+			errs.add("invalid IMAGE_FORMAT_COLUMNS entry %q: must be webp=<column> or avif=<column>", pair)
+			continue
+		}
+		if cfg.ImageFormatColumns == nil {
+			cfg.ImageFormatColumns = map[string]string{}
+		}
+		cfg.ImageFormatColumns[format] = column
+	}
+	for _, pair := range strings.Split(src.get("GQL_RESPONSE_CACHE"), ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		seconds, err := strconv.Atoi(strings.TrimSpace(value))
+		if name == "" || err != nil || seconds < 1 || seconds > 86400 {
+			errs.add("invalid GQL_RESPONSE_CACHE entry %q: must be <operationName>=<seconds between 1 and 86400>", pair)
+			continue
+		}
+		if cfg.GQLResponseCache == nil {
+			cfg.GQLResponseCache = map[string]int{}
+		}
+		cfg.GQLResponseCache[name] = seconds
+	}
+	cfg.GQLCoalesce = src.boolValue("GQL_COALESCE", false, errs)
+	cfg.GQLDocumentCacheSize = src.intValue("GQL_DOCUMENT_CACHE_SIZE", 1000, 0, 100000, errs)
+	cfg.GQLCacheControl = src.boolValue("GQL_CACHE_CONTROL", false, errs)
+	cfg.GQLDefaultMaxAge = src.intValue("GQL_DEFAULT_MAX_AGE", 0, 0, 86400, errs)
+
+	// shadow traffic 比對
+	cfg.ShadowReferenceURL = src.get("SHADOW_REFERENCE_URL")
+	if cfg.ShadowReferenceURL != "" {
+		errs.checkURL("SHADOW_REFERENCE_URL", cfg.ShadowReferenceURL, "http", "https")
+	}
+	cfg.ShadowSamplePercent = src.intValue("SHADOW_SAMPLE_PERCENT", 10, 1, 100, errs)
+	for _, path := range strings.Split(src.get("SHADOW_IGNORE_PATHS"), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			cfg.ShadowIgnorePaths = append(cfg.ShadowIgnorePaths, path)
+		}
+	}
+
+	// 依 Host header 切換的其他站台
+	cfg.TenantsFile = src.get("TENANTS_FILE")
+	if cfg.TenantsFile != "" {
+		cfg.Tenants = loadTenants(cfg.TenantsFile, src, cfg.MockMode, errs)
+	}
+
+	if src.err != nil {
+		return Config{}, src.err
+	}
+	if len(errs.Problems) > 0 {
+		return Config{}, errs
+	}
+	cfg.SecretRefs = src.refs
+
 	return cfg, nil
 }
 
+// ListenAddr returns the network ("tcp" or "unix") and address the HTTP
+// server listens on. The network is empty when LISTEN is not recognized.
+func (c Config) ListenAddr() (network, address string) {
+	if c.Listen == "" {
+		return "tcp", ":" + c.Port
+	}
+	if path, ok := strings.CutPrefix(c.Listen, "unix://"); ok {
+		return "unix", path
+	}
+	if addr, ok := strings.CutPrefix(c.Listen, "tcp://"); ok {
+		return "tcp", addr
+	}
+	return "", c.Listen
+}
+
+// TLSEnabled reports whether the HTTP listener (PORT or LISTEN) serves HTTPS.
+func (c Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" || len(c.TLSAutocertDomains) > 0
+}
+
 // encodeDatabaseURL 自動處理 DATABASE_URL 的編碼
 // 如果 URL 中的密碼尚未編碼，會自動進行 URL 編碼
 func encodeDatabaseURL(rawURL string) (string, error) {
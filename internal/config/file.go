@@ -0,0 +1,110 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// source 依序從命令列參數、環境變數、設定檔取值
+// 值為 sm:// 參照時會向 Secret Manager 取得實際內容
+type source struct {
+	overrides map[string]string
+	file      map[string]string
+	secrets   *secretClient
+	// refs 記錄哪些 key 來自 Secret Manager，供定期更新使用
+	refs map[string]string
+	// err 記錄第一個 secret 解析失敗的錯誤
+	err error
+}
+
+func (s *source) get(key string) string {
+	v, ok := s.lookup(key)
+	if !ok {
+		v = s.file[key]
+	}
+	if !isSecretRef(v) {
+		return v
+	}
+	resolved, ok := s.resolve(key, v)
+	if !ok {
+		return ""
+	}
+	if s.refs == nil {
+		s.refs = map[string]string{}
+	}
+	s.refs[key] = v
+	return resolved
+}
+
+// lookup 依序查詢命令列參數與環境變數；有設定即使為空字串也採用，
+// 讓 -REDIS_URL= 或 GQL_ALLOWLIST="" 可以清除設定檔中的值
+func (s *source) lookup(key string) (string, bool) {
+	if v, ok := s.overrides[key]; ok {
+		return v, true
+	}
+	return os.LookupEnv(key)
+}
+
+// resolve 取得 sm:// 參照的內容；失敗時記錄第一個錯誤
+func (s *source) resolve(key, v string) (string, bool) {
+	if s.secrets == nil {
+		s.secrets = newSecretClient()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	resolved, err := s.secrets.resolve(ctx, v)
+	if err != nil {
+		if s.err == nil {
+			s.err = fmt.Errorf("resolve %s: %w", key, err)
+		}
+		return "", false
+	}
+	return resolved, true
+}
+
+// loadFile 讀取 CONFIG_FILE 指定的 YAML/JSON 設定檔
+// 檔案為扁平的 key/value，key 與環境變數同名，例如：
+//
+//	REDIS_ENABLED: true
+//	REDIS_TTL: 600
+func loadFile(path string) (map[string]string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	values := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, &values)
+	case ".json":
+		err = json.Unmarshal(raw, &values)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q (use .yaml, .yml or .json)", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config file: %w", err)
+	}
+
+	result := make(map[string]string, len(values))
+	for k, v := range values {
+		switch val := v.(type) {
+		case nil:
+			continue
+		case string:
+			result[k] = val
+		case map[string]interface{}, []interface{}:
+			return nil, fmt.Errorf("config file key %s: nested values are not supported", k)
+		default:
+			result[k] = fmt.Sprintf("%v", val)
+		}
+	}
+	return result, nil
+}
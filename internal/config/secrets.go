@@ -0,0 +1,122 @@
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"go-story/internal/gcpauth"
+)
+
+// secretPrefix 標示 GCP Secret Manager 參照，例如
+// sm://projects/my-project/secrets/db-url 或 sm://projects/my-project/secrets/db-url/versions/3
+const secretPrefix = "sm://"
+
+const secretManagerAPI = "https://secretmanager.googleapis.com/v1/"
+
+func isSecretRef(v string) bool {
+	return strings.HasPrefix(v, secretPrefix)
+}
+
+// secretClient 以 gcpauth 取得的 access token 呼叫 Secret Manager REST API
+type secretClient struct {
+	http *http.Client
+}
+
+func newSecretClient() *secretClient {
+	return &secretClient{http: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// resolve 取得 sm:// 參照的 secret 內容，未指定版本時使用 latest
+func (c *secretClient) resolve(ctx context.Context, ref string) (string, error) {
+	name := strings.TrimPrefix(ref, secretPrefix)
+	if !strings.HasPrefix(name, "projects/") || !strings.Contains(name, "/secrets/") {
+		return "", fmt.Errorf("invalid secret reference %q (want sm://projects/<p>/secrets/<s>[/versions/<v>])", ref)
+	}
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+
+	token, err := gcpauth.AccessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, secretManagerAPI+name+":access", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("access secret %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("access secret %s: status %d", name, resp.StatusCode)
+	}
+
+	var body struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode secret %s: %w", name, err)
+	}
+	value, err := base64.StdEncoding.DecodeString(body.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("decode secret %s payload: %w", name, err)
+	}
+	return strings.TrimSpace(string(value)), nil
+}
+
+// WatchSecrets periodically re-resolves the sm:// references in cfg.SecretRefs
+// and calls onChange with the key and new value whenever a secret rotates.
+// DATABASE_URL values are passed through the same encoding as Load.
+func WatchSecrets(ctx context.Context, cfg Config, onChange func(key, value string)) {
+	if cfg.SecretRefreshMinutes <= 0 || len(cfg.SecretRefs) == 0 {
+		return
+	}
+	client := newSecretClient()
+	current := map[string]string{
+		"DATABASE_URL": cfg.DatabaseURL,
+		"REDIS_URL":    cfg.RedisURL,
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(cfg.SecretRefreshMinutes) * time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			for key, ref := range cfg.SecretRefs {
+				value, err := client.resolve(ctx, ref)
+				if err != nil {
+					log.Printf("[Config] Failed to refresh secret %s: %v", key, err)
+					continue
+				}
+				if key == "DATABASE_URL" {
+					if value, err = encodeDatabaseURL(value); err != nil {
+						log.Printf("[Config] Failed to encode refreshed DATABASE_URL: %v", err)
+						continue
+					}
+				}
+				if current[key] == value {
+					continue
+				}
+				current[key] = value
+				log.Printf("[Config] Secret %s rotated", key)
+				onChange(key, value)
+			}
+		}
+	}()
+}
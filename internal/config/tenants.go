@@ -0,0 +1,113 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Tenant is another site served by the same deployment. Requests whose Host
+// header matches one of Hosts are answered from the tenant's database and
+// statics host, with its cache keys prefixed by CachePrefix. Every other
+// setting is shared with the main configuration.
+type Tenant struct {
+	Hosts       []string `yaml:"hosts" json:"hosts"`
+	DatabaseURL string   `yaml:"database_url" json:"database_url"`
+	StaticsHost string   `yaml:"statics_host" json:"statics_host"`
+	CachePrefix string   `yaml:"cache_prefix" json:"cache_prefix"`
+}
+
+// cachePrefixPattern 限制 cache_prefix 的字元，避免與 key 的分隔符號或 SCAN pattern 衝突
+var cachePrefixPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// loadTenants 讀取 TENANTS_FILE（YAML/JSON 的 tenant 陣列），例如：
+//
+//   - hosts: [www.mirrordaily.news, mirrordaily.news]
+//     database_url: sm://projects/p/secrets/daily-db
+//     statics_host: https://statics.mirrordaily.news/images
+//     cache_prefix: daily
+//
+// database_url 可為 sm:// 參照，但不會隨 SECRET_REFRESH_MINUTES 更新
+func loadTenants(path string, s *source, mockMode bool, errs *ValidationError) []Tenant {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		errs.add("read TENANTS_FILE: %v", err)
+		return nil
+	}
+	var tenants []Tenant
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, &tenants)
+	case ".json":
+		err = json.Unmarshal(raw, &tenants)
+	default:
+		errs.add("unsupported TENANTS_FILE extension %q (use .yaml, .yml or .json)", filepath.Ext(path))
+		return nil
+	}
+	if err != nil {
+		errs.add("parse TENANTS_FILE: %v", err)
+		return nil
+	}
+
+	hosts := map[string]bool{}
+	prefixes := map[string]bool{}
+	for i := range tenants {
+		t := &tenants[i]
+		name := fmt.Sprintf("TENANTS_FILE entry %d", i+1)
+		if t.CachePrefix != "" {
+			name = fmt.Sprintf("TENANTS_FILE entry %q", t.CachePrefix)
+		}
+
+		if len(t.Hosts) == 0 {
+			errs.add("%s: hosts must not be empty", name)
+		}
+		for j, host := range t.Hosts {
+			host = strings.ToLower(strings.TrimSpace(host))
+			t.Hosts[j] = host
+			if host == "" || strings.Contains(host, "/") {
+				errs.add("%s: invalid host %q", name, host)
+			} else if hosts[host] {
+				errs.add("%s: host %q is listed more than once", name, host)
+			}
+			hosts[host] = true
+		}
+
+		if !cachePrefixPattern.MatchString(t.CachePrefix) {
+			errs.add("%s: cache_prefix must be lowercase letters, digits, '-' or '_'", name)
+		} else if prefixes[t.CachePrefix] {
+			errs.add("%s: cache_prefix is used by another tenant", name)
+		}
+		prefixes[t.CachePrefix] = true
+
+		if t.StaticsHost == "" {
+			errs.add("%s: statics_host not set", name)
+		} else {
+			errs.checkURL(name+" statics_host", t.StaticsHost, "http", "https")
+		}
+
+		if isSecretRef(t.DatabaseURL) {
+			t.DatabaseURL, _ = s.resolve(name+" database_url", t.DatabaseURL)
+		}
+		if t.DatabaseURL == "" {
+			if !mockMode {
+				errs.add("%s: database_url not set", name)
+			}
+			continue
+		}
+		encoded, err := encodeDatabaseURL(t.DatabaseURL)
+		if err != nil {
+			errs.add("%s: failed to encode database_url: %v", name, err)
+			continue
+		}
+		t.DatabaseURL = encoded
+		if strings.Contains(t.DatabaseURL, "://") {
+			errs.checkURL(name+" database_url", t.DatabaseURL, "postgres", "postgresql")
+		}
+	}
+	return tenants
+}
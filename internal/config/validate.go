@@ -0,0 +1,161 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ValidationError aggregates every configuration problem found by Load.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+func (e *ValidationError) add(format string, args ...interface{}) {
+	e.Problems = append(e.Problems, fmt.Sprintf(format, args...))
+}
+
+// checkURL 檢查 URL 格式與 scheme，錯誤訊息不包含原始值以免洩漏密碼
+func (e *ValidationError) checkURL(key, raw string, schemes ...string) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		e.add("invalid %s: not a valid URL", key)
+		return
+	}
+	validScheme := false
+	for _, s := range schemes {
+		if strings.EqualFold(u.Scheme, s) {
+			validScheme = true
+			break
+		}
+	}
+	if !validScheme {
+		e.add("invalid %s: scheme must be one of %s", key, strings.Join(schemes, ", "))
+		return
+	}
+	if u.Host == "" {
+		e.add("invalid %s: missing host", key)
+	}
+}
+
+// intValue 解析整數設定，未設定時回傳 def，並檢查 [min, max] 範圍
+func (s *source) intValue(key string, def, min, max int, errs *ValidationError) int {
+	raw := s.get(key)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		errs.add("invalid %s value: %v", key, err)
+		return def
+	}
+	if v < min || v > max {
+		errs.add("invalid %s value %d: must be between %d and %d", key, v, min, max)
+	}
+	return v
+}
+
+// boolValue 解析布林設定，未設定時回傳 def
+func (s *source) boolValue(key string, def bool, errs *ValidationError) bool {
+	raw := s.get(key)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		errs.add("invalid %s value: %v", key, err)
+		return def
+	}
+	return v
+}
+
+// prefixListValue 解析逗號分隔的 CIDR 清單，單一 IP 視為只含該位址的範圍
+func (s *source) prefixListValue(key string, errs *ValidationError) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, item := range strings.Split(s.get(key), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if !strings.Contains(item, "/") {
+			addr, err := netip.ParseAddr(item)
+			if err != nil {
+				errs.add("invalid %s entry %q: must be a CIDR such as 10.0.0.0/8 or an IP address", key, item)
+				continue
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(item)
+		if err != nil {
+			errs.add("invalid %s entry %q: must be a CIDR such as 10.0.0.0/8 or an IP address", key, item)
+			continue
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes
+}
+
+// byteSizeSuffixes 依 Go runtime 解析 GOMEMLIMIT 的單位，較長的後綴需先比對
+var byteSizeSuffixes = []struct {
+	suffix string
+	size   int64
+}{
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+	{"B", 1},
+}
+
+// byteSizeValue 以 GOMEMLIMIT 的格式解析大小（例如 512MiB），未設定時回傳 0，
+// off 回傳 math.MaxInt64（即不限制）
+func (s *source) byteSizeValue(key string, errs *ValidationError) int64 {
+	raw := strings.TrimSpace(s.get(key))
+	if raw == "" {
+		return 0
+	}
+	if raw == "off" {
+		return math.MaxInt64
+	}
+	num, unit := raw, int64(1)
+	for _, u := range byteSizeSuffixes {
+		if strings.HasSuffix(raw, u.suffix) {
+			num, unit = strings.TrimSuffix(raw, u.suffix), u.size
+			break
+		}
+	}
+	v, err := strconv.ParseInt(num, 10, 64)
+	if err != nil || v <= 0 || v > math.MaxInt64/unit {
+		errs.add("invalid %s value %q: must be a positive size such as 512MiB, or off", key, raw)
+		return 0
+	}
+	return v * unit
+}
+
+// validPubSubName 檢查 Pub/Sub 資源名稱是否為 projects/<project>/<kind>/<name>
+func validPubSubName(name, kind string) bool {
+	parts := strings.Split(name, "/")
+	return len(parts) == 4 && parts[0] == "projects" && parts[1] != "" && parts[2] == kind && parts[3] != ""
+}
+
+// validColumnName 檢查是否為可直接放進 SQL 的欄位名稱（英數字與底線）
+func validColumnName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		isLetter := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !isLetter && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ArchiveMonth is a month with published posts, in the output time zone.
+type ArchiveMonth struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Count int `json:"count"`
+}
+
+// archiveRange 回傳 year/month 在 loc 的起訖時間 [start, end)；month 為 0 表示整年
+func archiveRange(year, month int, loc *time.Location) (time.Time, time.Time) {
+	if month == 0 {
+		start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(1, 0, 0)
+	}
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// location 回傳輸出時間的時區，未設定時為 UTC
+func (r *Repo) location() *time.Location {
+	if r.opts.Location == nil {
+		return time.UTC
+	}
+	return r.opts.Location
+}
+
+// QueryPostsArchive returns the published posts of a month (or of a whole
+// year when month is 0), newest first, optionally limited to one section.
+// Months follow the output time zone. The month is matched with a half-open
+// range on "publishedDate" rather than date_trunc, so an index on the column
+// can be used.
+func (r *Repo) QueryPostsArchive(ctx context.Context, year, month int, sectionSlug string, take, skip int) ([]Post, error) {
+	ctx = withOp(ctx, "posts_archive")
+	start, end := archiveRange(year, month, r.location())
+	if r.mock != nil {
+		return r.mock.postsArchive(start, end, sectionSlug, take, skip), nil
+	}
+	idCtx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
+	defer cancel()
+
+	// 先取得這一頁的 id，再交給 QueryPosts 組裝關聯並沿用其 cache
+	query := `SELECT p.id FROM "Post" p WHERE p.state = 'published' AND p."publishedDate" >= $1 AND p."publishedDate" < $2`
+	args := []interface{}{start, end}
+	if sectionSlug != "" {
+		query += ` AND EXISTS (SELECT 1 FROM "_Post_sections" ps JOIN "Section" s ON s.id = ps."B" WHERE ps."A" = p.id AND s.slug = $3)`
+		args = append(args, sectionSlug)
+	}
+	query += ` ORDER BY p."publishedDate" DESC, p.id DESC`
+	if take >= 0 {
+		query += fmt.Sprintf(" LIMIT %d", take)
+	}
+	if skip > 0 {
+		query += fmt.Sprintf(" OFFSET %d", skip)
+	}
+	rows, err := r.db.QueryContext(idCtx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := []string{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, strconv.Itoa(id))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []Post{}, nil
+	}
+	return r.QueryPosts(ctx, &PostWhereInput{ID: &IDFilter{In: ids}}, nil, len(ids), 0)
+}
+
+// QueryArchiveMonths lists the months that have published posts, newest
+// first, with the number of posts in each, optionally limited to one
+// section. Results are cached like other queries.
+func (r *Repo) QueryArchiveMonths(ctx context.Context, sectionSlug string) ([]ArchiveMonth, error) {
+	ctx = withOp(ctx, "archive_months")
+	loc := r.location()
+	if r.mock != nil {
+		return r.mock.archiveMonths(sectionSlug, loc), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
+	defer cancel()
+
+	cacheKey := GenerateCacheKey("archiveMonths", map[string]interface{}{
+		"section":  sectionSlug,
+		"location": loc.String(),
+	})
+	if r.cache != nil && r.cache.Enabled() {
+		var cached []ArchiveMonth
+		if found, _ := r.cache.Get(ctx, cacheKey, &cached); found {
+			return cached, nil
+		}
+	}
+
+	// 以輸出時區的年月分組，與 postsArchive 的月份邊界一致
+	query := `SELECT EXTRACT(YEAR FROM local)::int, EXTRACT(MONTH FROM local)::int, COUNT(*) FROM (
+		SELECT p."publishedDate" AT TIME ZONE $1 AS local FROM "Post" p
+		WHERE p.state = 'published' AND p."publishedDate" IS NOT NULL`
+	args := []interface{}{loc.String()}
+	if sectionSlug != "" {
+		query += ` AND EXISTS (SELECT 1 FROM "_Post_sections" ps JOIN "Section" s ON s.id = ps."B" WHERE ps."A" = p.id AND s.slug = $2)`
+		args = append(args, sectionSlug)
+	}
+	query += `
+	) dates GROUP BY 1, 2 ORDER BY 1 DESC, 2 DESC`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []ArchiveMonth{}
+	for rows.Next() {
+		var m ArchiveMonth
+		if err := rows.Scan(&m.Year, &m.Month, &m.Count); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if r.cache != nil && r.cache.Enabled() {
+		_ = r.cache.Set(ctx, cacheKey, result)
+	}
+	return result, nil
+}
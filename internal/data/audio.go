@@ -0,0 +1,266 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AudioFile mirrors the Keystone file field of an Audio.
+type AudioFile struct {
+	Filename string `json:"filename"`
+	Filesize int    `json:"filesize"`
+	URL      string `json:"url"`
+}
+
+// Audio is a podcast episode or other audio clip.
+type Audio struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	File      AudioFile      `json:"file"`
+	HeroImage *Photo         `json:"heroImage"`
+	CreatedAt string         `json:"createdAt"`
+	UpdatedAt string         `json:"updatedAt"`
+	Metadata  map[string]any `json:"-"`
+}
+
+type AudioWhereInput struct {
+	ID   *IDFilter     `mapstructure:"id"`
+	Name *StringFilter `mapstructure:"name"`
+}
+
+func DecodeAudioWhere(input interface{}) (*AudioWhereInput, error) {
+	if input == nil {
+		return nil, nil
+	}
+	var where AudioWhereInput
+	if err := decodeInto(input, &where); err != nil {
+		return nil, err
+	}
+	return &where, nil
+}
+
+const audioColumns = `a.id, a.name, COALESCE(a."file_filename", ''), COALESCE(a."file_filesize", 0), a."heroImage", a."createdAt", a."updatedAt"`
+
+// QueryAudios returns audios ordered by creation time, newest first.
+func (r *Repo) QueryAudios(ctx context.Context, where *AudioWhereInput, take, skip int) ([]Audio, error) {
+	ctx = withOp(ctx, "audios_list")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
+	defer cancel()
+	if r.mock != nil {
+		return r.mock.queryAudios(where, take, skip), nil
+	}
+
+	// 嘗試從 cache 讀取
+	if r.cache != nil && r.cache.Enabled() {
+		cacheKey := GenerateCacheKey("audios", map[string]interface{}{
+			"where": where,
+			"take":  take,
+			"skip":  skip,
+		})
+		var cached []Audio
+		if found, _ := r.cache.Get(ctx, cacheKey, &cached); found {
+			return cached, nil
+		}
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(`SELECT ` + audioColumns + ` FROM "Audio" a`)
+	conds := []string{}
+	args := []interface{}{}
+	argIdx := 1
+	if where != nil {
+		if where.ID != nil {
+			if where.ID.Equals != nil {
+				id, err := parseIDs([]string{*where.ID.Equals})
+				if err != nil {
+					return nil, err
+				}
+				conds = append(conds, fmt.Sprintf(`a.id = $%d`, argIdx))
+				args = append(args, id[0])
+				argIdx++
+			}
+			if len(where.ID.In) > 0 {
+				ids, err := parseIDs(where.ID.In)
+				if err != nil {
+					return nil, err
+				}
+				conds = append(conds, fmt.Sprintf(`a.id = ANY($%d)`, argIdx))
+				args = append(args, pqIntArray(ids))
+				argIdx++
+			}
+			if len(where.ID.NotIn) > 0 {
+				ids, err := parseIDs(where.ID.NotIn)
+				if err != nil {
+					return nil, err
+				}
+				conds = append(conds, fmt.Sprintf(`a.id <> ALL($%d)`, argIdx))
+				args = append(args, pqIntArray(ids))
+				argIdx++
+			}
+		}
+		if f := where.Name; f != nil {
+			if f.Equals != nil {
+				conds = append(conds, fmt.Sprintf(`a.name = $%d`, argIdx))
+				args = append(args, *f.Equals)
+				argIdx++
+			}
+			if len(f.In) > 0 {
+				conds = append(conds, fmt.Sprintf(`a.name = ANY($%d)`, argIdx))
+				args = append(args, f.In)
+				argIdx++
+			}
+			if len(f.NotIn) > 0 {
+				conds = append(conds, fmt.Sprintf(`a.name <> ALL($%d)`, argIdx))
+				args = append(args, f.NotIn)
+				argIdx++
+			}
+		}
+	}
+	if len(conds) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(conds, " AND "))
+	}
+	sb.WriteString(` ORDER BY a."createdAt" DESC NULLS LAST, a.id DESC`)
+	if take >= 0 {
+		sb.WriteString(fmt.Sprintf(" LIMIT %d", take))
+	}
+	if skip > 0 {
+		sb.WriteString(fmt.Sprintf(" OFFSET %d", skip))
+	}
+
+	rows, err := r.db.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Audio{}
+	imageIDs := []int{}
+	for rows.Next() {
+		a, err := r.scanAudio(rows)
+		if err != nil {
+			return nil, err
+		}
+		if id := getMetaInt(a.Metadata, "heroImageID"); id > 0 {
+			imageIDs = append(imageIDs, id)
+		}
+		result = append(result, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	imageMap, err := r.fetchImages(ctx, imageIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result {
+		if id := getMetaInt(result[i].Metadata, "heroImageID"); id > 0 {
+			result[i].HeroImage = imageMap[id]
+		}
+	}
+
+	// 寫入 cache
+	if r.cache != nil && r.cache.Enabled() {
+		cacheKey := GenerateCacheKey("audios", map[string]interface{}{
+			"where": where,
+			"take":  take,
+			"skip":  skip,
+		})
+		_ = r.cache.Set(ctx, cacheKey, result)
+	}
+
+	return result, nil
+}
+
+func (r *Repo) scanAudio(rows *sql.Rows) (Audio, error) {
+	var (
+		a            Audio
+		dbID         int
+		heroImageID  sql.NullInt64
+		creAt, updAt sql.NullTime
+	)
+	if err := rows.Scan(&dbID, &a.Name, &a.File.Filename, &a.File.Filesize, &heroImageID, &creAt, &updAt); err != nil {
+		return a, err
+	}
+	a.ID = strconv.Itoa(dbID)
+	a.File.URL = r.buildFileURL(a.File.Filename)
+	if creAt.Valid {
+		a.CreatedAt = r.formatTime(creAt.Time)
+	}
+	if updAt.Valid {
+		a.UpdatedAt = r.formatTime(updAt.Time)
+	}
+	if heroImageID.Valid {
+		a.Metadata = map[string]any{"heroImageID": int(heroImageID.Int64)}
+	}
+	return a, nil
+}
+
+// buildFileURL 以 STATICS_HOST 組出上傳檔案的 URL
+func (r *Repo) buildFileURL(filename string) string {
+	if filename == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", r.staticsHost, filename)
+}
+
+// fetchAudios 回傳 audio 與其 heroImage id，heroImage 由呼叫端統一查詢
+func (r *Repo) fetchAudios(ctx context.Context, ids []int) (map[int]*Audio, []int, error) {
+	result := map[int]*Audio{}
+	imageIDs := []int{}
+	if len(ids) == 0 {
+		return result, imageIDs, nil
+	}
+	rows, err := r.db.QueryContext(ctx, `SELECT `+audioColumns+` FROM "Audio" a WHERE a.id = ANY($1)`, pqIntArray(ids))
+	if err != nil {
+		return result, imageIDs, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		a, err := r.scanAudio(rows)
+		if err != nil {
+			return result, imageIDs, err
+		}
+		if id := getMetaInt(a.Metadata, "heroImageID"); id > 0 {
+			imageIDs = append(imageIDs, id)
+		}
+		id, _ := strconv.Atoi(a.ID)
+		result[id] = &a
+	}
+	return result, imageIDs, rows.Err()
+}
+
+// postAudioRef 文章的 heroAudio / audio 關聯 id
+type postAudioRef struct {
+	HeroAudioID int
+	AudioID     int
+}
+
+// fetchPostAudioRefs 讀取文章的 audio 關聯；欄位不存在等錯誤由呼叫端忽略，不影響文章本身
+func (r *Repo) fetchPostAudioRefs(ctx context.Context, postIDs []int) (map[int]postAudioRef, error) {
+	result := map[int]postAudioRef{}
+	if len(postIDs) == 0 {
+		return result, nil
+	}
+	rows, err := r.db.QueryContext(ctx, `SELECT id, "heroAudio", audio FROM "Post" WHERE id = ANY($1) AND ("heroAudio" IS NOT NULL OR audio IS NOT NULL)`, pqIntArray(postIDs))
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			pid              int
+			heroAudio, audio sql.NullInt64
+		)
+		if err := rows.Scan(&pid, &heroAudio, &audio); err != nil {
+			return result, err
+		}
+		result[pid] = postAudioRef{HeroAudioID: nullableInt(heroAudio), AudioID: nullableInt(audio)}
+	}
+	return result, rows.Err()
+}
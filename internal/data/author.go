@@ -0,0 +1,150 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// authorRoleTables 為 Contact 與 Post 的關聯表（"A" 為 Contact、"B" 為 Post），作者頁涵蓋所有角色
+var authorRoleTables = []string{
+	"_Post_writers",
+	"_Post_photographers",
+	"_Post_camera_man",
+	"_Post_designers",
+	"_Post_engineers",
+	"_Post_vocals",
+}
+
+// AuthorWhereUniqueInput selects one contact by id or slug.
+type AuthorWhereUniqueInput struct {
+	ID   *string `mapstructure:"id"`
+	Slug *string `mapstructure:"slug"`
+}
+
+func DecodeAuthorWhereUnique(input interface{}) (*AuthorWhereUniqueInput, error) {
+	if input == nil {
+		return nil, nil
+	}
+	var where AuthorWhereUniqueInput
+	if err := decodeInto(input, &where); err != nil {
+		return nil, fmt.Errorf("author unique where: %w", err)
+	}
+	return &where, nil
+}
+
+// authorPostIDsSQL 列出 $1 在任一角色出現的文章 id；各關聯表的 ("A", "B") unique index 可直接以 "A" 查詢
+func authorPostIDsSQL() string {
+	parts := make([]string, 0, len(authorRoleTables))
+	for _, table := range authorRoleTables {
+		parts = append(parts, fmt.Sprintf(`SELECT "B" FROM "%s" WHERE "A" = $1`, table))
+	}
+	return strings.Join(parts, " UNION ")
+}
+
+// QueryAuthor returns the contact matching where, or nil when none does.
+func (r *Repo) QueryAuthor(ctx context.Context, where *AuthorWhereUniqueInput) (*Contact, error) {
+	ctx = withOp(ctx, "author")
+	if where == nil || (where.ID == nil && where.Slug == nil) {
+		return nil, nil
+	}
+	if r.mock != nil {
+		return r.mock.authorByUnique(where), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
+	defer cancel()
+
+	var (
+		c    Contact
+		dbID int
+		row  *sql.Row
+	)
+	if where.ID != nil {
+		ids, err := parseIDs([]string{*where.ID})
+		if err != nil {
+			return nil, err
+		}
+		row = r.db.QueryRowContext(ctx, `SELECT id, COALESCE(name, '') FROM "Contact" WHERE id = $1`, ids[0])
+	} else {
+		row = r.db.QueryRowContext(ctx, `SELECT id, COALESCE(name, '') FROM "Contact" WHERE slug = $1`, *where.Slug)
+	}
+	if err := row.Scan(&dbID, &c.Name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	c.ID = strconv.Itoa(dbID)
+	return &c, nil
+}
+
+// QueryAuthorPosts returns published posts crediting the contact in any role
+// (writer, photographer, camera man, designer, engineer or vocal), newest
+// first. Each post appears once even when the contact has several roles.
+func (r *Repo) QueryAuthorPosts(ctx context.Context, contactID string, take, skip int) ([]Post, error) {
+	ctx = withOp(ctx, "author_posts")
+	if r.mock != nil {
+		return r.mock.authorPosts(contactID, take, skip), nil
+	}
+	cid, err := parseIDs([]string{contactID})
+	if err != nil {
+		return nil, err
+	}
+	idCtx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
+	defer cancel()
+
+	// 先取得這一頁的 id，再交給 QueryPosts 組裝關聯並沿用其 cache
+	query := `SELECT p.id FROM "Post" p WHERE p.state = 'published' AND p.id IN (` + authorPostIDsSQL() + `)
+ORDER BY p."publishedDate" DESC NULLS LAST, p.id DESC`
+	if take >= 0 {
+		query += fmt.Sprintf(" LIMIT %d", take)
+	}
+	if skip > 0 {
+		query += fmt.Sprintf(" OFFSET %d", skip)
+	}
+	rows, err := r.db.QueryContext(idCtx, query, cid[0])
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := []string{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, strconv.Itoa(id))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []Post{}, nil
+	}
+	return r.QueryPosts(ctx, &PostWhereInput{ID: &IDFilter{In: ids}}, nil, len(ids), 0)
+}
+
+// QueryAuthorPostsCount counts the published posts QueryAuthorPosts pages through.
+func (r *Repo) QueryAuthorPostsCount(ctx context.Context, contactID string) (int, error) {
+	ctx = withOp(ctx, "author_posts_count")
+	if r.mock != nil {
+		return len(r.mock.filterAuthorPosts(contactID)), nil
+	}
+	cid, err := parseIDs([]string{contactID})
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
+	defer cancel()
+
+	var count int
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM "Post" p WHERE p.state = 'published' AND p.id IN (`+authorPostIDsSQL()+`)`, cid[0]).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -20,6 +22,19 @@ type Cache struct {
 	enabled bool
 	ttl     time.Duration
 	env     string // 執行環境 (dev/staging/prod)
+	// jitter 為 Set 時 TTL 隨機增減的比例，避免同一批寫入的 key 同時過期
+	jitter float64
+
+	// keyPrefix 加在所有 Redis key 前面，多個站台共用同一個 Redis 時區隔各自的 key
+	keyPrefix string
+
+	// 連線帳密，secret 輪替時可透過 UpdateCredentials 更新，新連線會套用
+	credsMu  sync.RWMutex
+	username string
+	password string
+
+	// counters 依 key prefix 累計 hit / miss，供 /debug/cache 使用
+	counters cacheCounters
 }
 
 // NewCache creates a new cache instance.
@@ -49,6 +64,8 @@ func NewCache(redisURL string, enabled bool, ttlSeconds int, env string) (*Cache
 		return cache, nil
 	}
 
+	cache.username, cache.password = opt.Username, opt.Password
+	opt.CredentialsProvider = cache.credentials
 	client := redis.NewClient(opt)
 
 	// 測試連線，如果失敗則將 enabled 設為 false
@@ -67,6 +84,36 @@ func NewCache(redisURL string, enabled bool, ttlSeconds int, env string) (*Cache
 	return cache, nil
 }
 
+// SetKeyPrefix prefixes every Redis key written or read through this cache
+// with "<prefix>:", so several sites can share one Redis database. Call it
+// before the cache is used.
+func (c *Cache) SetKeyPrefix(prefix string) {
+	c.keyPrefix = prefix
+}
+
+// SetTTLJitter randomizes the TTL of entries written by Set by up to
+// ±percent, so entries cached in the same burst don't all expire at once.
+// Call it before the cache is used.
+func (c *Cache) SetTTLJitter(percent int) {
+	c.jitter = float64(percent) / 100
+}
+
+// jitteredTTL 依 jitter 在 ttl 上下隨機調整
+func (c *Cache) jitteredTTL(ttl time.Duration) time.Duration {
+	if c.jitter <= 0 {
+		return ttl
+	}
+	return ttl + time.Duration((rand.Float64()*2-1)*c.jitter*float64(ttl))
+}
+
+// key 回傳實際存放於 Redis 的 key
+func (c *Cache) key(k string) string {
+	if c.keyPrefix == "" {
+		return k
+	}
+	return c.keyPrefix + ":" + k
+}
+
 // Enabled returns whether cache is enabled.
 func (c *Cache) Enabled() bool {
 	return c.enabled && c.client != nil
@@ -84,6 +131,25 @@ func (c *Cache) logError(format string, v ...interface{}) {
 	log.Printf(format, v...)
 }
 
+// credentials 提供給 go-redis 建立新連線時使用
+func (c *Cache) credentials() (string, string) {
+	c.credsMu.RLock()
+	defer c.credsMu.RUnlock()
+	return c.username, c.password
+}
+
+// UpdateCredentials applies the username/password of a rotated REDIS_URL to new connections.
+func (c *Cache) UpdateCredentials(redisURL string) error {
+	opt, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return fmt.Errorf("parse redis url: %w", err)
+	}
+	c.credsMu.Lock()
+	defer c.credsMu.Unlock()
+	c.username, c.password = opt.Username, opt.Password
+	return nil
+}
+
 // Close closes the Redis client.
 func (c *Cache) Close() error {
 	if c.client != nil {
@@ -98,12 +164,16 @@ func (c *Cache) Get(ctx context.Context, key string, dest interface{}) (bool, er
 		return false, nil
 	}
 
-	val, err := c.client.Get(ctx, key).Result()
+	val, err := c.client.Get(ctx, c.key(key)).Result()
 	if errors.Is(err, redis.Nil) {
+		c.counters.record(key, func(s *CachePrefixStats) { s.Misses++ })
+		cacheTraceFrom(ctx).record(key, false)
 		c.logInfo("[Redis] Cache miss: %s", key)
 		return false, nil
 	}
 	if err != nil {
+		c.counters.record(key, func(s *CachePrefixStats) { s.Errors++ })
+		cacheTraceFrom(ctx).record(key, false)
 		c.logError("[Redis] Get error for key %s: %v (disabling cache)", key, err)
 		// 如果讀取失敗，可能是連線問題，將 enabled 設為 false
 		c.enabled = false
@@ -111,16 +181,39 @@ func (c *Cache) Get(ctx context.Context, key string, dest interface{}) (bool, er
 	}
 
 	if err := json.Unmarshal([]byte(val), dest); err != nil {
+		c.counters.record(key, func(s *CachePrefixStats) { s.Errors++ })
+		cacheTraceFrom(ctx).record(key, false)
 		c.logError("[Redis] Unmarshal error for key %s: %v", key, err)
 		return false, fmt.Errorf("unmarshal cache value: %w", err)
 	}
 
+	c.counters.record(key, func(s *CachePrefixStats) { s.Hits++ })
+	cacheTraceFrom(ctx).record(key, true)
 	c.logInfo("[Redis] Cache hit: %s", key)
 	return true, nil
 }
 
-// Set stores a value in cache.
+// Set stores a value in cache with REDIS_TTL, adjusted by the TTL jitter.
 func (c *Cache) Set(ctx context.Context, key string, value interface{}) error {
+	return c.SetWithTTL(ctx, key, value, c.jitteredTTL(c.ttl))
+}
+
+// SetWithTTL stores a value in cache with a TTL other than REDIS_TTL.
+// Entries of the prefixes that may hold posts, topics or externals are
+// indexed under those entities, so PurgeEntity can delete them.
+func (c *Cache) SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if !c.Enabled() {
+		return nil
+	}
+	var entityKeys []string
+	if indexesEntities(key) {
+		entityKeys = entityKeysOf(value)
+	}
+	return c.set(ctx, key, value, ttl, entityKeys)
+}
+
+// set 寫入 value 並將 key 加入 entityKeys 的索引
+func (c *Cache) set(ctx context.Context, key string, value interface{}, ttl time.Duration, entityKeys []string) error {
 	if !c.Enabled() {
 		return nil
 	}
@@ -131,14 +224,17 @@ func (c *Cache) Set(ctx context.Context, key string, value interface{}) error {
 		return fmt.Errorf("marshal cache value: %w", err)
 	}
 
-	if err := c.client.Set(ctx, key, data, c.ttl).Err(); err != nil {
+	if err := c.client.Set(ctx, c.key(key), data, ttl).Err(); err != nil {
+		c.counters.record(key, func(s *CachePrefixStats) { s.Errors++ })
 		c.logError("[Redis] Set error for key %s: %v (disabling cache)", key, err)
 		// 如果寫入失敗，可能是連線問題，將 enabled 設為 false
 		c.enabled = false
 		return nil // 不返回錯誤，讓查詢繼續進行
 	}
 
-	c.logInfo("[Redis] Cache set: %s (TTL: %v)", key, c.ttl)
+	c.index(ctx, key, entityKeys, ttl)
+	c.counters.record(key, func(s *CachePrefixStats) { s.Sets++ })
+	c.logInfo("[Redis] Cache set: %s (TTL: %v)", key, ttl)
 	return nil
 }
 
@@ -148,7 +244,7 @@ func (c *Cache) Delete(ctx context.Context, key string) error {
 		return nil
 	}
 
-	if err := c.client.Del(ctx, key).Err(); err != nil {
+	if err := c.client.Del(ctx, c.key(key)).Err(); err != nil {
 		c.logError("[Redis] Delete error for key %s: %v (disabling cache)", key, err)
 		// 如果刪除失敗，可能是連線問題，將 enabled 設為 false
 		c.enabled = false
@@ -0,0 +1,108 @@
+package data
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CachePrefixStats counts cache operations of one key prefix (e.g. "posts").
+type CachePrefixStats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	Sets   uint64 `json:"sets"`
+	Errors uint64 `json:"errors"`
+}
+
+// CacheStats is a snapshot of the cache counters and Redis memory usage.
+type CacheStats struct {
+	Enabled  bool                        `json:"enabled"`
+	Hits     uint64                      `json:"hits"`
+	Misses   uint64                      `json:"misses"`
+	HitRatio float64                     `json:"hitRatio"`
+	Prefixes map[string]CachePrefixStats `json:"prefixes"`
+	// Redis 為 INFO memory 的內容與 key 數量，cache 未啟用或讀取失敗時為 nil
+	Redis map[string]string `json:"redis"`
+	// RedisError 讀取 INFO 失敗時的錯誤訊息
+	RedisError string `json:"redisError,omitempty"`
+}
+
+// redisMemoryFields 從 INFO memory 中輸出的欄位
+var redisMemoryFields = []string{
+	"used_memory", "used_memory_human", "used_memory_peak_human",
+	"maxmemory", "maxmemory_human", "maxmemory_policy", "mem_fragmentation_ratio",
+}
+
+// cacheCounters 依 key prefix 累計 cache 操作次數
+type cacheCounters struct {
+	mu       sync.Mutex
+	prefixes map[string]*CachePrefixStats
+}
+
+// record 以 key 中第一個 ":" 之前的部分作為 prefix 累計
+func (c *cacheCounters) record(key string, update func(*CachePrefixStats)) {
+	prefix, _, _ := strings.Cut(key, ":")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.prefixes == nil {
+		c.prefixes = map[string]*CachePrefixStats{}
+	}
+	s, ok := c.prefixes[prefix]
+	if !ok {
+		s = &CachePrefixStats{}
+		c.prefixes[prefix] = s
+	}
+	update(s)
+}
+
+// Stats returns hit/miss counters per key prefix and the Redis memory
+// usage reported by INFO memory.
+func (c *Cache) Stats(ctx context.Context) CacheStats {
+	stats := CacheStats{Enabled: c.Enabled(), Prefixes: map[string]CachePrefixStats{}}
+	c.counters.mu.Lock()
+	for prefix, s := range c.counters.prefixes {
+		stats.Prefixes[prefix] = *s
+		stats.Hits += s.Hits
+		stats.Misses += s.Misses
+	}
+	c.counters.mu.Unlock()
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+
+	if !stats.Enabled {
+		return stats
+	}
+	info, err := c.client.Info(ctx, "memory").Result()
+	if err != nil {
+		stats.RedisError = err.Error()
+		return stats
+	}
+	values := parseRedisInfo(info)
+	stats.Redis = map[string]string{}
+	for _, field := range redisMemoryFields {
+		if v, ok := values[field]; ok {
+			stats.Redis[field] = v
+		}
+	}
+	if n, err := c.client.DBSize(ctx).Result(); err == nil {
+		stats.Redis["keys"] = strconv.FormatInt(n, 10)
+	}
+	return stats
+}
+
+// parseRedisInfo 解析 INFO 回傳的 key:value 行，忽略 # 開頭的區段標題
+func parseRedisInfo(info string) map[string]string {
+	values := map[string]string{}
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if k, v, ok := strings.Cut(line, ":"); ok {
+			values[k] = v
+		}
+	}
+	return values
+}
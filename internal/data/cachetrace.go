@@ -0,0 +1,87 @@
+package data
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CacheTrace records the cache lookups made while serving one request, so
+// the handler can report them in the X-Cache response headers.
+type CacheTrace struct {
+	mu sync.Mutex
+	// prefixes 為各 key prefix 是否全部命中
+	prefixes map[string]bool
+}
+
+type cacheTraceKey struct{}
+
+// WithCacheTrace returns a context recording the cache lookups made with it
+// into the returned CacheTrace.
+func WithCacheTrace(ctx context.Context) (context.Context, *CacheTrace) {
+	t := &CacheTrace{prefixes: map[string]bool{}}
+	return context.WithValue(ctx, cacheTraceKey{}, t), t
+}
+
+// cacheTraceFrom 回傳 context 中的 CacheTrace，沒有時為 nil
+func cacheTraceFrom(ctx context.Context) *CacheTrace {
+	if ctx == nil {
+		return nil
+	}
+	t, _ := ctx.Value(cacheTraceKey{}).(*CacheTrace)
+	return t
+}
+
+// record 記錄一次查詢，同一 prefix 只要有一次 miss 即視為 MISS
+func (t *CacheTrace) record(key string, hit bool) {
+	if t == nil {
+		return
+	}
+	prefix, _, _ := strings.Cut(key, ":")
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if prev, ok := t.prefixes[prefix]; ok {
+		hit = hit && prev
+	}
+	t.prefixes[prefix] = hit
+}
+
+// Status returns "MISS" when any lookup missed (so the database was queried),
+// "HIT" when every lookup hit and "BYPASS" when the cache was not used.
+func (t *CacheTrace) Status() string {
+	if t == nil {
+		return "BYPASS"
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.prefixes) == 0 {
+		return "BYPASS"
+	}
+	for _, hit := range t.prefixes {
+		if !hit {
+			return "MISS"
+		}
+	}
+	return "HIT"
+}
+
+// Prefixes returns the status of each key prefix looked up, e.g.
+// "posts=HIT, topics=MISS", sorted by prefix.
+func (t *CacheTrace) Prefixes() string {
+	if t == nil {
+		return ""
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	parts := make([]string, 0, len(t.prefixes))
+	for prefix, hit := range t.prefixes {
+		status := "MISS"
+		if hit {
+			status = "HIT"
+		}
+		parts = append(parts, prefix+"="+status)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
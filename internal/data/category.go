@@ -0,0 +1,138 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryCategories returns the categories matching where, ordered by id, with
+// their sections filled in.
+func (r *Repo) QueryCategories(ctx context.Context, where *CategoryWhereInput, take, skip int) ([]Category, error) {
+	ctx = withOp(ctx, "categories_list")
+	if r.mock != nil {
+		return r.mock.queryCategories(where, take, skip), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
+	defer cancel()
+
+	conds := []string{}
+	args := []interface{}{}
+	buildStringFilter := func(field string, f *StringFilter) {
+		if f == nil {
+			return
+		}
+		if f.Equals != nil {
+			args = append(args, *f.Equals)
+			conds = append(conds, fmt.Sprintf(`%s = $%d`, field, len(args)))
+		}
+		if len(f.In) > 0 {
+			args = append(args, f.In)
+			conds = append(conds, fmt.Sprintf(`%s = ANY($%d)`, field, len(args)))
+		}
+		if len(f.NotIn) > 0 {
+			args = append(args, f.NotIn)
+			conds = append(conds, fmt.Sprintf(`%s <> ALL($%d)`, field, len(args)))
+		}
+	}
+	if where != nil {
+		buildStringFilter("c.name", where.Name)
+		buildStringFilter("c.slug", where.Slug)
+		buildStringFilter("c.state", where.State)
+		if where.IsMemberOnly != nil && where.IsMemberOnly.Equals != nil {
+			args = append(args, *where.IsMemberOnly.Equals)
+			conds = append(conds, fmt.Sprintf(`c."isMemberOnly" = $%d`, len(args)))
+		}
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(`SELECT c.id, c.name, c.slug, c.state, c."isMemberOnly" FROM "Category" c`)
+	if len(conds) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(conds, " AND "))
+	}
+	sb.WriteString(" ORDER BY c.id")
+	if take >= 0 {
+		sb.WriteString(fmt.Sprintf(" LIMIT %d", take))
+	}
+	if skip > 0 {
+		sb.WriteString(fmt.Sprintf(" OFFSET %d", skip))
+	}
+
+	rows, err := r.db.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := []Category{}
+	for rows.Next() {
+		var (
+			c    Category
+			dbID int
+		)
+		if err := rows.Scan(&dbID, &c.Name, &c.Slug, &c.State, &c.IsMemberOnly); err != nil {
+			return nil, err
+		}
+		c.ID = strconv.Itoa(dbID)
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := r.attachCategorySections(ctx, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// attachCategorySections 以一次查詢填入各 list 中每個 category 的 sections（直接修改 list 的元素）
+func (r *Repo) attachCategorySections(ctx context.Context, lists ...[]Category) error {
+	ids := []int{}
+	seen := map[int]bool{}
+	for _, list := range lists {
+		for _, c := range list {
+			if id, err := strconv.Atoi(c.ID); err == nil && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	sectionsMap, err := r.fetchCategorySections(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, list := range lists {
+		for i := range list {
+			id, _ := strconv.Atoi(list[i].ID)
+			list[i].Sections = sectionsMap[id]
+		}
+	}
+	return nil
+}
+
+func (r *Repo) fetchCategorySections(ctx context.Context, categoryIDs []int) (map[int][]Section, error) {
+	result := map[int][]Section{}
+	if len(categoryIDs) == 0 {
+		return result, nil
+	}
+	query := `SELECT cs."A" as category_id, s.id, s.name, s.slug, s.state FROM "_Category_sections" cs JOIN "Section" s ON s.id = cs."B" WHERE cs."A" = ANY($1) ORDER BY s.id`
+	rows, err := r.db.QueryContext(ctx, query, pqIntArray(categoryIDs))
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var cid int
+		var s Section
+		if err := rows.Scan(&cid, &s.ID, &s.Name, &s.Slug, &s.State); err != nil {
+			return result, err
+		}
+		result[cid] = append(result[cid], s)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,219 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of entity returned by QueryChangedStories.
+const (
+	ChangeKindPost     = "post"
+	ChangeKindExternal = "external"
+	ChangeKindTopic    = "topic"
+)
+
+// StoryChange is a post, external or topic updated after a watermark.
+// Deleted marks a tombstone: the entity exists but is no longer published,
+// so downstream copies should be removed. Fresh marks entities first
+// published (topics: created) after the watermark. Only one of Post,
+// External and Topic is set, and none for tombstones.
+type StoryChange struct {
+	Kind      string    `json:"kind"`
+	ID        string    `json:"id"`
+	Slug      string    `json:"slug"`
+	UpdatedAt string    `json:"updatedAt"`
+	Deleted   bool      `json:"deleted"`
+	Fresh     bool      `json:"fresh"`
+	Post      *Post     `json:"post"`
+	External  *External `json:"external"`
+	Topic     *Topic    `json:"topic"`
+	Cursor    string    `json:"cursor"`
+}
+
+// ChangeCursor marks a position in the (updatedAt, kind, id) order of
+// QueryChangedStories.
+type ChangeCursor struct {
+	UpdatedAt time.Time
+	Kind      string
+	ID        int
+}
+
+// String encodes the cursor as an opaque URL-safe token.
+func (c ChangeCursor) String() string {
+	raw := fmt.Sprintf("%d:%s:%d", c.UpdatedAt.UnixMicro(), c.Kind, c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseChangeCursor decodes a token produced by ChangeCursor.String.
+func ParseChangeCursor(token string) (ChangeCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ChangeCursor{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 {
+		return ChangeCursor{}, fmt.Errorf("invalid cursor %q", token)
+	}
+	us, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return ChangeCursor{}, fmt.Errorf("invalid cursor time: %w", err)
+	}
+	id, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return ChangeCursor{}, fmt.Errorf("invalid cursor id: %w", err)
+	}
+	return ChangeCursor{UpdatedAt: time.UnixMicro(us).UTC(), Kind: parts[1], ID: id}, nil
+}
+
+// changedStoriesQuery 三種 entity 依 updatedAt 合併；since 放在各自的子查詢以使用 updatedAt 索引
+// fresh 表示發布時間（topic 為建立時間）也在 since 之後
+const changedStoriesQuery = `SELECT kind, id, slug, published, fresh, "updatedAt" FROM (
+	SELECT 'post' AS kind, id, COALESCE(slug, '') AS slug, state = 'published' AS published, COALESCE("publishedDate" > $1, false) AS fresh, "updatedAt" FROM "Post" WHERE "updatedAt" > $1
+	UNION ALL
+	SELECT 'external', id, COALESCE(slug, ''), state = 'published', COALESCE("publishedDate" > $1, false), "updatedAt" FROM "External" WHERE "updatedAt" > $1
+	UNION ALL
+	SELECT 'topic', id, COALESCE(slug, ''), state = 'published', COALESCE("createdAt" > $1, false), "updatedAt" FROM "Topic" WHERE "updatedAt" > $1
+) c`
+
+// QueryChangedStories returns up to take posts, externals and topics whose
+// updatedAt is after since (or after the cursor, when given), oldest first.
+// Published entities are loaded with their relations; unpublished ones are
+// returned as tombstones. Hard-deleted rows cannot be detected.
+func (r *Repo) QueryChangedStories(ctx context.Context, since time.Time, after *ChangeCursor, take int) ([]StoryChange, error) {
+	ctx = withOp(ctx, "changed_stories")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(15*time.Second))
+	defer cancel()
+
+	changes, err := r.QueryChanges(ctx, since, after, take)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadChangedEntities(ctx, changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// QueryChanges is like QueryChangedStories but only returns the kind, id,
+// slug and state of each change, without loading the entities.
+func (r *Repo) QueryChanges(ctx context.Context, since time.Time, after *ChangeCursor, take int) ([]StoryChange, error) {
+	ctx = withOp(ctx, "changes")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
+	defer cancel()
+	if r.mock != nil {
+		return r.mock.changes(r, since, after, take), nil
+	}
+
+	query := changedStoriesQuery
+	args := []interface{}{since}
+	if after != nil {
+		// cursor 之前的時間已處理過，將 since 提前到 cursor 以縮小子查詢範圍
+		if after.UpdatedAt.After(since) {
+			args[0] = after.UpdatedAt.Add(-time.Microsecond)
+		}
+		query += ` WHERE ("updatedAt", kind, id) > ($2, $3, $4)`
+		args = append(args, after.UpdatedAt, after.Kind, after.ID)
+	}
+	query += fmt.Sprintf(` ORDER BY "updatedAt", kind, id LIMIT %d`, take)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []StoryChange{}
+	for rows.Next() {
+		var (
+			c         StoryChange
+			id        int
+			published bool
+			updatedAt sql.NullTime
+		)
+		if err := rows.Scan(&c.Kind, &id, &c.Slug, &published, &c.Fresh, &updatedAt); err != nil {
+			return nil, err
+		}
+		c.ID = strconv.Itoa(id)
+		c.Deleted = !published
+		c.UpdatedAt = r.formatTime(updatedAt.Time)
+		c.Cursor = ChangeCursor{UpdatedAt: updatedAt.Time, Kind: c.Kind, ID: id}.String()
+		changes = append(changes, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// loadChangedEntities 依種類批次載入已發布的 entity，每種最多一次查詢
+func (r *Repo) loadChangedEntities(ctx context.Context, changes []StoryChange) error {
+	var postIDs, externalSlugs, topicSlugs []string
+	for _, c := range changes {
+		if c.Deleted {
+			continue
+		}
+		switch c.Kind {
+		case ChangeKindPost:
+			postIDs = append(postIDs, c.ID)
+		case ChangeKindExternal:
+			externalSlugs = append(externalSlugs, c.Slug)
+		case ChangeKindTopic:
+			topicSlugs = append(topicSlugs, c.Slug)
+		}
+	}
+
+	posts := map[string]*Post{}
+	if len(postIDs) > 0 {
+		list, err := r.QueryPosts(ctx, &PostWhereInput{ID: &IDFilter{In: postIDs}}, nil, len(postIDs), 0)
+		if err != nil {
+			return fmt.Errorf("load changed posts: %w", err)
+		}
+		for i := range list {
+			posts[list[i].ID] = &list[i]
+		}
+	}
+	externals := map[string]*External{}
+	if len(externalSlugs) > 0 {
+		// 依 updatedAt 排序，避免預設排序過濾掉沒有 publishedDate 的 external
+		orders := []OrderRule{{Field: "updatedAt", Direction: "asc"}}
+		list, err := r.QueryExternals(ctx, &ExternalWhereInput{Slug: &StringFilter{In: externalSlugs}}, orders, len(externalSlugs), 0)
+		if err != nil {
+			return fmt.Errorf("load changed externals: %w", err)
+		}
+		for i := range list {
+			externals[list[i].ID] = &list[i]
+		}
+	}
+	topics := map[string]*Topic{}
+	if len(topicSlugs) > 0 {
+		where := &TopicWhereInput{Slug: &StringFilter{In: topicSlugs}, State: &StringFilter{Equals: ptrString("published")}}
+		list, err := r.QueryTopics(ctx, where, nil, len(topicSlugs), 0)
+		if err != nil {
+			return fmt.Errorf("load changed topics: %w", err)
+		}
+		for i := range list {
+			topics[list[i].ID] = &list[i]
+		}
+	}
+
+	for i := range changes {
+		c := &changes[i]
+		if c.Deleted {
+			continue
+		}
+		switch c.Kind {
+		case ChangeKindPost:
+			c.Post = posts[c.ID]
+		case ChangeKindExternal:
+			c.External = externals[c.ID]
+		case ChangeKindTopic:
+			c.Topic = topics[c.ID]
+		}
+	}
+	return nil
+}
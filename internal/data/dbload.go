@@ -0,0 +1,65 @@
+package data
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// dbLatencyAlpha 延遲 EWMA 的權重，越大越快反應最新的查詢
+	dbLatencyAlpha = 0.2
+	// dbLatencyMaxAge 超過這段時間沒有新的查詢時，視為延遲已恢復
+	dbLatencyMaxAge = 5 * time.Second
+)
+
+// DBLoad is a snapshot of the database load seen by the repo.
+type DBLoad struct {
+	// InFlight 正在執行（含等待連線）的查詢數
+	InFlight int
+	// Latency 最近查詢延遲的 EWMA，近期沒有查詢時為 0
+	Latency time.Duration
+}
+
+// dbLoadTracker 統計進行中的查詢數與查詢延遲，供 load shedding 判斷 DB 是否飽和
+type dbLoadTracker struct {
+	mu         sync.Mutex
+	inFlight   int
+	latency    float64
+	lastSample time.Time
+}
+
+// begin 標記查詢開始，回傳的函式在查詢結束時呼叫
+func (t *dbLoadTracker) begin() func() {
+	start := time.Now()
+	t.mu.Lock()
+	t.inFlight++
+	t.mu.Unlock()
+	return func() {
+		now := time.Now()
+		elapsed := float64(now.Sub(start))
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.inFlight--
+		if t.lastSample.IsZero() || now.Sub(t.lastSample) > dbLatencyMaxAge {
+			t.latency = elapsed
+		} else {
+			t.latency = dbLatencyAlpha*elapsed + (1-dbLatencyAlpha)*t.latency
+		}
+		t.lastSample = now
+	}
+}
+
+func (t *dbLoadTracker) snapshot() DBLoad {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	load := DBLoad{InFlight: t.inFlight}
+	if !t.lastSample.IsZero() && time.Since(t.lastSample) <= dbLatencyMaxAge {
+		load.Latency = time.Duration(t.latency)
+	}
+	return load
+}
+
+// DBLoad returns the current in-flight query count and recent query latency.
+func (r *Repo) DBLoad() DBLoad {
+	return r.db.load.snapshot()
+}
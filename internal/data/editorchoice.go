@@ -0,0 +1,183 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EditorChoice is one curated front-page pick.
+type EditorChoice struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	SortOrder     *int   `json:"sortOrder"`
+	State         string `json:"state"`
+	PublishedDate string `json:"publishedDate"`
+	Choices       *Post  `json:"choices"`
+	CreatedAt     string `json:"createdAt"`
+	UpdatedAt     string `json:"updatedAt"`
+}
+
+type EditorChoiceWhereInput struct {
+	State *StringFilter `mapstructure:"state"`
+}
+
+func DecodeEditorChoiceWhere(input interface{}) (*EditorChoiceWhereInput, error) {
+	if input == nil {
+		return nil, nil
+	}
+	var where EditorChoiceWhereInput
+	if err := decodeInto(input, &where); err != nil {
+		return nil, err
+	}
+	return &where, nil
+}
+
+// QueryEditorChoices returns editor choices ordered by sortOrder, each with
+// its chosen post and the post's hero image. Only published choices pointing
+// at a published post are returned unless where sets another state.
+func (r *Repo) QueryEditorChoices(ctx context.Context, where *EditorChoiceWhereInput, take, skip int) ([]EditorChoice, error) {
+	ctx = withOp(ctx, "editor_choices_list")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
+	defer cancel()
+
+	if where == nil {
+		where = &EditorChoiceWhereInput{}
+	}
+	if where.State == nil {
+		where.State = &StringFilter{Equals: ptrString("published")}
+	}
+	if r.mock != nil {
+		return r.mock.queryEditorChoices(where, take, skip), nil
+	}
+
+	// 嘗試從 cache 讀取
+	if r.cache != nil && r.cache.Enabled() {
+		cacheKey := GenerateCacheKey("editorChoices", map[string]interface{}{
+			"where": where,
+			"take":  take,
+			"skip":  skip,
+		})
+		var cached []EditorChoice
+		if found, _ := r.cache.Get(ctx, cacheKey, &cached); found {
+			return cached, nil
+		}
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(`SELECT ec.id, ec.name, ec."sortOrder", ec.state, ec."publishedDate", ec.choices, ec."createdAt", ec."updatedAt" FROM "EditorChoice" ec`)
+	// 只保留指向已發布文章的精選
+	conds := []string{`EXISTS (SELECT 1 FROM "Post" p WHERE p.id = ec.choices AND p.state = 'published')`}
+	args := []interface{}{}
+	argIdx := 1
+	if f := where.State; f != nil {
+		if f.Equals != nil {
+			conds = append(conds, fmt.Sprintf(`ec.state = $%d`, argIdx))
+			args = append(args, *f.Equals)
+			argIdx++
+		}
+		if len(f.In) > 0 {
+			conds = append(conds, fmt.Sprintf(`ec.state = ANY($%d)`, argIdx))
+			args = append(args, f.In)
+			argIdx++
+		}
+		if len(f.NotIn) > 0 {
+			conds = append(conds, fmt.Sprintf(`ec.state <> ALL($%d)`, argIdx))
+			args = append(args, f.NotIn)
+			argIdx++
+		}
+	}
+	sb.WriteString(" WHERE ")
+	sb.WriteString(strings.Join(conds, " AND "))
+	sb.WriteString(` ORDER BY ec."sortOrder" ASC NULLS LAST, ec.id DESC`)
+	if take >= 0 {
+		sb.WriteString(fmt.Sprintf(" LIMIT %d", take))
+	}
+	if skip > 0 {
+		sb.WriteString(fmt.Sprintf(" OFFSET %d", skip))
+	}
+
+	rows, err := r.db.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []EditorChoice{}
+	choiceIDs := []int{}
+	postIDs := []int{}
+	for rows.Next() {
+		var (
+			ec                  EditorChoice
+			dbID                int
+			sortOrder           sql.NullInt64
+			postID              sql.NullInt64
+			pubAt, creAt, updAt sql.NullTime
+		)
+		if err := rows.Scan(&dbID, &ec.Name, &sortOrder, &ec.State, &pubAt, &postID, &creAt, &updAt); err != nil {
+			return nil, err
+		}
+		ec.ID = strconv.Itoa(dbID)
+		if sortOrder.Valid {
+			val := int(sortOrder.Int64)
+			ec.SortOrder = &val
+		}
+		if pubAt.Valid {
+			ec.PublishedDate = r.formatTime(pubAt.Time)
+		}
+		if creAt.Valid {
+			ec.CreatedAt = r.formatTime(creAt.Time)
+		}
+		if updAt.Valid {
+			ec.UpdatedAt = r.formatTime(updAt.Time)
+		}
+		pid := 0
+		if postID.Valid {
+			pid = int(postID.Int64)
+			postIDs = append(postIDs, pid)
+		}
+		choiceIDs = append(choiceIDs, pid)
+		result = append(result, ec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// choice → post → heroImage
+	posts, imageIDs, err := r.fetchPostsByIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	imageMap, err := r.fetchImages(ctx, imageIDs)
+	if err != nil {
+		return nil, err
+	}
+	postMap := make(map[int]Post, len(posts))
+	for _, p := range posts {
+		if idImg := getMetaInt(p.Metadata, "heroImageID"); idImg > 0 {
+			p.HeroImage = imageMap[idImg]
+		}
+		id, _ := strconv.Atoi(p.ID)
+		postMap[id] = p
+	}
+	for i := range result {
+		if p, ok := postMap[choiceIDs[i]]; ok {
+			result[i].Choices = &p
+		}
+	}
+
+	// 寫入 cache
+	if r.cache != nil && r.cache.Enabled() {
+		cacheKey := GenerateCacheKey("editorChoices", map[string]interface{}{
+			"where": where,
+			"take":  take,
+			"skip":  skip,
+		})
+		_ = r.cache.Set(ctx, cacheKey, result)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,221 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// entityIndexPrefix 為 entity 索引的 key prefix：entityIndex:post-123 是一個 set，
+// 內容為包含該 entity 的 cache key，purge 單一 entity 時不需清除整個 prefix
+const entityIndexPrefix = "entityIndex"
+
+// untaggedEntityKey 收錄沒有 entity key 的回應快取（未開啟 SURROGATE_KEYS 時），
+// 無法判斷其中包含哪些 entity，因此每次 PurgeEntity 都會一併清除
+const untaggedEntityKey = "untagged"
+
+// entityIndexTypes 為寫入索引的型別與其種類，只包含 CMS 最常修改的內容；
+// tag、section 等其他種類出現在大量文章中，索引成本高，PurgeEntity 改以 prefix 清除
+var entityIndexTypes = map[reflect.Type]string{
+	reflect.TypeOf(Post{}):     "post",
+	reflect.TypeOf(Topic{}):    "topic",
+	reflect.TypeOf(External{}): "external",
+}
+
+// entityIndexKinds 為寫入索引的種類
+var entityIndexKinds = func() map[string]bool {
+	m := map[string]bool{}
+	for _, kind := range entityIndexTypes {
+		m[kind] = true
+	}
+	return m
+}()
+
+// entityIndexPrefixes 為可能包含上述種類、需要寫入索引的 cache key prefix；
+// 其他 prefix（navigation、tagSuggest 等）寫入時不做任何索引
+var entityIndexPrefixes = func() map[string]bool {
+	m := map[string]bool{}
+	for _, kind := range entityIndexTypes {
+		for _, prefix := range purgePrefixes[kind] {
+			m[prefix] = true
+		}
+	}
+	// 計數不包含 entity
+	delete(m, "topicsCount")
+	return m
+}()
+
+// EntityKey returns the index key of an entity, the same as its surrogate
+// key: the lowercase type name and the id, e.g. "post-123" or
+// "editorchoice-4".
+func EntityKey(entity, id string) string {
+	return strings.ToLower(entity) + "-" + id
+}
+
+// indexesEntities 回傳 key 的 prefix 是否需要寫入索引
+func indexesEntities(key string) bool {
+	prefix, _, _ := strings.Cut(key, ":")
+	return entityIndexPrefixes[prefix]
+}
+
+// SetWithKeys stores a value like SetWithTTL, indexed under the post, topic
+// and external keys among keys (surrogate keys such as "post-123") instead
+// of the entities found in value. An empty keys list means the entities are
+// unknown, and the entry is cleared by every PurgeEntity.
+func (c *Cache) SetWithKeys(ctx context.Context, key string, value interface{}, ttl time.Duration, keys []string) error {
+	if len(keys) == 0 {
+		return c.set(ctx, key, value, ttl, []string{untaggedEntityKey})
+	}
+	var indexed []string
+	for _, k := range keys {
+		kind, _, _ := strings.Cut(k, "-")
+		if entityIndexKinds[kind] && !strings.HasSuffix(k, "-list") {
+			indexed = append(indexed, k)
+		}
+	}
+	return c.set(ctx, key, value, ttl, indexed)
+}
+
+// index 將 key 加入各 entity 的索引；索引寫入失敗時刪除 key，避免之後無法以 entity 清除
+func (c *Cache) index(ctx context.Context, key string, entityKeys []string, ttl time.Duration) {
+	if len(entityKeys) == 0 {
+		return
+	}
+	// 索引由多個 key 共用，每次寫入都會重設 TTL；取較長的時間避免索引比其中的 key 先過期
+	indexTTL := 2 * max(ttl, c.ttl)
+	pipe := c.client.Pipeline()
+	for _, ek := range entityKeys {
+		indexKey := c.key(entityIndexPrefix + ":" + ek)
+		pipe.SAdd(ctx, indexKey, c.key(key))
+		pipe.Expire(ctx, indexKey, indexTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.logError("[Redis] Index error for key %s: %v", key, err)
+		_ = c.client.Unlink(ctx, c.key(key)).Err()
+	}
+}
+
+// PurgeEntity deletes the cached entries containing the entity of kind with
+// id. Posts, topics and externals are found through the index written by
+// Set, plus the cached responses whose entities are unknown; other kinds
+// are not indexed and fall back to Purge. It returns the number of deleted
+// entries.
+func (c *Cache) PurgeEntity(ctx context.Context, entity, id string) (int, error) {
+	if !c.Enabled() {
+		return 0, nil
+	}
+	if _, ok := purgePrefixes[entity]; !ok {
+		return 0, fmt.Errorf("unknown entity %q", entity)
+	}
+	if !entityIndexKinds[entity] {
+		return c.Purge(ctx, entity)
+	}
+	deleted := 0
+	for _, ek := range []string{EntityKey(entity, id), untaggedEntityKey} {
+		n, err := c.purgeIndex(ctx, ek)
+		deleted += n
+		if err != nil {
+			return deleted, fmt.Errorf("purge %s: %w", ek, err)
+		}
+	}
+	return deleted, nil
+}
+
+// purgeIndex 刪除索引中的 key 與索引本身
+func (c *Cache) purgeIndex(ctx context.Context, entityKey string) (int, error) {
+	indexKey := c.key(entityIndexPrefix + ":" + entityKey)
+	keys, err := c.client.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for start := 0; start < len(keys); start += purgeScanCount {
+		n, err := c.client.Unlink(ctx, keys[start:min(start+purgeScanCount, len(keys))]...).Result()
+		if err != nil {
+			return deleted, err
+		}
+		deleted += int(n)
+	}
+	return deleted, c.client.Unlink(ctx, indexKey).Err()
+}
+
+// entityKeysOf 以 reflection 找出 value 中的 Post、Topic 與 External，回傳排序後的 entity key；
+// 只走訪可能包含這些型別的欄位，圖片、標籤與文章內文等不會走訪
+func entityKeysOf(value interface{}) []string {
+	seen := map[string]bool{}
+	collectEntityKeys(reflect.ValueOf(value), seen)
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func collectEntityKeys(rv reflect.Value, seen map[string]bool) {
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if !mayContainEntity(rv.Type().Elem()) {
+			return
+		}
+		for i := 0; i < rv.Len(); i++ {
+			collectEntityKeys(rv.Index(i), seen)
+		}
+	case reflect.Struct:
+		t := rv.Type()
+		if kind, ok := entityIndexTypes[t]; ok {
+			if id := rv.FieldByName("ID").String(); id != "" {
+				seen[EntityKey(kind, id)] = true
+			}
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.IsExported() && mayContainEntity(f.Type) {
+				collectEntityKeys(rv.Field(i), seen)
+			}
+		}
+	}
+}
+
+// entityTypeCache 記錄各型別是否可能包含寫入索引的型別
+var entityTypeCache sync.Map
+
+// mayContainEntity 回傳型別 t 的值是否可能包含 Post、Topic 或 External；map 與 interface 不走訪
+func mayContainEntity(t reflect.Type) bool {
+	if v, ok := entityTypeCache.Load(t); ok {
+		return v.(bool)
+	}
+	result := containsEntityType(t, map[reflect.Type]bool{})
+	entityTypeCache.Store(t, result)
+	return result
+}
+
+func containsEntityType(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return containsEntityType(t.Elem(), visiting)
+	case reflect.Struct:
+		if _, ok := entityIndexTypes[t]; ok {
+			return true
+		}
+		if visiting[t] {
+			return false
+		}
+		visiting[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.IsExported() && containsEntityType(f.Type, visiting) {
+				return true
+			}
+		}
+	}
+	return false
+}
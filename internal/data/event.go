@@ -0,0 +1,177 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is an embedded livestream or campaign shown in the site header.
+type Event struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Slug          string `json:"slug"`
+	State         string `json:"state"`
+	EventType     string `json:"eventType"`
+	Link          string `json:"link"`
+	EmbedCode     string `json:"embedCode"`
+	StartDate     string `json:"startDate"`
+	EndDate       string `json:"endDate"`
+	PublishedDate string `json:"publishedDate"`
+	HeroImage     *Photo `json:"heroImage"`
+	CreatedAt     string `json:"createdAt"`
+	UpdatedAt     string `json:"updatedAt"`
+}
+
+type EventWhereInput struct {
+	Slug      *StringFilter `mapstructure:"slug"`
+	State     *StringFilter `mapstructure:"state"`
+	EventType *StringFilter `mapstructure:"eventType"`
+	// IsActive 為計算條件：startDate 已開始且 endDate 未結束（endDate 為空視為未結束）
+	IsActive *bool `mapstructure:"isActive"`
+}
+
+func DecodeEventWhere(input interface{}) (*EventWhereInput, error) {
+	if input == nil {
+		return nil, nil
+	}
+	var where EventWhereInput
+	if err := decodeInto(input, &where); err != nil {
+		return nil, err
+	}
+	return &where, nil
+}
+
+// QueryEvents returns events ordered by startDate, newest first. Only
+// published events are returned unless where sets another state. Results are
+// not cached because isActive depends on the current time.
+func (r *Repo) QueryEvents(ctx context.Context, where *EventWhereInput, take, skip int) ([]Event, error) {
+	ctx = withOp(ctx, "events_list")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
+	defer cancel()
+
+	if where == nil {
+		where = &EventWhereInput{}
+	}
+	if where.State == nil {
+		where.State = &StringFilter{Equals: ptrString("published")}
+	}
+	if r.mock != nil {
+		return r.mock.queryEvents(where, take, skip, time.Now()), nil
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(`SELECT ev.id, ev.name, ev.slug, ev.state, ev."eventType", ev.link, ev."embedCode", ev."startDate", ev."endDate", ev."publishedDate", ev."heroImage", ev."createdAt", ev."updatedAt" FROM "Event" ev`)
+
+	conds := []string{}
+	args := []interface{}{}
+	argIdx := 1
+	buildStringFilter := func(field string, f *StringFilter) {
+		if f == nil {
+			return
+		}
+		if f.Equals != nil {
+			conds = append(conds, fmt.Sprintf(`%s = $%d`, field, argIdx))
+			args = append(args, *f.Equals)
+			argIdx++
+		}
+		if len(f.In) > 0 {
+			conds = append(conds, fmt.Sprintf(`%s = ANY($%d)`, field, argIdx))
+			args = append(args, f.In)
+			argIdx++
+		}
+		if len(f.NotIn) > 0 {
+			conds = append(conds, fmt.Sprintf(`%s <> ALL($%d)`, field, argIdx))
+			args = append(args, f.NotIn)
+			argIdx++
+		}
+	}
+	buildStringFilter("ev.slug", where.Slug)
+	buildStringFilter("ev.state", where.State)
+	buildStringFilter(`ev."eventType"`, where.EventType)
+	if where.IsActive != nil {
+		active := `(ev."startDate" <= now() AND (ev."endDate" IS NULL OR ev."endDate" >= now()))`
+		if *where.IsActive {
+			conds = append(conds, active)
+		} else {
+			conds = append(conds, "NOT "+active)
+		}
+	}
+	if len(conds) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(conds, " AND "))
+	}
+	sb.WriteString(` ORDER BY ev."startDate" DESC NULLS LAST, ev.id DESC`)
+	if take >= 0 {
+		sb.WriteString(fmt.Sprintf(" LIMIT %d", take))
+	}
+	if skip > 0 {
+		sb.WriteString(fmt.Sprintf(" OFFSET %d", skip))
+	}
+
+	rows, err := r.db.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Event{}
+	heroIDs := []int{}
+	imageIDs := []int{}
+	for rows.Next() {
+		var (
+			ev                         Event
+			dbID                       int
+			eventType, link, embedCode sql.NullString
+			startAt, endAt, pubAt      sql.NullTime
+			creAt, updAt               sql.NullTime
+			heroImageID                sql.NullInt64
+		)
+		if err := rows.Scan(&dbID, &ev.Name, &ev.Slug, &ev.State, &eventType, &link, &embedCode, &startAt, &endAt, &pubAt, &heroImageID, &creAt, &updAt); err != nil {
+			return nil, err
+		}
+		ev.ID = strconv.Itoa(dbID)
+		ev.EventType = eventType.String
+		ev.Link = link.String
+		ev.EmbedCode = embedCode.String
+		if startAt.Valid {
+			ev.StartDate = r.formatTime(startAt.Time)
+		}
+		if endAt.Valid {
+			ev.EndDate = r.formatTime(endAt.Time)
+		}
+		if pubAt.Valid {
+			ev.PublishedDate = r.formatTime(pubAt.Time)
+		}
+		if creAt.Valid {
+			ev.CreatedAt = r.formatTime(creAt.Time)
+		}
+		if updAt.Valid {
+			ev.UpdatedAt = r.formatTime(updAt.Time)
+		}
+		heroID := 0
+		if heroImageID.Valid {
+			heroID = int(heroImageID.Int64)
+			imageIDs = append(imageIDs, heroID)
+		}
+		heroIDs = append(heroIDs, heroID)
+		result = append(result, ev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	imageMap, err := r.fetchImages(ctx, imageIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result {
+		if heroIDs[i] > 0 {
+			result[i].HeroImage = imageMap[heroIDs[i]]
+		}
+	}
+	return result, nil
+}
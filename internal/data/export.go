@@ -0,0 +1,89 @@
+package data
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportCursor marks the last post returned by QueryPostsForExport; exports
+// are ordered by (updatedAt, id) so a cursor resumes without gaps.
+type ExportCursor struct {
+	UpdatedAt time.Time
+	ID        int
+}
+
+// String encodes the cursor as an opaque URL-safe token.
+func (c ExportCursor) String() string {
+	raw := fmt.Sprintf("%d:%d", c.UpdatedAt.UnixMicro(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseExportCursor decodes a token produced by ExportCursor.String.
+func ParseExportCursor(token string) (ExportCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ExportCursor{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return ExportCursor{}, fmt.Errorf("invalid cursor %q", token)
+	}
+	us, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return ExportCursor{}, fmt.Errorf("invalid cursor time: %w", err)
+	}
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return ExportCursor{}, fmt.Errorf("invalid cursor id: %w", err)
+	}
+	return ExportCursor{UpdatedAt: time.UnixMicro(us).UTC(), ID: n}, nil
+}
+
+// QueryPostsForExport returns up to take published posts updated after since
+// (and after the cursor, when given) in (updatedAt, id) order, with relations
+// assembled like QueryPosts. Results bypass the cache; use CursorOf to
+// resume after any returned post.
+func (r *Repo) QueryPostsForExport(ctx context.Context, since time.Time, after *ExportCursor, take int) ([]Post, error) {
+	ctx = withOp(ctx, "posts_export")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(30*time.Second))
+	defer cancel()
+	if r.mock != nil {
+		return r.mock.postsForExport(since, after, take), nil
+	}
+
+	query := `SELECT ` + postListColumns + ` FROM "Post" p WHERE state = 'published' AND "updatedAt" > $1`
+	args := []interface{}{since}
+	if after != nil {
+		query += ` AND ("updatedAt", id) > ($2, $3)`
+		args = append(args, after.UpdatedAt, after.ID)
+	}
+	query += fmt.Sprintf(` ORDER BY "updatedAt", id LIMIT %d`, take)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	posts, err := r.scanPostRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(posts) == 0 {
+		return posts, nil
+	}
+	if err := r.enrichPosts(ctx, posts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// CursorOf returns the export cursor of a post loaded by QueryPostsForExport.
+func CursorOf(p Post) ExportCursor {
+	id, _ := strconv.Atoi(p.ID)
+	updatedAt, _ := p.Metadata["updatedAt"].(time.Time)
+	return ExportCursor{UpdatedAt: updatedAt, ID: id}
+}
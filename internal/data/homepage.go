@@ -0,0 +1,144 @@
+package data
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// HomepageSection holds the latest posts of one section.
+type HomepageSection struct {
+	Section Section `json:"section"`
+	Posts   []Post  `json:"posts"`
+}
+
+// Homepage bundles the lists rendered on the front page.
+type Homepage struct {
+	Sections  []HomepageSection `json:"sections"`
+	Topics    []Topic           `json:"topics"`
+	Externals []External        `json:"externals"`
+}
+
+// HomepageOptions selects what QueryHomepage returns.
+type HomepageOptions struct {
+	// SectionSlugs 首頁顯示的 section，依此順序輸出
+	SectionSlugs    []string
+	PostsPerSection int
+	TopicsTake      int
+	ExternalsTake   int
+}
+
+// QueryHomepage returns the latest published posts of each section, the
+// featured topics and the latest externals in one call. Section posts are
+// picked with a single window query and loaded with one QueryPosts call, so
+// enrichment is batched across all sections. Sections without posts are
+// omitted.
+func (r *Repo) QueryHomepage(ctx context.Context, opts HomepageOptions) (*Homepage, error) {
+	ctx = withOp(ctx, "homepage")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(15*time.Second))
+	defer cancel()
+
+	result := &Homepage{Sections: []HomepageSection{}, Topics: []Topic{}, Externals: []External{}}
+
+	if len(opts.SectionSlugs) > 0 && opts.PostsPerSection > 0 {
+		sections, err := r.queryHomepageSections(ctx, opts.SectionSlugs, opts.PostsPerSection)
+		if err != nil {
+			return nil, err
+		}
+		result.Sections = sections
+	}
+
+	if opts.TopicsTake > 0 {
+		published := "published"
+		featured := true
+		topics, err := r.QueryTopics(ctx, &TopicWhereInput{
+			State:      &StringFilter{Equals: &published},
+			IsFeatured: &BooleanFilter{Equals: &featured},
+		}, nil, opts.TopicsTake, 0)
+		if err != nil {
+			return nil, err
+		}
+		result.Topics = topics
+	}
+
+	if opts.ExternalsTake > 0 {
+		externals, err := r.QueryExternals(ctx, nil, nil, opts.ExternalsTake, 0)
+		if err != nil {
+			return nil, err
+		}
+		result.Externals = externals
+	}
+	return result, nil
+}
+
+func (r *Repo) queryHomepageSections(ctx context.Context, slugs []string, perSection int) ([]HomepageSection, error) {
+	if r.mock != nil {
+		return r.mock.homepageSections(slugs, perSection), nil
+	}
+	// 以 window function 一次取出每個 section 最新的 N 篇文章 id
+	query := `SELECT id, name, slug, state, post_id FROM (
+		SELECT sec.id, sec.name, sec.slug, sec.state, p.id AS post_id,
+			ROW_NUMBER() OVER (PARTITION BY sec.id ORDER BY p."publishedDate" DESC NULLS LAST, p.id DESC) AS rn
+		FROM "Section" sec
+		JOIN "_Post_sections" ps ON ps."B" = sec.id
+		JOIN "Post" p ON p.id = ps."A"
+		WHERE sec.slug = ANY($1) AND p.state = 'published'
+	) ranked WHERE rn <= $2 ORDER BY rn`
+	rows, err := r.db.QueryContext(ctx, query, slugs, perSection)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sectionsBySlug := map[string]Section{}
+	postIDsBySlug := map[string][]string{}
+	postIDs := []string{}
+	seen := map[string]bool{}
+	for rows.Next() {
+		var (
+			s      Section
+			postID int
+		)
+		if err := rows.Scan(&s.ID, &s.Name, &s.Slug, &s.State, &postID); err != nil {
+			return nil, err
+		}
+		id := strconv.Itoa(postID)
+		sectionsBySlug[s.Slug] = s
+		postIDsBySlug[s.Slug] = append(postIDsBySlug[s.Slug], id)
+		if !seen[id] {
+			seen[id] = true
+			postIDs = append(postIDs, id)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(postIDs) == 0 {
+		return []HomepageSection{}, nil
+	}
+
+	posts, err := r.QueryPosts(ctx, &PostWhereInput{ID: &IDFilter{In: postIDs}}, nil, -1, 0)
+	if err != nil {
+		return nil, err
+	}
+	postsByID := make(map[string]Post, len(posts))
+	for _, p := range posts {
+		postsByID[p.ID] = p
+	}
+
+	result := []HomepageSection{}
+	for _, slug := range slugs {
+		s, ok := sectionsBySlug[slug]
+		if !ok {
+			continue
+		}
+		list := make([]Post, 0, len(postIDsBySlug[slug]))
+		for _, id := range postIDsBySlug[slug] {
+			if p, ok := postsByID[id]; ok {
+				list = append(list, p)
+			}
+		}
+		result = append(result, HomepageSection{Section: s, Posts: list})
+	}
+	return result, nil
+}
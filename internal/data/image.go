@@ -0,0 +1,30 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// QueryPhoto returns the image with id and its resized URLs, or nil when it
+// does not exist. Results are not cached; callers such as the image redirect
+// rely on HTTP caching instead.
+func (r *Repo) QueryPhoto(ctx context.Context, id string) (*Photo, error) {
+	ctx = withOp(ctx, "photo_unique")
+	imageID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, nil
+	}
+	if r.mock != nil {
+		return r.mock.photos[strconv.Itoa(imageID)], nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
+	defer cancel()
+
+	images, err := r.fetchImages(ctx, []int{imageID})
+	if err != nil {
+		return nil, fmt.Errorf("query photo %s: %w", id, err)
+	}
+	return images[imageID], nil
+}
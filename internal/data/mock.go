@@ -0,0 +1,944 @@
+package data
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mockStore 為 MOCK_MODE 的資料來源：固定的示範資料，查詢全部在記憶體中完成。
+// 過濾與排序盡量與 SQL 一致，但只涵蓋前端常用的條件
+type mockStore struct {
+	posts         []mockPost
+	externals     []mockExternal
+	topics        []mockTopic
+	editorChoices []EditorChoice
+	events        []mockEvent
+	audios        []mockAudio
+	sections      []Section
+	categories    []Category
+	// sectionCategories 以 section slug 對應其 category
+	sectionCategories map[string][]Category
+	tags              []Tag
+	partners          []*Partner
+	contacts          []Contact
+	contactSlugs      map[string]string
+	photos            map[string]*Photo
+
+	mu    sync.Mutex
+	views map[string]int
+}
+
+type mockPost struct {
+	Post
+	published  time.Time
+	updated    time.Time
+	relatedIDs []string
+}
+
+type mockExternal struct {
+	External
+	published time.Time
+	created   time.Time
+	updated   time.Time
+}
+
+type mockTopic struct {
+	Topic
+	created time.Time
+	updated time.Time
+}
+
+type mockEvent struct {
+	Event
+	start time.Time
+	end   time.Time
+}
+
+type mockAudio struct {
+	Audio
+	created time.Time
+}
+
+// NewMockRepo returns a Repo serving a fixed in-memory dataset instead of a
+// database, for running the API locally without any infrastructure. Writes
+// such as view counts only live until the process exits.
+func NewMockRepo(staticsHost string, opts RepoOptions) *Repo {
+	r := &Repo{db: &tracedDB{}, staticsHost: staticsHost, opts: opts}
+	r.mock = newMockStore(r)
+	return r
+}
+
+// page 依 take / skip 分頁；take 小於 0 表示不限筆數，與 SQL 的 LIMIT 相同
+func page[T any](items []T, take, skip int) []T {
+	if skip > 0 {
+		if skip >= len(items) {
+			return []T{}
+		}
+		items = items[skip:]
+	}
+	if take >= 0 && take < len(items) {
+		items = items[:take]
+	}
+	return append([]T{}, items...)
+}
+
+// idPosition 回傳 id 在 ids 中的位置，供 id in 查詢依輸入順序排列
+func idPosition(ids []string, id string) int {
+	for i, v := range ids {
+		if v == id {
+			return i
+		}
+	}
+	return len(ids)
+}
+
+// ascending 依方向回傳比較結果；direction 空白時使用 def
+func ascending(direction, def string) bool {
+	dir := strings.ToUpper(direction)
+	if dir != "ASC" && dir != "DESC" {
+		dir = def
+	}
+	return dir == "ASC"
+}
+
+func lessTime(a, b time.Time, asc bool) bool {
+	// NULL 在 DESC 時排在最前面，與 Postgres 的預設相同
+	if asc {
+		return !a.IsZero() && (b.IsZero() || a.Before(b))
+	}
+	return a.IsZero() && !b.IsZero() || !b.IsZero() && a.After(b)
+}
+
+func lessString(a, b string, asc bool) bool {
+	if asc {
+		return a < b
+	}
+	return a > b
+}
+
+func mockMatchString(value string, f *StringFilter) bool {
+	if f == nil {
+		return true
+	}
+	if f.Equals != nil && value != *f.Equals {
+		return false
+	}
+	if len(f.In) > 0 && idPosition(f.In, value) == len(f.In) {
+		return false
+	}
+	if idPosition(f.NotIn, value) < len(f.NotIn) {
+		return false
+	}
+	if f.Not != nil && mockMatchString(value, f.Not) {
+		return false
+	}
+	return true
+}
+
+func mockMatchID(value string, f *IDFilter) bool {
+	if f == nil {
+		return true
+	}
+	if f.Equals != nil && value != *f.Equals {
+		return false
+	}
+	if len(f.In) > 0 && idPosition(f.In, value) == len(f.In) {
+		return false
+	}
+	return idPosition(f.NotIn, value) == len(f.NotIn)
+}
+
+func mockMatchBool(value bool, f *BooleanFilter) bool {
+	return f == nil || f.Equals == nil || value == *f.Equals
+}
+
+// mockMatchTime 比對 DateTimeNullableFilter，無法解析的時間視為不符合
+func mockMatchTime(value time.Time, f *DateTimeNullableFilter) bool {
+	if f == nil {
+		return true
+	}
+	for _, c := range []struct {
+		value *string
+		match func(t time.Time) bool
+	}{
+		{f.Equals, value.Equal},
+		{f.Gt, value.After},
+		{f.Gte, func(t time.Time) bool { return !value.Before(t) }},
+		{f.Lt, value.Before},
+		{f.Lte, func(t time.Time) bool { return !value.After(t) }},
+	} {
+		if c.value == nil {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, *c.value)
+		if err != nil || value.IsZero() || !c.match(t) {
+			return false
+		}
+	}
+	if f.Not != nil {
+		if value.IsZero() {
+			return false
+		}
+		if f.Not.Equals != nil {
+			if t, err := time.Parse(time.RFC3339, *f.Not.Equals); err == nil && value.Equal(t) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func mockMatchTags(tags []Tag, f *TagManyRelationFilter) bool {
+	if f == nil || f.Some == nil {
+		return true
+	}
+	for _, t := range tags {
+		if mockMatchString(t.Slug, f.Some.Slug) && mockMatchString(t.Name, f.Some.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *mockStore) post(id string) *mockPost {
+	for i := range m.posts {
+		if m.posts[i].ID == id {
+			return &m.posts[i]
+		}
+	}
+	return nil
+}
+
+func (m *mockStore) matchPost(p mockPost, where *PostWhereInput) bool {
+	if where == nil {
+		return true
+	}
+	style := p.Style
+	if style == "" {
+		style = "article"
+	}
+	if !mockMatchID(p.ID, where.ID) || !mockMatchString(p.Slug, where.Slug) || !mockMatchString(p.State, where.State) ||
+		!mockMatchString(style, where.Style) || !mockMatchBool(p.IsAdult, where.IsAdult) || !mockMatchBool(p.IsMember, where.IsMember) ||
+		!mockMatchBool(p.IsFeatured, where.IsFeatured) || !mockMatchBool(p.HeroVideo != nil, where.HasVideo) ||
+		!mockMatchTags(p.TagsAlgo, where.TagsAlgo) {
+		return false
+	}
+	if where.Sections != nil && where.Sections.Some != nil {
+		found := false
+		for _, s := range p.Sections {
+			w := where.Sections.Some
+			if mockMatchString(s.Name, w.Name) && mockMatchString(s.Slug, w.Slug) && mockMatchString(s.State, w.State) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if where.Categories != nil && where.Categories.Some != nil {
+		found := false
+		for _, c := range p.Categories {
+			w := where.Categories.Some
+			if mockMatchString(c.Name, w.Name) && mockMatchString(c.Slug, w.Slug) && mockMatchString(c.State, w.State) && mockMatchBool(c.IsMemberOnly, w.IsMemberOnly) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if where.Topics != nil && (where.Topics.ID != nil || where.Topics.Slug != nil) {
+		if p.Topics == nil || p.Topics.ID == "" || !mockMatchID(p.Topics.ID, where.Topics.ID) || !mockMatchString(p.Topics.Slug, where.Topics.Slug) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *mockStore) filterPosts(where *PostWhereInput) []mockPost {
+	result := []mockPost{}
+	for _, p := range m.posts {
+		if m.matchPost(p, where) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func (m *mockStore) queryPosts(where *PostWhereInput, orders []OrderRule, take, skip int) []Post {
+	posts := m.filterPosts(where)
+	sort.SliceStable(posts, func(i, j int) bool {
+		a, b := posts[i], posts[j]
+		if len(orders) > 0 {
+			asc := ascending(orders[0].Direction, "DESC")
+			switch orders[0].Field {
+			case "updatedAt":
+				return lessTime(a.updated, b.updated, asc)
+			case "title":
+				return lessString(a.Title, b.Title, asc)
+			case "publishedDate":
+				return lessTime(a.published, b.published, asc)
+			}
+		} else if where != nil && where.ID != nil && len(where.ID.In) > 0 {
+			return idPosition(where.ID.In, a.ID) < idPosition(where.ID.In, b.ID)
+		}
+		return lessTime(a.published, b.published, false)
+	})
+	result := make([]Post, 0, len(posts))
+	for _, p := range page(posts, take, skip) {
+		result = append(result, p.Post)
+	}
+	return result
+}
+
+func (m *mockStore) postsCountBySection(where *PostWhereInput) []SectionPostCount {
+	result := []SectionPostCount{}
+	for _, s := range m.sections {
+		count := 0
+		for _, p := range m.filterPosts(where) {
+			for _, ps := range p.Sections {
+				if ps.ID == s.ID {
+					count++
+					break
+				}
+			}
+		}
+		if count > 0 {
+			result = append(result, SectionPostCount{Section: s, Count: count})
+		}
+	}
+	return result
+}
+
+func (m *mockStore) postByUnique(where *PostWhereUniqueInput) *Post {
+	for _, p := range m.posts {
+		if (where.ID != nil && p.ID == *where.ID) || (where.ID == nil && where.Slug != nil && p.Slug == *where.Slug) {
+			post := p.Post
+			return &post
+		}
+	}
+	return nil
+}
+
+// postsForExport 與 QueryPostsForExport 相同，依 (updatedAt, id) 排序
+func (m *mockStore) postsForExport(since time.Time, after *ExportCursor, take int) []Post {
+	posts := []mockPost{}
+	for _, p := range m.posts {
+		if p.State != "published" || !p.updated.After(since) {
+			continue
+		}
+		if after != nil && !exportAfter(p, *after) {
+			continue
+		}
+		posts = append(posts, p)
+	}
+	sort.SliceStable(posts, func(i, j int) bool {
+		if !posts[i].updated.Equal(posts[j].updated) {
+			return posts[i].updated.Before(posts[j].updated)
+		}
+		return atoi(posts[i].ID) < atoi(posts[j].ID)
+	})
+	result := []Post{}
+	for _, p := range page(posts, take, 0) {
+		result = append(result, p.Post)
+	}
+	return result
+}
+
+func exportAfter(p mockPost, c ExportCursor) bool {
+	if !p.updated.Equal(c.UpdatedAt) {
+		return p.updated.After(c.UpdatedAt)
+	}
+	return atoi(p.ID) > c.ID
+}
+
+func (m *mockStore) matchExternal(e mockExternal, where *ExternalWhereInput) bool {
+	if where == nil {
+		return true
+	}
+	if !mockMatchString(e.Slug, where.Slug) || !mockMatchString(e.State, where.State) || !mockMatchTags(e.Tags, where.Tags) {
+		return false
+	}
+	// 與 SQL 的 JOIN 一致，沒有 partner 的 external 不符合任何 partner 條件
+	if where.Partner != nil && where.Partner.Slug != nil {
+		if e.Partner == nil || !mockMatchString(e.Partner.Slug, where.Partner.Slug) {
+			return false
+		}
+	}
+	if !mockMatchTime(e.published, where.PublishedDate) {
+		return false
+	}
+	return true
+}
+
+func (m *mockStore) filterExternals(where *ExternalWhereInput) []mockExternal {
+	result := []mockExternal{}
+	for _, e := range m.externals {
+		if m.matchExternal(e, where) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func (m *mockStore) queryExternals(where *ExternalWhereInput, orders []OrderRule, take, skip int) []External {
+	externals := m.filterExternals(where)
+	if len(orders) == 0 {
+		orders = []OrderRule{{Field: "publishedDate", Direction: "desc"}}
+	}
+	sort.SliceStable(externals, func(i, j int) bool {
+		a, b := externals[i], externals[j]
+		for _, rule := range orders {
+			asc := ascending(rule.Direction, "DESC")
+			switch rule.Field {
+			case "publishedDate":
+				if !a.published.Equal(b.published) {
+					return lessTime(a.published, b.published, asc)
+				}
+			case "updatedAt":
+				if !a.updated.Equal(b.updated) {
+					return lessTime(a.updated, b.updated, asc)
+				}
+			case "createdAt":
+				if !a.created.Equal(b.created) {
+					return lessTime(a.created, b.created, asc)
+				}
+			case "title":
+				if a.Title != b.Title {
+					return lessString(a.Title, b.Title, asc)
+				}
+			case "partnerName":
+				if a.Partner != nil && b.Partner != nil && a.Partner.Name != b.Partner.Name {
+					return lessString(a.Partner.Name, b.Partner.Name, asc)
+				}
+			}
+		}
+		return false
+	})
+	result := make([]External, 0, len(externals))
+	for _, e := range page(externals, take, skip) {
+		result = append(result, e.External)
+	}
+	return result
+}
+
+func (m *mockStore) externalsCountByPartner(where *ExternalWhereInput) []PartnerExternalCount {
+	result := []PartnerExternalCount{}
+	for _, partner := range m.partners {
+		count := 0
+		for _, e := range m.filterExternals(where) {
+			if e.Partner != nil && e.Partner.ID == partner.ID {
+				count++
+			}
+		}
+		if count > 0 {
+			p := *partner
+			result = append(result, PartnerExternalCount{Partner: &p, Count: count})
+		}
+	}
+	return result
+}
+
+func (m *mockStore) matchTopic(t mockTopic, where *TopicWhereInput) bool {
+	if where == nil {
+		return true
+	}
+	return mockMatchID(t.ID, where.ID) && mockMatchString(t.Slug, where.Slug) && mockMatchString(t.Name, where.Name) &&
+		mockMatchString(t.State, where.State) && mockMatchBool(t.IsFeatured, where.IsFeatured) &&
+		mockMatchString(t.Type, where.Type) && mockMatchString(t.Style, where.Style)
+}
+
+func (m *mockStore) filterTopics(where *TopicWhereInput) []mockTopic {
+	result := []mockTopic{}
+	for _, t := range m.topics {
+		if m.matchTopic(t, where) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func (m *mockStore) queryTopics(where *TopicWhereInput, orders []OrderRule, take, skip int) []Topic {
+	topics := m.filterTopics(where)
+	sortOrder := func(t mockTopic) int {
+		if t.SortOrder == nil {
+			return int(^uint(0) >> 1)
+		}
+		return *t.SortOrder
+	}
+	sort.SliceStable(topics, func(i, j int) bool {
+		a, b := topics[i], topics[j]
+		if len(orders) > 0 {
+			asc := ascending(orders[0].Direction, "ASC")
+			switch orders[0].Field {
+			case "createdAt":
+				return lessTime(a.created, b.created, asc)
+			case "updatedAt":
+				return lessTime(a.updated, b.updated, asc)
+			case "name":
+				return lessString(a.Name, b.Name, asc)
+			case "slug":
+				return lessString(a.Slug, b.Slug, asc)
+			case "sortOrder":
+				if asc {
+					return sortOrder(a) < sortOrder(b)
+				}
+				return sortOrder(a) > sortOrder(b)
+			}
+		} else if where != nil && where.ID != nil && len(where.ID.In) > 0 {
+			return idPosition(where.ID.In, a.ID) < idPosition(where.ID.In, b.ID)
+		}
+		if sortOrder(a) != sortOrder(b) {
+			return sortOrder(a) < sortOrder(b)
+		}
+		return lessTime(a.created, b.created, false)
+	})
+	result := make([]Topic, 0, len(topics))
+	for _, t := range page(topics, take, skip) {
+		result = append(result, t.Topic)
+	}
+	return result
+}
+
+func (m *mockStore) topicByUnique(where *TopicWhereUniqueInput) *Topic {
+	for _, t := range m.topics {
+		switch {
+		case where.ID != nil:
+			if t.ID != *where.ID {
+				continue
+			}
+		case where.Slug != nil:
+			if t.Slug != *where.Slug {
+				continue
+			}
+		case where.Name != nil:
+			if t.Name != *where.Name {
+				continue
+			}
+		default:
+			return nil
+		}
+		topic := t.Topic
+		return &topic
+	}
+	return nil
+}
+
+func (m *mockStore) queryEditorChoices(where *EditorChoiceWhereInput, take, skip int) []EditorChoice {
+	result := []EditorChoice{}
+	for _, c := range m.editorChoices {
+		if mockMatchString(c.State, where.State) && c.Choices != nil && c.Choices.State == "published" {
+			result = append(result, c)
+		}
+	}
+	return page(result, take, skip)
+}
+
+func (m *mockStore) queryEvents(where *EventWhereInput, take, skip int, now time.Time) []Event {
+	result := []Event{}
+	for _, e := range m.events {
+		if !mockMatchString(e.Slug, where.Slug) || !mockMatchString(e.State, where.State) || !mockMatchString(e.EventType, where.EventType) {
+			continue
+		}
+		if where.IsActive != nil {
+			active := !e.start.After(now) && (e.end.IsZero() || !e.end.Before(now))
+			if active != *where.IsActive {
+				continue
+			}
+		}
+		result = append(result, e.Event)
+	}
+	return page(result, take, skip)
+}
+
+func (m *mockStore) queryAudios(where *AudioWhereInput, take, skip int) []Audio {
+	result := []Audio{}
+	for _, a := range m.audios {
+		if where != nil && (!mockMatchID(a.ID, where.ID) || !mockMatchString(a.Name, where.Name)) {
+			continue
+		}
+		result = append(result, a.Audio)
+	}
+	return page(result, take, skip)
+}
+
+// changes 合併三種 entity 的變更，依 (updatedAt, kind, id) 排序，與 changedStoriesQuery 相同
+func (m *mockStore) changes(r *Repo, since time.Time, after *ChangeCursor, take int) []StoryChange {
+	type change struct {
+		StoryChange
+		updated time.Time
+		id      int
+	}
+	all := []change{}
+	add := func(kind, id, slug, state string, updated, fresh time.Time) {
+		if !updated.After(since) {
+			return
+		}
+		c := change{StoryChange: StoryChange{
+			Kind:      kind,
+			ID:        id,
+			Slug:      slug,
+			UpdatedAt: r.formatTime(updated),
+			Deleted:   state != "published",
+			Fresh:     fresh.After(since),
+		}, updated: updated, id: atoi(id)}
+		c.Cursor = ChangeCursor{UpdatedAt: updated, Kind: kind, ID: c.id}.String()
+		all = append(all, c)
+	}
+	for _, p := range m.posts {
+		add(ChangeKindPost, p.ID, p.Slug, p.State, p.updated, p.published)
+	}
+	for _, e := range m.externals {
+		add(ChangeKindExternal, e.ID, e.Slug, e.State, e.updated, e.published)
+	}
+	for _, t := range m.topics {
+		add(ChangeKindTopic, t.ID, t.Slug, t.State, t.updated, t.created)
+	}
+	less := func(a change, updated time.Time, kind string, id int) bool {
+		if !a.updated.Equal(updated) {
+			return a.updated.Before(updated)
+		}
+		if a.Kind != kind {
+			return a.Kind < kind
+		}
+		return a.id < id
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return less(all[i], all[j].updated, all[j].Kind, all[j].id)
+	})
+	result := []StoryChange{}
+	for _, c := range all {
+		if after != nil && !less(change{updated: after.UpdatedAt, StoryChange: StoryChange{Kind: after.Kind}, id: after.ID}, c.updated, c.Kind, c.id) {
+			continue
+		}
+		if len(result) >= take {
+			break
+		}
+		result = append(result, c.StoryChange)
+	}
+	return result
+}
+
+func (m *mockStore) homepageSections(slugs []string, perSection int) []HomepageSection {
+	result := []HomepageSection{}
+	for _, slug := range slugs {
+		where := &PostWhereInput{
+			State:    &StringFilter{Equals: ptrString("published")},
+			Sections: &SectionManyRelationFilter{Some: &SectionWhereInput{Slug: &StringFilter{Equals: &slug}}},
+		}
+		posts := m.queryPosts(where, nil, perSection, 0)
+		if len(posts) == 0 {
+			continue
+		}
+		for _, s := range m.sections {
+			if s.Slug == slug {
+				result = append(result, HomepageSection{Section: s, Posts: posts})
+				break
+			}
+		}
+	}
+	return result
+}
+
+// sectionPage 與 QuerySectionPage 相同，發布時間相同時文章排在外稿之前
+func (m *mockStore) sectionPage(slug string, partners []string, take, skip int) *SectionPage {
+	var result *SectionPage
+	for _, s := range m.sections {
+		if s.Slug == slug && s.State == "active" {
+			result = &SectionPage{Section: s, Categories: append([]Category{}, m.sectionCategories[slug]...)}
+			break
+		}
+	}
+	if result == nil {
+		return nil
+	}
+
+	type streamItem struct {
+		item      SectionPageItem
+		published time.Time
+		id        int
+	}
+	stream := []streamItem{}
+	for _, p := range m.posts {
+		if p.State != "published" || !mockHasSection(p.Sections, slug) {
+			continue
+		}
+		post := p.Post
+		stream = append(stream, streamItem{SectionPageItem{Kind: ChangeKindPost, Post: &post, PublishedDate: post.PublishedDate}, p.published, atoi(p.ID)})
+	}
+	for _, e := range m.externals {
+		if e.State != "published" || e.Partner == nil || !slices.Contains(partners, e.Partner.Slug) {
+			continue
+		}
+		external := e.External
+		stream = append(stream, streamItem{SectionPageItem{Kind: ChangeKindExternal, External: &external, PublishedDate: external.PublishedDate}, e.published, atoi(e.ID)})
+	}
+	sort.SliceStable(stream, func(i, j int) bool {
+		a, b := stream[i], stream[j]
+		if !a.published.Equal(b.published) {
+			return a.published.After(b.published)
+		}
+		if a.item.Kind != b.item.Kind {
+			return a.item.Kind == ChangeKindPost
+		}
+		return a.id > b.id
+	})
+	result.Items = []SectionPageItem{}
+	for _, s := range page(stream, take, skip) {
+		result.Items = append(result.Items, s.item)
+	}
+	return result
+}
+
+// postsArchive 與 QueryPostsArchive 相同，發布時間在 [start, end) 之間
+func (m *mockStore) postsArchive(start, end time.Time, sectionSlug string, take, skip int) []Post {
+	posts := []mockPost{}
+	for _, p := range m.posts {
+		if p.State != "published" || p.published.Before(start) || !p.published.Before(end) {
+			continue
+		}
+		if sectionSlug != "" && !mockHasSection(p.Sections, sectionSlug) {
+			continue
+		}
+		posts = append(posts, p)
+	}
+	sort.SliceStable(posts, func(i, j int) bool {
+		if !posts[i].published.Equal(posts[j].published) {
+			return posts[i].published.After(posts[j].published)
+		}
+		return atoi(posts[i].ID) > atoi(posts[j].ID)
+	})
+	result := make([]Post, 0, len(posts))
+	for _, p := range page(posts, take, skip) {
+		result = append(result, p.Post)
+	}
+	return result
+}
+
+func (m *mockStore) archiveMonths(sectionSlug string, loc *time.Location) []ArchiveMonth {
+	result := []ArchiveMonth{}
+	for _, p := range m.posts {
+		if p.State != "published" || p.published.IsZero() {
+			continue
+		}
+		if sectionSlug != "" && !mockHasSection(p.Sections, sectionSlug) {
+			continue
+		}
+		local := p.published.In(loc)
+		found := false
+		for i := range result {
+			if result[i].Year == local.Year() && result[i].Month == int(local.Month()) {
+				result[i].Count++
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, ArchiveMonth{Year: local.Year(), Month: int(local.Month()), Count: 1})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Year != result[j].Year {
+			return result[i].Year > result[j].Year
+		}
+		return result[i].Month > result[j].Month
+	})
+	return result
+}
+
+func mockHasSection(sections []Section, slug string) bool {
+	for _, s := range sections {
+		if s.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *mockStore) navigation() []NavigationSection {
+	result := []NavigationSection{}
+	for _, s := range m.sections {
+		if s.State != "active" {
+			continue
+		}
+		nav := NavigationSection{Section: s, Categories: []Category{}}
+		for _, c := range m.sectionCategories[s.Slug] {
+			if c.State == "active" {
+				nav.Categories = append(nav.Categories, c)
+			}
+		}
+		result = append(result, nav)
+	}
+	return result
+}
+
+func (m *mockStore) queryCategories(where *CategoryWhereInput, take, skip int) []Category {
+	result := []Category{}
+	for _, c := range m.categories {
+		if where != nil && (!mockMatchString(c.Name, where.Name) || !mockMatchString(c.Slug, where.Slug) ||
+			!mockMatchString(c.State, where.State) || !mockMatchBool(c.IsMemberOnly, where.IsMemberOnly)) {
+			continue
+		}
+		result = append(result, c)
+	}
+	return page(result, take, skip)
+}
+
+func (m *mockStore) tagSuggestions(prefix string, take int) []Tag {
+	lower := strings.ToLower(prefix)
+	result := []Tag{}
+	for _, t := range m.tags {
+		if strings.Contains(strings.ToLower(t.Name), lower) {
+			result = append(result, t)
+		}
+	}
+	// 開頭相符的排在前面，其次依名稱長度
+	sort.SliceStable(result, func(i, j int) bool {
+		pi := strings.HasPrefix(strings.ToLower(result[i].Name), lower)
+		pj := strings.HasPrefix(strings.ToLower(result[j].Name), lower)
+		if pi != pj {
+			return pi
+		}
+		if len([]rune(result[i].Name)) != len([]rune(result[j].Name)) {
+			return len([]rune(result[i].Name)) < len([]rune(result[j].Name))
+		}
+		return result[i].Name < result[j].Name
+	})
+	return page(result, take, 0)
+}
+
+func (m *mockStore) searchContacts(q string, take int) []Contact {
+	lower := strings.ToLower(q)
+	result := []Contact{}
+	for _, c := range m.contacts {
+		if strings.Contains(strings.ToLower(strings.ReplaceAll(c.Name, " ", "")), lower) {
+			result = append(result, c)
+		}
+	}
+	return page(result, take, 0)
+}
+
+func (m *mockStore) authorByUnique(where *AuthorWhereUniqueInput) *Contact {
+	for _, c := range m.contacts {
+		if (where.ID != nil && c.ID == *where.ID) || (where.ID == nil && where.Slug != nil && m.contactSlugs[c.ID] == *where.Slug) {
+			contact := c
+			return &contact
+		}
+	}
+	return nil
+}
+
+// filterAuthorPosts 與 authorPostIDsSQL 相同，涵蓋所有角色且每篇只出現一次
+func (m *mockStore) filterAuthorPosts(contactID string) []mockPost {
+	result := []mockPost{}
+	for _, p := range m.posts {
+		if p.State != "published" {
+			continue
+		}
+		for _, role := range [][]Contact{p.Writers, p.Photographers, p.CameraMan, p.Designers, p.Engineers, p.Vocals} {
+			if mockHasContact(role, contactID) {
+				result = append(result, p)
+				break
+			}
+		}
+	}
+	return result
+}
+
+func (m *mockStore) authorPosts(contactID string, take, skip int) []Post {
+	posts := m.filterAuthorPosts(contactID)
+	sort.SliceStable(posts, func(i, j int) bool {
+		if !posts[i].published.Equal(posts[j].published) {
+			return posts[i].published.After(posts[j].published)
+		}
+		return atoi(posts[i].ID) > atoi(posts[j].ID)
+	})
+	result := make([]Post, 0, len(posts))
+	for _, p := range page(posts, take, skip) {
+		result = append(result, p.Post)
+	}
+	return result
+}
+
+func (m *mockStore) tagByUnique(where *TagWhereUniqueInput) *Tag {
+	for _, t := range m.tags {
+		if (where.ID != nil && t.ID == *where.ID) || (where.ID == nil && where.Slug != nil && t.Slug == *where.Slug) {
+			found := t
+			return &found
+		}
+	}
+	return nil
+}
+
+func (m *mockStore) partnerByUnique(where *PartnerWhereUniqueInput) *Partner {
+	for _, p := range m.partners {
+		if (where.ID != nil && p.ID == *where.ID) || (where.ID == nil && where.Slug != nil && p.Slug == *where.Slug) {
+			found := *p
+			return &found
+		}
+	}
+	return nil
+}
+
+// relatedPosts 依關聯的 owner（tag、section、category）分組回傳符合 where 的文章，各 owner 分別分頁
+func (m *mockStore) relatedPosts(rel postRelation, ownerIDs []string, where *PostWhereInput, orders []OrderRule, take, skip int) map[string][]Post {
+	posts := m.queryPosts(where, orders, -1, 0)
+	result := make(map[string][]Post, len(ownerIDs))
+	for _, id := range ownerIDs {
+		related := []Post{}
+		for _, p := range posts {
+			if owners := rel.owners(p); idPosition(owners, id) < len(owners) {
+				related = append(related, p)
+			}
+		}
+		result[id] = page(related, take, skip)
+	}
+	return result
+}
+
+func (m *mockStore) relatedPostsCount(rel postRelation, ownerIDs []string, where *PostWhereInput) map[string]int {
+	posts := m.filterPosts(where)
+	result := make(map[string]int, len(ownerIDs))
+	for _, id := range ownerIDs {
+		for _, p := range posts {
+			if owners := rel.owners(p.Post); idPosition(owners, id) < len(owners) {
+				result[id]++
+			}
+		}
+	}
+	return result
+}
+
+func mockHasContact(contacts []Contact, id string) bool {
+	for _, c := range contacts {
+		if c.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *mockStore) recordView(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.post(id) != nil {
+		m.views[id]++
+	}
+}
+
+func (m *mockStore) postViews(id string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.views[id]
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
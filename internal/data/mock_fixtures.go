@@ -0,0 +1,387 @@
+package data
+
+import (
+	"strconv"
+	"time"
+)
+
+// mockEpoch 示範資料的基準時間；固定值讓每次啟動的回應都相同
+var mockEpoch = time.Date(2024, 6, 1, 4, 0, 0, 0, time.UTC)
+
+// mockAt 回傳基準時間之前 hours 小時
+func mockAt(hours int) time.Time {
+	return mockEpoch.Add(-time.Duration(hours) * time.Hour)
+}
+
+// draftDoc 以段落文字組出 draft-js 格式的 brief / content
+func draftDoc(key string, paragraphs ...string) map[string]any {
+	blocks := make([]any, 0, len(paragraphs))
+	for i, text := range paragraphs {
+		blocks = append(blocks, map[string]any{
+			"key":               key + strconv.Itoa(i),
+			"text":              text,
+			"type":              "unstyled",
+			"depth":             0,
+			"inlineStyleRanges": []any{},
+			"entityRanges":      []any{},
+			"data":              map[string]any{},
+		})
+	}
+	return map[string]any{"blocks": blocks, "entityMap": map[string]any{}}
+}
+
+// newMockStore 組出示範資料，內容與 `go-story seed` 寫入的資料相同
+func newMockStore(r *Repo) *mockStore {
+	m := &mockStore{photos: map[string]*Photo{}, views: map[string]int{}}
+
+	for _, im := range []struct {
+		id, name, fileID, keywords string
+		width, height              int
+	}{
+		{"1", "颱風過境 台北街景", "seed-typhoon", "", 1600, 900},
+		{"2", "金曲獎紅毯", "seed-golden-melody", "", 1600, 1067},
+		{"3", "夜市小吃", "seed-night-market", "", 1200, 800},
+		{"4", "專題主視覺 台灣山林", "seed-topic-hero", "登山,林道", 1920, 1080},
+		{"5", "玉山主峰", "seed-yushan", "玉山", 1600, 1067},
+		{"6", "雪山圈谷", "seed-xueshan", "雪山", 1600, 1067},
+		{"7", "音樂節舞台", "seed-music-festival", "", 1600, 900},
+	} {
+		photo := &Photo{
+			ID:            im.id,
+			Name:          im.name,
+			TopicKeywords: im.keywords,
+			ImageFile:     ImageFile{Width: im.width, Height: im.height},
+			Metadata:      map[string]any{"name": im.name, "topicKeywords": im.keywords},
+		}
+		r.setResizedURLs(photo, im.fileID, "jpg", imageFormats{webp: true, avif: true})
+		m.photos[im.id] = photo
+	}
+
+	video := &Video{
+		ID:        "1",
+		Name:      "颱風現場直擊",
+		State:     "published",
+		VideoSrc:  r.buildFileURL("seed-typhoon.mp4"),
+		Mp4Src:    r.buildFileURL("seed-typhoon.mp4"),
+		Duration:  95,
+		HeroImage: m.photos["1"],
+	}
+	audio := Audio{
+		ID:        "1",
+		Name:      "鏡週刊 Podcast：本週焦點",
+		File:      AudioFile{Filename: "seed-podcast-01.mp3", Filesize: 5242880, URL: r.buildFileURL("seed-podcast-01.mp3")},
+		HeroImage: m.photos["7"],
+		CreatedAt: r.formatTime(mockAt(48)),
+		UpdatedAt: r.formatTime(mockAt(48)),
+	}
+	m.audios = []mockAudio{{Audio: audio, created: mockAt(48)}}
+
+	sections := map[string]Section{
+		"news":          {ID: "1", Name: "時事", Slug: "news", State: "active"},
+		"entertainment": {ID: "2", Name: "娛樂", Slug: "entertainment", State: "active"},
+		"life":          {ID: "3", Name: "生活", Slug: "life", State: "active"},
+		"member":        {ID: "4", Name: "會員專區", Slug: "member", State: "active"},
+	}
+	for _, slug := range []string{"news", "entertainment", "life", "member"} {
+		m.sections = append(m.sections, sections[slug])
+	}
+	categories := map[string]Category{
+		"political": {ID: "1", Name: "焦點", Slug: "political", State: "active"},
+		"celebrity": {ID: "2", Name: "娛樂頭條", Slug: "celebrity", State: "active"},
+		"food":      {ID: "3", Name: "美食", Slug: "food", State: "active"},
+		"report":    {ID: "4", Name: "深度報導", Slug: "report", State: "active", IsMemberOnly: true},
+	}
+	// 與 seed 的 _Category_sections 相同，每個 category 屬於一個 section
+	for category, section := range map[string]string{"political": "news", "celebrity": "entertainment", "food": "life", "report": "member"} {
+		c := categories[category]
+		c.Sections = []Section{sections[section]}
+		categories[category] = c
+	}
+	for _, slug := range []string{"political", "celebrity", "food", "report"} {
+		m.categories = append(m.categories, categories[slug])
+	}
+	m.sectionCategories = map[string][]Category{
+		"news":          {categories["political"]},
+		"entertainment": {categories["celebrity"]},
+		"life":          {categories["food"]},
+		"member":        {categories["report"]},
+	}
+	m.contacts = []Contact{{ID: "1", Name: "王小明"}, {ID: "2", Name: "陳美玲"}, {ID: "3", Name: "林志強"}}
+	m.contactSlugs = map[string]string{"1": "wang-xiaoming", "2": "chen-meiling", "3": "lin-zhiqiang"}
+	m.tags = []Tag{
+		{ID: "1", Name: "颱風", Slug: "typhoon"},
+		{ID: "2", Name: "金曲獎", Slug: "golden-melody"},
+		{ID: "3", Name: "夜市", Slug: "night-market"},
+		{ID: "4", Name: "登山", Slug: "hiking"},
+		{ID: "5", Name: "天氣", Slug: "weather"},
+	}
+	contact := func(i int) Contact { return m.contacts[i-1] }
+	tag := func(i int) Tag { return m.tags[i-1] }
+
+	order := 1
+	topic := Topic{
+		ID:                           "1",
+		Name:                         "走進台灣山林",
+		Slug:                         "taiwan-mountains",
+		SortOrder:                    &order,
+		State:                        "published",
+		Brief:                        draftDoc("t1b", "百岳、林道與山屋，帶你認識台灣的高山。"),
+		HeroImage:                    m.photos["4"],
+		Leading:                      "slideshow",
+		OgTitle:                      "走進台灣山林",
+		OgDescription:                "百岳、林道與山屋，帶你認識台灣的高山。",
+		OgImage:                      m.photos["4"],
+		IsFeatured:                   true,
+		Type:                         "list",
+		Tags:                         []Tag{tag(4)},
+		SlideshowImages:              []Photo{*m.photos["5"], *m.photos["6"]},
+		SlideshowImagesInOrder:       []Photo{*m.photos["6"], *m.photos["5"]},
+		ManualOrderOfSlideshowImages: []any{map[string]any{"id": "6"}, map[string]any{"id": "5"}},
+		CreatedAt:                    r.formatTime(mockAt(720)),
+		UpdatedAt:                    r.formatTime(mockAt(24)),
+	}
+	groupOrder := 2
+	group := Topic{
+		ID:            "2",
+		Name:          "台灣戶外指南",
+		Slug:          "taiwan-outdoors",
+		SortOrder:     &groupOrder,
+		State:         "published",
+		Brief:         draftDoc("t2b", "登山、露營與單車，整理台灣的戶外專題。"),
+		HeroImage:     m.photos["6"],
+		OgTitle:       "台灣戶外指南",
+		OgDescription: "登山、露營與單車，整理台灣的戶外專題。",
+		OgImage:       m.photos["6"],
+		Type:          "group",
+		CreatedAt:     r.formatTime(mockAt(960)),
+		UpdatedAt:     r.formatTime(mockAt(48)),
+	}
+	// 文章的 topics 與 DB 模式一樣不帶關聯，因此在 posts 組好前保留未連結的 topic
+	linkedTopic, linkedGroup := topic, group
+	linkedTopic.ParentTopic = relatedTopic(group)
+	linkedGroup.Subtopics = []Topic{*relatedTopic(topic)}
+	m.topics = []mockTopic{
+		{Topic: linkedTopic, created: mockAt(720), updated: mockAt(24)},
+		{Topic: linkedGroup, created: mockAt(960), updated: mockAt(48)},
+	}
+
+	type postFixture struct {
+		post       Post
+		published  int
+		updated    int
+		sections   []string
+		categories []string
+		writers    []int
+		photogs    []int
+		tags       []int
+		tagsAlgo   []int
+		relateds   []string
+		hero       string
+		video      bool
+		heroAudio  bool
+		topic      bool
+	}
+	fixtures := []postFixture{
+		{
+			post: Post{ID: "1", Slug: "20261001-typhoon", Title: "颱風逼近 北部今晚起防強風豪雨", Subtitle: "氣象署發布海上陸上颱風警報", State: "published", Style: "article",
+				HeroCaption: "颱風外圍環流影響，台北街頭風雨交加。", IsFeatured: true,
+				OgTitle: "颱風逼近 北部今晚起防強風豪雨", OgDescription: "氣象署發布海上陸上颱風警報",
+				Brief:   draftDoc("p1b", "颱風持續逼近，氣象署提醒北部及東北部民眾嚴防強風豪雨。"),
+				Content: draftDoc("p1c", "氣象署表示，颱風中心今晚將通過北部海面。", "請民眾減少外出，並注意最新警報。")},
+			published: 6, updated: 6, sections: []string{"news"}, categories: []string{"political"},
+			writers: []int{1}, photogs: []int{2}, tags: []int{1, 5}, tagsAlgo: []int{5}, relateds: []string{"4"}, hero: "1", video: true,
+		},
+		{
+			post: Post{ID: "2", Slug: "20261002-golden-melody", Title: "金曲獎紅毯 星光熠熠", State: "published", Style: "article",
+				HeroCaption: "入圍者陸續走上紅毯。",
+				Brief:       draftDoc("p2b", "金曲獎今晚登場，入圍者盛裝出席。"),
+				Content:     draftDoc("p2c", "今年金曲獎共有多位新人入圍，競爭激烈。")},
+			published: 24, updated: 24, sections: []string{"entertainment"}, categories: []string{"celebrity"},
+			writers: []int{2}, tags: []int{2}, hero: "2",
+		},
+		{
+			post: Post{ID: "3", Slug: "20261003-night-market", Title: "在地人推薦的夜市小吃", State: "published", Style: "wide",
+				Brief:   draftDoc("p3b", "從蚵仔煎到胡椒餅，一次吃遍夜市經典。"),
+				Content: draftDoc("p3c", "夜市是台灣飲食文化的縮影。")},
+			published: 48, updated: 48, sections: []string{"life"}, categories: []string{"food"},
+			writers: []int{1}, tags: []int{3}, hero: "3", heroAudio: true,
+		},
+		{
+			post: Post{ID: "4", Slug: "20261004-yushan", Title: "玉山攻頂全紀錄", Subtitle: "第一次挑戰百岳就上手", State: "published", Style: "article",
+				Brief:   draftDoc("p4b", "從排雲山莊出發，凌晨摸黑攻頂看日出。"),
+				Content: draftDoc("p4c", "申請入園與山屋床位是第一步。")},
+			published: 72, updated: 72, sections: []string{"life"},
+			writers: []int{3}, photogs: []int{3}, tags: []int{4}, tagsAlgo: []int{4}, relateds: []string{"5"}, hero: "5", topic: true,
+		},
+		{
+			post: Post{ID: "5", Slug: "20261005-member-report", Title: "會員獨享：山屋經濟調查", State: "published", Style: "article", IsMember: true,
+				Brief:   draftDoc("p5b", "山屋一位難求，背後的供需問題。"),
+				Content: draftDoc("p5c", "本文為會員專屬內容。", "調查發現，熱門山屋的抽籤中籤率不到兩成。")},
+			published: 96, updated: 96, sections: []string{"member"}, categories: []string{"report"},
+			writers: []int{3}, tags: []int{4}, relateds: []string{"4"}, hero: "6", topic: true,
+		},
+		{
+			post: Post{ID: "6", Slug: "20261006-draft", Title: "尚未發布的草稿", State: "draft", Style: "article",
+				Brief: draftDoc("p6b"), Content: draftDoc("p6c")},
+			published: -1, updated: 1, sections: []string{"news"}, categories: []string{"political"},
+		},
+	}
+	for _, f := range fixtures {
+		p := f.post
+		var published time.Time
+		if f.published >= 0 {
+			published = mockAt(f.published)
+			p.PublishedDate = r.formatTime(published)
+		}
+		updated := mockAt(f.updated)
+		p.UpdatedAt = r.formatTime(updated)
+		p.TrimmedContent = p.Content
+		for _, slug := range f.sections {
+			p.Sections = append(p.Sections, sections[slug])
+		}
+		p.SectionsInInputOrder = p.Sections
+		for _, slug := range f.categories {
+			p.Categories = append(p.Categories, categories[slug])
+		}
+		p.CategoriesInInputOrder = p.Categories
+		for _, id := range f.writers {
+			p.Writers = append(p.Writers, contact(id))
+		}
+		p.WritersInInputOrder = p.Writers
+		for _, id := range f.photogs {
+			p.Photographers = append(p.Photographers, contact(id))
+		}
+		for _, id := range f.tags {
+			p.Tags = append(p.Tags, tag(id))
+		}
+		for _, id := range f.tagsAlgo {
+			p.TagsAlgo = append(p.TagsAlgo, tag(id))
+		}
+		if f.hero != "" {
+			p.HeroImage = m.photos[f.hero]
+		}
+		if f.video {
+			p.HeroVideo = video
+		}
+		if f.heroAudio {
+			p.HeroAudio = &audio
+		}
+		if f.topic {
+			t := topic
+			p.Topics = &t
+		}
+		p.Metadata = map[string]any{"updatedAt": updated}
+		m.posts = append(m.posts, mockPost{Post: p, published: published, updated: updated, relatedIDs: f.relateds})
+	}
+	// 相關文章只帶基本欄位，不再展開其相關文章
+	for i := range m.posts {
+		for _, id := range m.posts[i].relatedIDs {
+			if rp := m.post(id); rp != nil {
+				related := rp.Post
+				related.Relateds, related.RelatedsInInputOrder = nil, nil
+				m.posts[i].Relateds = append(m.posts[i].Relateds, related)
+			}
+		}
+		m.posts[i].RelatedsInInputOrder = m.posts[i].Relateds
+	}
+
+	m.partners = []*Partner{
+		{ID: "1", Slug: "ebc", Name: "東森新聞", ShowOnIndex: true, ShowThumb: true},
+		{ID: "2", Slug: "healthnews", Name: "健康醫療網", ShowThumb: true, ShowBrief: true},
+	}
+	for _, e := range []struct {
+		ext      External
+		hours    int
+		partner  int
+		tags     []int
+		relateds []string
+	}{
+		{External{ID: "1", Slug: "ebc-20261001-traffic", Title: "颱風影響 國道車流管制", State: "published", ExtendByline: "東森新聞",
+			Thumb: "https://example.com/seed/ebc-traffic.jpg", Brief: "<p>國道部分路段實施車流管制。</p>",
+			Content: "<p>高公局表示，颱風期間國道部分路段將實施管制，請用路人留意。</p>"}, 5, 1, []int{1}, []string{"1"}},
+		{External{ID: "2", Slug: "healthnews-20261002-sleep", Title: "睡不好？醫師教你改善失眠", State: "published", ExtendByline: "健康醫療網",
+			Thumb: "https://example.com/seed/healthnews-sleep.jpg", Brief: "<p>規律作息是改善睡眠的第一步。</p>",
+			Content: "<p>醫師建議睡前一小時避免使用手機。</p>"}, 24, 2, nil, nil},
+	} {
+		ext := e.ext
+		at := mockAt(e.hours)
+		ext.PublishedDate = r.formatTime(at)
+		ext.UpdatedAt = r.formatTime(at)
+		ext.Partner = m.partners[e.partner-1]
+		ext.Metadata = map[string]any{"partnerID": e.partner}
+		for _, id := range e.tags {
+			ext.Tags = append(ext.Tags, tag(id))
+		}
+		// 與 fetchExternalRelateds 一致只保留已發布的相關文章
+		for _, id := range e.relateds {
+			if rp := m.post(id); rp != nil && rp.State == "published" {
+				ext.Relateds = append(ext.Relateds, rp.Post)
+			}
+		}
+		m.externals = append(m.externals, mockExternal{External: ext, published: at, created: at, updated: at})
+	}
+
+	for i, c := range []struct {
+		name   string
+		postID string
+		hours  int
+	}{
+		{"颱風逼近", "1", 6},
+		{"玉山攻頂", "4", 72},
+	} {
+		sortOrder := i + 1
+		choice := EditorChoice{
+			ID:            strconv.Itoa(i + 1),
+			Name:          c.name,
+			SortOrder:     &sortOrder,
+			State:         "published",
+			PublishedDate: r.formatTime(mockAt(c.hours)),
+			CreatedAt:     r.formatTime(mockAt(c.hours)),
+			UpdatedAt:     r.formatTime(mockAt(c.hours)),
+		}
+		if p := m.post(c.postID); p != nil {
+			post := p.Post
+			choice.Choices = &post
+		}
+		m.editorChoices = append(m.editorChoices, choice)
+	}
+
+	// 活動沒有結束時間，isActive 不受目前時間影響
+	m.events = []mockEvent{{
+		Event: Event{
+			ID:            "1",
+			Name:          "秋季音樂節",
+			Slug:          "autumn-music-festival",
+			State:         "published",
+			EventType:     "mod",
+			Link:          "https://example.com/seed/festival",
+			StartDate:     r.formatTime(mockAt(24)),
+			PublishedDate: r.formatTime(mockAt(72)),
+			HeroImage:     m.photos["7"],
+			CreatedAt:     r.formatTime(mockAt(72)),
+			UpdatedAt:     r.formatTime(mockAt(72)),
+		},
+		start: mockAt(24),
+	}}
+
+	for id, views := range map[string]int{"1": 1280, "2": 640, "3": 320, "4": 160} {
+		m.views[id] = views
+	}
+	return m
+}
+
+// relatedTopic 回傳 parentTopic / subtopics 中的 topic，欄位與 DB 模式的 relatedTopicSelect 相同
+func relatedTopic(t Topic) *Topic {
+	return &Topic{
+		ID:        t.ID,
+		Name:      t.Name,
+		Slug:      t.Slug,
+		SortOrder: t.SortOrder,
+		State:     t.State,
+		Brief:     t.Brief,
+		HeroImage: t.HeroImage,
+		HeroURL:   t.HeroURL,
+		Type:      t.Type,
+		Style:     t.Style,
+	}
+}
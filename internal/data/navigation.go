@@ -0,0 +1,94 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+)
+
+// navigationTTL 導覽很少變動，cache 時間比一般查詢長；CMS 修改 section / category 時以 purge 清除
+const navigationTTL = 24 * time.Hour
+
+// NavigationSection is an active section with its active categories.
+type NavigationSection struct {
+	Section    Section    `json:"section"`
+	Categories []Category `json:"categories"`
+}
+
+// QueryNavigation returns the active sections ordered by id, each with its
+// active categories ordered by id, in a single query. The tree is cached for
+// a day; purge "section" or "category" after editing them.
+func (r *Repo) QueryNavigation(ctx context.Context) ([]NavigationSection, error) {
+	ctx = withOp(ctx, "navigation")
+	if r.mock != nil {
+		return r.mock.navigation(), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
+	defer cancel()
+
+	cacheKey := GenerateCacheKey("navigation", nil)
+	if r.cache != nil && r.cache.Enabled() {
+		var cached []NavigationSection
+		if found, _ := r.cache.Get(ctx, cacheKey, &cached); found {
+			return cached, nil
+		}
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT s.id, s.name, s.slug, s.state, c.id, c.name, c.slug, c.state, c."isMemberOnly"
+FROM "Section" s
+LEFT JOIN "_Category_sections" cs ON cs."B" = s.id
+LEFT JOIN "Category" c ON c.id = cs."A" AND c.state = 'active'
+WHERE s.state = 'active'
+ORDER BY s.id, c.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []NavigationSection{}
+	for rows.Next() {
+		var (
+			s          Section
+			sectionID  int
+			categoryID sql.NullInt64
+			name, slug sql.NullString
+			state      sql.NullString
+			memberOnly sql.NullBool
+		)
+		if err := rows.Scan(&sectionID, &s.Name, &s.Slug, &s.State, &categoryID, &name, &slug, &state, &memberOnly); err != nil {
+			return nil, err
+		}
+		s.ID = strconv.Itoa(sectionID)
+		// 同一個 section 的資料列相鄰
+		if len(result) == 0 || result[len(result)-1].Section.ID != s.ID {
+			result = append(result, NavigationSection{Section: s, Categories: []Category{}})
+		}
+		if categoryID.Valid {
+			last := &result[len(result)-1]
+			last.Categories = append(last.Categories, Category{
+				ID:           strconv.FormatInt(categoryID.Int64, 10),
+				Name:         name.String,
+				Slug:         slug.String,
+				State:        state.String,
+				IsMemberOnly: memberOnly.Bool,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	lists := make([][]Category, 0, len(result))
+	for _, s := range result {
+		lists = append(lists, s.Categories)
+	}
+	if err := r.attachCategorySections(ctx, lists...); err != nil {
+		return nil, err
+	}
+
+	if r.cache != nil && r.cache.Enabled() {
+		_ = r.cache.SetWithTTL(ctx, cacheKey, result, navigationTTL)
+	}
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// PartnerWhereUniqueInput selects one partner by id or slug.
+type PartnerWhereUniqueInput struct {
+	ID   *string `mapstructure:"id"`
+	Slug *string `mapstructure:"slug"`
+}
+
+func DecodePartnerWhereUnique(input interface{}) (*PartnerWhereUniqueInput, error) {
+	if input == nil {
+		return nil, nil
+	}
+	var where PartnerWhereUniqueInput
+	if err := decodeInto(input, &where); err != nil {
+		return nil, fmt.Errorf("partner unique where: %w", err)
+	}
+	return &where, nil
+}
+
+// QueryPartner returns the partner matching where, or nil when none does.
+func (r *Repo) QueryPartner(ctx context.Context, where *PartnerWhereUniqueInput) (*Partner, error) {
+	ctx = withOp(ctx, "partner")
+	if where == nil || (where.ID == nil && where.Slug == nil) {
+		return nil, nil
+	}
+	if r.mock != nil {
+		return r.mock.partnerByUnique(where), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
+	defer cancel()
+
+	const partnerSelect = `SELECT id, slug, name, "showOnIndex", COALESCE("showThumb", true), COALESCE("showBrief", false) FROM "Partner" `
+	var (
+		p    Partner
+		dbID int
+		row  *sql.Row
+	)
+	if where.ID != nil {
+		ids, err := parseIDs([]string{*where.ID})
+		if err != nil {
+			return nil, err
+		}
+		row = r.db.QueryRowContext(ctx, partnerSelect+`WHERE id = $1`, ids[0])
+	} else {
+		row = r.db.QueryRowContext(ctx, partnerSelect+`WHERE slug = $1`, *where.Slug)
+	}
+	if err := row.Scan(&dbID, &p.Slug, &p.Name, &p.ShowOnIndex, &p.ShowThumb, &p.ShowBrief); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	p.ID = strconv.Itoa(dbID)
+	return &p, nil
+}
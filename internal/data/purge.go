@@ -0,0 +1,105 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// purgeScanCount 每次 SCAN 取回的 key 數量建議值
+const purgeScanCount = 500
+
+// purgePrefixes 為各種 entity 變更時需要清除的 cache key prefix；
+// key 是查詢參數的 hash，新增或下架會改變哪些查詢包含該 entity，因此清除所有可能包含它的查詢。
+// 只修改內容時以 PurgeEntity 依索引清除即可
+var purgePrefixes = map[string][]string{
+	// 文章也會出現在 topic、精選與 external 的 relateds 中
+	"post":         {"posts", "post:unique", "topics", "topic:unique", "editorChoices", "externals", ResponseCachePrefix},
+	"topic":        {"topics", "topic:unique", "topicsCount", "posts", "post:unique", ResponseCachePrefix},
+	"external":     {"externals", ResponseCachePrefix},
+	"editorChoice": {"editorChoices", ResponseCachePrefix},
+	"audio":        {"audios", "posts", "post:unique", ResponseCachePrefix},
+	// tag 名稱也會出現在文章、topic 與 external 中
+	"tag": {"tagSuggest", "posts", "post:unique", "topics", "topic:unique", "externals", ResponseCachePrefix},
+	// section 與 category 也會出現在文章中
+	"section":  {"navigation", "posts", "post:unique", ResponseCachePrefix},
+	"category": {"navigation", "posts", "post:unique", ResponseCachePrefix},
+}
+
+// ResponseCachePrefix is the key prefix of whole GraphQL responses cached by
+// the handler. A response may contain any entity, so every purge clears it.
+const ResponseCachePrefix = "gqlResponse"
+
+// PurgeEntities lists the entity kinds accepted by Cache.Purge, plus "all".
+func PurgeEntities() []string {
+	kinds := make([]string, 0, len(purgePrefixes)+1)
+	for kind := range purgePrefixes {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return append(kinds, "all")
+}
+
+// Purge deletes the cached queries that may contain an entity of the given
+// kind ("post", "topic", ...; "all" clears every query prefix) and returns
+// the number of deleted keys.
+func (c *Cache) Purge(ctx context.Context, entity string) (int, error) {
+	if !c.Enabled() {
+		return 0, nil
+	}
+	prefixes, ok := purgePrefixes[entity]
+	if entity == "all" {
+		prefixes, ok = append(allPurgePrefixes(), entityIndexPrefix), true
+	}
+	if !ok {
+		return 0, fmt.Errorf("unknown entity %q", entity)
+	}
+
+	deleted := 0
+	for _, prefix := range prefixes {
+		n, err := c.deletePrefix(ctx, prefix)
+		deleted += n
+		if err != nil {
+			return deleted, fmt.Errorf("purge %s: %w", prefix, err)
+		}
+	}
+	return deleted, nil
+}
+
+// deletePrefix 以 SCAN 找出 prefix 開頭的 key 並以 UNLINK 刪除，不會像 KEYS 一樣阻塞 Redis
+func (c *Cache) deletePrefix(ctx context.Context, prefix string) (int, error) {
+	deleted := 0
+	var cursor uint64
+	for {
+		keys, next, err := c.client.Scan(ctx, cursor, c.key(prefix)+":*", purgeScanCount).Result()
+		if err != nil {
+			return deleted, err
+		}
+		if len(keys) > 0 {
+			n, err := c.client.Unlink(ctx, keys...).Result()
+			if err != nil {
+				return deleted, err
+			}
+			deleted += int(n)
+		}
+		if next == 0 {
+			return deleted, nil
+		}
+		cursor = next
+	}
+}
+
+func allPurgePrefixes() []string {
+	seen := map[string]bool{}
+	var prefixes []string
+	for _, list := range purgePrefixes {
+		for _, p := range list {
+			if !seen[p] {
+				seen[p] = true
+				prefixes = append(prefixes, p)
+			}
+		}
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
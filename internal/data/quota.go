@@ -0,0 +1,33 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// quotaKeyPrefix 為 API client 請求計數的 key prefix，不在 Purge 的範圍內
+const quotaKeyPrefix = "quota"
+
+// IncrQuota counts one request of client in the fixed window of length
+// window containing now, and returns the count so far and when the window
+// ends. ok is false when the cache is disabled or Redis fails, in which case
+// the request should not be limited.
+func (c *Cache) IncrQuota(ctx context.Context, client string, window time.Duration, now time.Time) (count int64, reset time.Time, ok bool) {
+	if !c.Enabled() {
+		return 0, time.Time{}, false
+	}
+	start := now.Truncate(window)
+	reset = start.Add(window)
+	key := c.key(fmt.Sprintf("%s:%s:%d:%d", quotaKeyPrefix, client, int64(window/time.Second), start.Unix()))
+
+	// 計數 key 在 window 結束後再保留一分鐘，避免時鐘誤差讓計數提早消失
+	pipe := c.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireAt(ctx, key, reset.Add(time.Minute))
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.logError("[Redis] Quota count error for client %s: %v", client, err)
+		return 0, time.Time{}, false
+	}
+	return incr.Val(), reset, true
+}
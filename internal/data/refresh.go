@@ -0,0 +1,58 @@
+package data
+
+import (
+	"context"
+	"fmt"
+)
+
+type cacheRefreshKey struct{}
+
+// withCacheRefresh 讓 unique 查詢略過 cache 讀取，直接查 DB 並寫回 cache
+func withCacheRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheRefreshKey{}, true)
+}
+
+func cacheRefreshing(ctx context.Context) bool {
+	refresh, _ := ctx.Value(cacheRefreshKey{}).(bool)
+	return refresh
+}
+
+// RefreshPost re-reads the post with slug from the DB and rewrites its
+// cached lookups by slug and by id. It returns nil when no post has slug.
+func (r *Repo) RefreshPost(ctx context.Context, slug string) (*Post, error) {
+	where := &PostWhereUniqueInput{Slug: &slug}
+	p, err := r.QueryPostByUnique(withCacheRefresh(ctx), where)
+	if err != nil {
+		return nil, fmt.Errorf("refresh post %s: %w", slug, err)
+	}
+	if r.cache == nil || !r.cache.Enabled() {
+		return p, nil
+	}
+	if p == nil {
+		// 查無資料時不會寫入 cache，需要自行清掉舊的 slug 查詢
+		_ = r.cache.Delete(ctx, GenerateCacheKey(postUniqueCachePrefix, where))
+		return nil, nil
+	}
+	_ = r.cache.Set(ctx, GenerateCacheKey(postUniqueCachePrefix, &PostWhereUniqueInput{ID: &p.ID}), p)
+	return p, nil
+}
+
+// RefreshTopic re-reads the topic with slug from the DB and rewrites its
+// cached lookups by slug, id and name. It returns nil when no topic has slug.
+func (r *Repo) RefreshTopic(ctx context.Context, slug string) (*Topic, error) {
+	where := &TopicWhereUniqueInput{Slug: &slug}
+	t, err := r.QueryTopicByUnique(withCacheRefresh(ctx), where)
+	if err != nil {
+		return nil, fmt.Errorf("refresh topic %s: %w", slug, err)
+	}
+	if r.cache == nil || !r.cache.Enabled() {
+		return t, nil
+	}
+	if t == nil {
+		_ = r.cache.Delete(ctx, GenerateCacheKey("topic:unique", where))
+		return nil, nil
+	}
+	_ = r.cache.Set(ctx, GenerateCacheKey("topic:unique", &TopicWhereUniqueInput{ID: &t.ID}), t)
+	_ = r.cache.Set(ctx, GenerateCacheKey("topic:unique", &TopicWhereUniqueInput{Name: &t.Name}), t)
+	return t, nil
+}
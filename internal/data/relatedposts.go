@@ -0,0 +1,192 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// postRelation 為文章與 tag、section、category 的多對多關聯表
+type postRelation struct {
+	table string
+	// postColumn、ownerColumn 為關聯表中文章與另一端的 id 欄位
+	postColumn  string
+	ownerColumn string
+	// owners 回傳 mock 文章所屬的 id
+	owners func(p Post) []string
+}
+
+var (
+	postTagsRelation = postRelation{table: "_Post_tags", postColumn: "A", ownerColumn: "B", owners: func(p Post) []string {
+		ids := make([]string, 0, len(p.Tags))
+		for _, t := range p.Tags {
+			ids = append(ids, t.ID)
+		}
+		return ids
+	}}
+	postSectionsRelation = postRelation{table: "_Post_sections", postColumn: "A", ownerColumn: "B", owners: func(p Post) []string {
+		ids := make([]string, 0, len(p.Sections))
+		for _, s := range p.Sections {
+			ids = append(ids, s.ID)
+		}
+		return ids
+	}}
+	categoryPostsRelation = postRelation{table: "_Category_posts", postColumn: "B", ownerColumn: "A", owners: func(p Post) []string {
+		ids := make([]string, 0, len(p.Categories))
+		for _, c := range p.Categories {
+			ids = append(ids, c.ID)
+		}
+		return ids
+	}}
+)
+
+// QuerySectionPosts returns, for each of sectionIDs, the page of posts in the
+// section that match where, in a single query. Sections without posts map to
+// an empty list.
+func (r *Repo) QuerySectionPosts(ctx context.Context, sectionIDs []string, where *PostWhereInput, orders []OrderRule, take, skip int) (map[string][]Post, error) {
+	return r.queryRelatedPosts(withOp(ctx, "section_posts"), postSectionsRelation, sectionIDs, where, orders, take, skip)
+}
+
+// QuerySectionPostsCount counts the posts matching where for each of sectionIDs.
+func (r *Repo) QuerySectionPostsCount(ctx context.Context, sectionIDs []string, where *PostWhereInput) (map[string]int, error) {
+	return r.queryRelatedPostsCount(withOp(ctx, "section_posts_count"), postSectionsRelation, sectionIDs, where)
+}
+
+// QueryCategoryPosts returns, for each of categoryIDs, the page of posts in the
+// category that match where, in a single query. Categories without posts map
+// to an empty list.
+func (r *Repo) QueryCategoryPosts(ctx context.Context, categoryIDs []string, where *PostWhereInput, orders []OrderRule, take, skip int) (map[string][]Post, error) {
+	return r.queryRelatedPosts(withOp(ctx, "category_posts"), categoryPostsRelation, categoryIDs, where, orders, take, skip)
+}
+
+// QueryCategoryPostsCount counts the posts matching where for each of categoryIDs.
+func (r *Repo) QueryCategoryPostsCount(ctx context.Context, categoryIDs []string, where *PostWhereInput) (map[string]int, error) {
+	return r.queryRelatedPostsCount(withOp(ctx, "category_posts_count"), categoryPostsRelation, categoryIDs, where)
+}
+
+// queryRelatedPosts 一次查詢多個 owner 各自的一頁文章
+func (r *Repo) queryRelatedPosts(ctx context.Context, rel postRelation, ownerIDs []string, where *PostWhereInput, orders []OrderRule, take, skip int) (map[string][]Post, error) {
+	where = ensurePostPublished(where)
+	result := make(map[string][]Post, len(ownerIDs))
+	for _, id := range ownerIDs {
+		result[id] = []Post{}
+	}
+	if r.mock != nil {
+		for id, posts := range r.mock.relatedPosts(rel, ownerIDs, where, orders, take, skip) {
+			result[id] = posts
+		}
+		return result, nil
+	}
+	ids, err := parseIDs(ownerIDs)
+	if err != nil {
+		return nil, err
+	}
+	idCtx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
+	defer cancel()
+
+	// 先以 window function 取得各 owner 這一頁的 id，再交給 QueryPosts 組裝關聯並沿用其 cache
+	conds, args, _, err := buildPostConds(where)
+	if err != nil {
+		return nil, err
+	}
+	conds = append(conds, fmt.Sprintf(`rel."%s" = ANY($%d)`, rel.ownerColumn, len(args)+1))
+	args = append(args, pqIntArray(ids))
+	order := `p."publishedDate" DESC`
+	if len(orders) > 0 {
+		order = buildOrderClause(orders[0])
+	}
+	query := fmt.Sprintf(`SELECT owner, id FROM (SELECT rel."%[2]s" AS owner, p.id, ROW_NUMBER() OVER (PARTITION BY rel."%[2]s" ORDER BY %[4]s, p.id DESC) AS rn
+FROM "Post" p JOIN "%[1]s" rel ON rel."%[3]s" = p.id WHERE %[5]s) rp`, rel.table, rel.ownerColumn, rel.postColumn, order, strings.Join(conds, " AND "))
+	query += fmt.Sprintf(" WHERE rn > %d", skip)
+	if take >= 0 {
+		query += fmt.Sprintf(" AND rn <= %d", skip+take)
+	}
+	query += " ORDER BY owner, rn"
+	rows, err := r.db.QueryContext(idCtx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	type ownerPost struct{ owner, post string }
+	pairs := []ownerPost{}
+	postIDs := []string{}
+	seen := map[string]bool{}
+	for rows.Next() {
+		var ownerID, postID int
+		if err := rows.Scan(&ownerID, &postID); err != nil {
+			return nil, err
+		}
+		pair := ownerPost{strconv.Itoa(ownerID), strconv.Itoa(postID)}
+		pairs = append(pairs, pair)
+		if !seen[pair.post] {
+			seen[pair.post] = true
+			postIDs = append(postIDs, pair.post)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(postIDs) == 0 {
+		return result, nil
+	}
+
+	posts, err := r.QueryPosts(ctx, &PostWhereInput{ID: &IDFilter{In: postIDs}, State: where.State}, nil, len(postIDs), 0)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]Post, len(posts))
+	for _, p := range posts {
+		byID[p.ID] = p
+	}
+	for _, pair := range pairs {
+		if p, ok := byID[pair.post]; ok {
+			result[pair.owner] = append(result[pair.owner], p)
+		}
+	}
+	return result, nil
+}
+
+// queryRelatedPostsCount 以一次 GROUP BY 計算多個 owner 各自符合 where 的文章數
+func (r *Repo) queryRelatedPostsCount(ctx context.Context, rel postRelation, ownerIDs []string, where *PostWhereInput) (map[string]int, error) {
+	where = ensurePostPublished(where)
+	result := make(map[string]int, len(ownerIDs))
+	for _, id := range ownerIDs {
+		result[id] = 0
+	}
+	if r.mock != nil {
+		for id, count := range r.mock.relatedPostsCount(rel, ownerIDs, where) {
+			result[id] = count
+		}
+		return result, nil
+	}
+	ids, err := parseIDs(ownerIDs)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
+	defer cancel()
+
+	conds, args, _, err := buildPostConds(where)
+	if err != nil {
+		return nil, err
+	}
+	conds = append(conds, fmt.Sprintf(`rel."%s" = ANY($%d)`, rel.ownerColumn, len(args)+1))
+	args = append(args, pqIntArray(ids))
+	query := fmt.Sprintf(`SELECT rel."%[2]s", COUNT(*) FROM "Post" p JOIN "%[1]s" rel ON rel."%[3]s" = p.id WHERE %[4]s GROUP BY rel."%[2]s"`,
+		rel.table, rel.ownerColumn, rel.postColumn, strings.Join(conds, " AND "))
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ownerID, count int
+		if err := rows.Scan(&ownerID, &count); err != nil {
+			return nil, err
+		}
+		result[strconv.Itoa(ownerID)] = count
+	}
+	return result, rows.Err()
+}
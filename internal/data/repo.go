@@ -5,10 +5,14 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"go-story/internal/apidata"
+
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/mitchellh/mapstructure"
@@ -36,6 +40,7 @@ type Photo struct {
 	ImageFile     ImageFile      `json:"imageFile"`
 	Resized       Resized        `json:"resized"`
 	ResizedWebp   Resized        `json:"resizedWebp"`
+	ResizedAvif   *Resized       `json:"resizedAvif"`
 	Metadata      map[string]any `json:"-"`
 }
 
@@ -76,9 +81,18 @@ type PhotoWhereInput struct {
 }
 
 type Video struct {
-	ID        string `json:"id"`
-	VideoSrc  string `json:"videoSrc"`
-	HeroImage *Photo `json:"heroImage"`
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+	// VideoSrc 原始影片網址（urlOriginal），沒有時使用上傳檔案的網址
+	VideoSrc string `json:"videoSrc"`
+	// Mp4Src / HlsSrc 依副檔名分類的播放網址，沒有對應格式時為空
+	Mp4Src string `json:"mp4Src"`
+	HlsSrc string `json:"hlsSrc"`
+	// Duration 影片長度（秒）
+	Duration  int            `json:"duration"`
+	HeroImage *Photo         `json:"heroImage"`
+	Metadata  map[string]any `json:"-"`
 }
 
 type Partner struct {
@@ -110,8 +124,10 @@ type Topic struct {
 	Tags                         []Tag          `json:"tags"`
 	SlideshowImages              []Photo        `json:"slideshow_images"`
 	SlideshowImagesInOrder       []Photo        `json:"slideshow_imagesInInputOrder"`
-	ManualOrderOfSlideshowImages map[string]any `json:"manualOrderOfSlideshowImages"`
+	ManualOrderOfSlideshowImages any            `json:"manualOrderOfSlideshowImages"`
 	Posts                        []Post         `json:"posts"`
+	ParentTopic                  *Topic         `json:"parentTopic"`
+	Subtopics                    []Topic        `json:"subtopics"`
 	Javascript                   string         `json:"javascript"`
 	Dfp                          string         `json:"dfp"`
 	MobileDfp                    string         `json:"mobile_dfp"`
@@ -146,6 +162,8 @@ type Post struct {
 	Tags                   []Tag          `json:"tags"`
 	TagsAlgo               []Tag          `json:"tags_algo"`
 	HeroVideo              *Video         `json:"heroVideo"`
+	HeroAudio              *Audio         `json:"heroAudio"`
+	Audio                  *Audio         `json:"audio"`
 	HeroImage              *Photo         `json:"heroImage"`
 	HeroCaption            string         `json:"heroCaption"`
 	Brief                  map[string]any `json:"brief"`
@@ -163,6 +181,8 @@ type Post struct {
 	IsAdvertised           bool           `json:"isAdvertised"`
 	IsFeatured             bool           `json:"isFeatured"`
 	Topics                 *Topic         `json:"topics"`
+	ReadingTime            int            `json:"readingTime"`
+	WordCount              int            `json:"wordCount"`
 	Metadata               map[string]any `json:"-"`
 }
 
@@ -188,6 +208,7 @@ type External struct {
 type StringFilter struct {
 	Equals *string       `mapstructure:"equals"`
 	In     []string      `mapstructure:"in"`
+	NotIn  []string      `mapstructure:"notIn"`
 	Not    *StringFilter `mapstructure:"not"`
 }
 
@@ -196,6 +217,7 @@ type BooleanFilter struct {
 }
 
 type SectionWhereInput struct {
+	Name  *StringFilter `mapstructure:"name"`
 	Slug  *StringFilter `mapstructure:"slug"`
 	State *StringFilter `mapstructure:"state"`
 }
@@ -205,6 +227,7 @@ type SectionManyRelationFilter struct {
 }
 
 type CategoryWhereInput struct {
+	Name         *StringFilter  `mapstructure:"name"`
 	Slug         *StringFilter  `mapstructure:"slug"`
 	State        *StringFilter  `mapstructure:"state"`
 	IsMemberOnly *BooleanFilter `mapstructure:"isMemberOnly"`
@@ -214,6 +237,10 @@ type CategoryManyRelationFilter struct {
 	Some *CategoryWhereInput `mapstructure:"some"`
 }
 
+type TagManyRelationFilter struct {
+	Some *TagWhereInput `mapstructure:"some"`
+}
+
 type PartnerWhereInput struct {
 	Slug *StringFilter `mapstructure:"slug"`
 }
@@ -221,25 +248,36 @@ type PartnerWhereInput struct {
 type DateTimeNullableFilter struct {
 	Equals *string                 `mapstructure:"equals"`
 	Not    *DateTimeNullableFilter `mapstructure:"not"`
+	Gt     *string                 `mapstructure:"gt"`
+	Gte    *string                 `mapstructure:"gte"`
+	Lt     *string                 `mapstructure:"lt"`
+	Lte    *string                 `mapstructure:"lte"`
 }
 
 type IDFilter struct {
-	Equals *string `mapstructure:"equals"`
+	Equals *string  `mapstructure:"equals"`
+	In     []string `mapstructure:"in"`
+	NotIn  []string `mapstructure:"notIn"`
 }
 
 type PostTopicsWhereInput struct {
-	ID *IDFilter `mapstructure:"id"`
+	ID   *IDFilter     `mapstructure:"id"`
+	Slug *StringFilter `mapstructure:"slug"`
 }
 
 type PostWhereInput struct {
+	ID         *IDFilter                   `mapstructure:"id"`
 	Slug       *StringFilter               `mapstructure:"slug"`
 	Sections   *SectionManyRelationFilter  `mapstructure:"sections"`
 	Categories *CategoryManyRelationFilter `mapstructure:"categories"`
 	State      *StringFilter               `mapstructure:"state"`
+	Style      *StringFilter               `mapstructure:"style"`
 	IsAdult    *BooleanFilter              `mapstructure:"isAdult"`
 	IsMember   *BooleanFilter              `mapstructure:"isMember"`
 	IsFeatured *BooleanFilter              `mapstructure:"isFeatured"`
 	Topics     *PostTopicsWhereInput       `mapstructure:"topics"`
+	TagsAlgo   *TagManyRelationFilter      `mapstructure:"tags_algo"`
+	HasVideo   *BooleanFilter              `mapstructure:"hasVideo"`
 }
 
 type PostWhereUniqueInput struct {
@@ -252,9 +290,11 @@ type ExternalWhereInput struct {
 	State         *StringFilter           `mapstructure:"state"`
 	Partner       *PartnerWhereInput      `mapstructure:"partner"`
 	PublishedDate *DateTimeNullableFilter `mapstructure:"publishedDate"`
+	Tags          *TagManyRelationFilter  `mapstructure:"tags"`
 }
 
 type TopicWhereInput struct {
+	ID         *IDFilter      `mapstructure:"id"`
 	Slug       *StringFilter  `mapstructure:"slug"`
 	Name       *StringFilter  `mapstructure:"name"`
 	State      *StringFilter  `mapstructure:"state"`
@@ -276,23 +316,87 @@ type OrderRule struct {
 
 // Repo wraps DB access.
 type Repo struct {
-	db          *sql.DB
+	db          *tracedDB
 	staticsHost string
 	cache       *Cache
-}
+	opts        RepoOptions
+	// mock 設定時（MOCK_MODE）所有查詢改由記憶體中的示範資料回應，不使用 db 與 cache
+	mock *mockStore
+}
+
+// RepoOptions tunes repo behaviour; zero values keep the defaults.
+type RepoOptions struct {
+	// QueryTimeout 覆寫每個查詢的 timeout，0 表示使用預設值 (列表 10s、計數 5s、關聯組裝 15s)
+	QueryTimeout time.Duration
+	// Location 輸出時間的時區，nil 表示 UTC
+	Location *time.Location
+	// TimeLayout 輸出時間的格式，空字串表示 timeLayoutMilli
+	TimeLayout string
+	// SQLComments 開啟後在每個 SQL 後面加上 /*op=...,req=...*/ 追蹤註解
+	SQLComments bool
+	// AvifImages 開啟後 Photo.ResizedAvif 輸出 avif 的 URL（新的圖片處理流程會同時產生 AVIF）
+	AvifImages bool
+	// ImageFormatColumns 衍生格式（webp、avif）對應到 Image 中標示該格式是否存在的 boolean 欄位；
+	// 未列出的格式視為一律存在
+	ImageFormatColumns map[string]string
+}
+
+// 包含相關文章的查詢快取 key。相關文章改為帶 State、State 為空視為未發布後，舊版快取中的相關文章
+// 沒有 State，讀到會讓相關文章全部消失，因此 key 加上版本，舊資料不再讀取、由 REDIS_TTL 淘汰。
+// 版本放在 prefix 之後，purge 與 /debug/cache 仍以 posts 等 prefix 處理
+const (
+	postsCachePrefix      = "posts:v2"
+	postUniqueCachePrefix = "post:unique:v2"
+	externalsCachePrefix  = "externals:v2"
+)
 
 const timeLayoutMilli = "2006-01-02T15:04:05.000Z07:00"
 
-func NewDB(dsn string) (*sql.DB, error) {
+// DBOptions tunes the connection pool created by NewDB.
+// Zero values keep the defaults (10 open / 5 idle connections, no lifetime limit, 5s ping timeout).
+type DBOptions struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	PingTimeout     time.Duration
+	// DSNFunc 回傳最新的連線字串 (例如 secret 輪替後)，新建立的連線會套用其帳密；nil 表示固定使用 dsn
+	DSNFunc func() string
+}
+
+func NewDB(dsn string, opts DBOptions) (*sql.DB, error) {
 	cfg, err := pgx.ParseConfig(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("parse dsn: %w", err)
 	}
-	conn := stdlib.OpenDB(*cfg)
-	conn.SetMaxOpenConns(10)
-	conn.SetMaxIdleConns(5)
+	openOpts := []stdlib.OptionOpenDB{}
+	if opts.DSNFunc != nil {
+		openOpts = append(openOpts, stdlib.OptionBeforeConnect(func(ctx context.Context, cc *pgx.ConnConfig) error {
+			latest, err := pgx.ParseConfig(opts.DSNFunc())
+			if err != nil {
+				return fmt.Errorf("parse refreshed dsn: %w", err)
+			}
+			cc.User = latest.User
+			cc.Password = latest.Password
+			return nil
+		}))
+	}
+	if opts.MaxOpenConns <= 0 {
+		opts.MaxOpenConns = 10
+	}
+	if opts.MaxIdleConns <= 0 {
+		opts.MaxIdleConns = 5
+	}
+	if opts.PingTimeout <= 0 {
+		opts.PingTimeout = 5 * time.Second
+	}
+	conn := stdlib.OpenDB(*cfg, openOpts...)
+	conn.SetMaxOpenConns(opts.MaxOpenConns)
+	conn.SetMaxIdleConns(opts.MaxIdleConns)
 	conn.SetConnMaxIdleTime(5 * time.Minute)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if opts.ConnMaxLifetime > 0 {
+		conn.SetConnMaxLifetime(opts.ConnMaxLifetime)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opts.PingTimeout)
 	defer cancel()
 	if err := conn.PingContext(ctx); err != nil {
 		return nil, fmt.Errorf("ping db: %w", err)
@@ -300,8 +404,26 @@ func NewDB(dsn string) (*sql.DB, error) {
 	return conn, nil
 }
 
-func NewRepo(db *sql.DB, staticsHost string, cache *Cache) *Repo {
-	return &Repo{db: db, staticsHost: staticsHost, cache: cache}
+func NewRepo(db *sql.DB, staticsHost string, cache *Cache, opts RepoOptions) *Repo {
+	return &Repo{db: &tracedDB{DB: db, enabled: opts.SQLComments}, staticsHost: staticsHost, cache: cache, opts: opts}
+}
+
+// timeout 回傳查詢 timeout，有設定 QueryTimeout 時優先使用
+func (r *Repo) timeout(def time.Duration) time.Duration {
+	if r.opts.QueryTimeout > 0 {
+		return r.opts.QueryTimeout
+	}
+	return def
+}
+
+// formatTime 依設定的時區與格式輸出時間
+func (r *Repo) formatTime(t time.Time) string {
+	loc := r.location()
+	layout := r.opts.TimeLayout
+	if layout == "" {
+		layout = timeLayoutMilli
+	}
+	return t.In(loc).Format(layout)
 }
 
 // Decode helpers
@@ -383,15 +505,20 @@ func DecodePhotoWhere(input interface{}) (*PhotoWhereInput, error) {
 }
 
 // Public queries
+// take < 0 表示不限制筆數
 func (r *Repo) QueryPosts(ctx context.Context, where *PostWhereInput, orders []OrderRule, take, skip int) ([]Post, error) {
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	ctx = withOp(ctx, "posts_list")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
 	defer cancel()
 
 	where = ensurePostPublished(where)
+	if r.mock != nil {
+		return r.mock.queryPosts(where, orders, take, skip), nil
+	}
 
 	// 嘗試從 cache 讀取
 	if r.cache != nil && r.cache.Enabled() {
-		cacheKey := GenerateCacheKey("posts", map[string]interface{}{
+		cacheKey := GenerateCacheKey(postsCachePrefix, map[string]interface{}{
 			"where":  where,
 			"orders": orders,
 			"take":   take,
@@ -404,76 +531,11 @@ func (r *Repo) QueryPosts(ctx context.Context, where *PostWhereInput, orders []O
 	}
 
 	sb := strings.Builder{}
-	sb.WriteString(`SELECT id, slug, title, subtitle, state, style, "isMember", "isAdult", "publishedDate", "updatedAt", COALESCE("heroCaption",'') as heroCaption, COALESCE("extend_byline",'') as extend_byline, "heroImage", "heroVideo", brief, content, COALESCE(redirect,'') as redirect, COALESCE(og_title,'') as og_title, COALESCE(og_description,'') as og_description, "hiddenAdvertised", "isAdvertised", "isFeatured", topics, "og_image", "relatedsOne", "relatedsTwo" FROM "Post" p`)
-
-	conds := []string{}
-	args := []interface{}{}
-	argIdx := 1
-
-	buildStringFilter := func(field string, f *StringFilter) {
-		if f == nil {
-			return
-		}
-		if f.Equals != nil {
-			conds = append(conds, fmt.Sprintf(`%s = $%d`, field, argIdx))
-			args = append(args, *f.Equals)
-			argIdx++
-		}
-		if len(f.In) > 0 {
-			conds = append(conds, fmt.Sprintf(`%s = ANY($%d)`, field, argIdx))
-			args = append(args, f.In)
-			argIdx++
-		}
-	}
+	sb.WriteString(`SELECT ` + postListColumns + ` FROM "Post" p`)
 
-	if where != nil {
-		buildStringFilter("slug", where.Slug)
-		buildStringFilter("state", where.State)
-		if where.IsAdult != nil && where.IsAdult.Equals != nil {
-			conds = append(conds, fmt.Sprintf(`"isAdult" = $%d`, argIdx))
-			args = append(args, *where.IsAdult.Equals)
-			argIdx++
-		}
-		if where.IsMember != nil && where.IsMember.Equals != nil {
-			conds = append(conds, fmt.Sprintf(`"isMember" = $%d`, argIdx))
-			args = append(args, *where.IsMember.Equals)
-			argIdx++
-		}
-		if where.Sections != nil && where.Sections.Some != nil {
-			sub := "EXISTS (SELECT 1 FROM \"_Post_sections\" ps JOIN \"Section\" s ON s.id = ps.\"B\" WHERE ps.\"A\" = p.id"
-			if where.Sections.Some.Slug != nil && where.Sections.Some.Slug.Equals != nil {
-				sub += fmt.Sprintf(" AND s.slug = $%d", argIdx)
-				args = append(args, *where.Sections.Some.Slug.Equals)
-				argIdx++
-			}
-			if where.Sections.Some.State != nil && where.Sections.Some.State.Equals != nil {
-				sub += fmt.Sprintf(" AND s.state = $%d", argIdx)
-				args = append(args, *where.Sections.Some.State.Equals)
-				argIdx++
-			}
-			sub += ")"
-			conds = append(conds, sub)
-		}
-		if where.Categories != nil && where.Categories.Some != nil {
-			sub := "EXISTS (SELECT 1 FROM \"_Category_posts\" cp JOIN \"Category\" c ON c.id = cp.\"A\" WHERE cp.\"B\" = p.id"
-			if where.Categories.Some.Slug != nil && where.Categories.Some.Slug.Equals != nil {
-				sub += fmt.Sprintf(" AND c.slug = $%d", argIdx)
-				args = append(args, *where.Categories.Some.Slug.Equals)
-				argIdx++
-			}
-			if where.Categories.Some.State != nil && where.Categories.Some.State.Equals != nil {
-				sub += fmt.Sprintf(" AND c.state = $%d", argIdx)
-				args = append(args, *where.Categories.Some.State.Equals)
-				argIdx++
-			}
-			if where.Categories.Some.IsMemberOnly != nil && where.Categories.Some.IsMemberOnly.Equals != nil {
-				sub += fmt.Sprintf(" AND c.\"isMemberOnly\" = $%d", argIdx)
-				args = append(args, *where.Categories.Some.IsMemberOnly.Equals)
-				argIdx++
-			}
-			sub += ")"
-			conds = append(conds, sub)
-		}
+	conds, args, idOrderArg, err := buildPostConds(where)
+	if err != nil {
+		return nil, err
 	}
 
 	if len(conds) > 0 {
@@ -484,11 +546,13 @@ func (r *Repo) QueryPosts(ctx context.Context, where *PostWhereInput, orders []O
 	if len(orders) > 0 {
 		sb.WriteString(" ORDER BY ")
 		sb.WriteString(buildOrderClause(orders[0]))
+	} else if idOrderArg > 0 {
+		sb.WriteString(fmt.Sprintf(` ORDER BY array_position($%d::bigint[], p.id::bigint)`, idOrderArg))
 	} else {
 		sb.WriteString(` ORDER BY "publishedDate" DESC`)
 	}
 
-	if take > 0 {
+	if take >= 0 {
 		sb.WriteString(fmt.Sprintf(" LIMIT %d", take))
 	}
 	if skip > 0 {
@@ -501,6 +565,40 @@ func (r *Repo) QueryPosts(ctx context.Context, where *PostWhereInput, orders []O
 	}
 	defer rows.Close()
 
+	posts, err := r.scanPostRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(posts) == 0 {
+		return posts, nil
+	}
+	if err := r.enrichPosts(ctx, posts); err != nil {
+		return nil, err
+	}
+
+	// 寫入 cache
+	if r.cache != nil && r.cache.Enabled() {
+		cacheKey := GenerateCacheKey(postsCachePrefix, map[string]interface{}{
+			"where":  where,
+			"orders": orders,
+			"take":   take,
+			"skip":   skip,
+		})
+		_ = r.cache.Set(ctx, cacheKey, posts)
+	}
+
+	return posts, nil
+}
+
+// postStyleExpr 為 style filter 比對的欄位；DB 值為空時 GraphQL 輸出 article，filter 需一致
+const postStyleExpr = `COALESCE(NULLIF(p.style, ''), 'article')`
+
+// postListColumns 為列表查詢的欄位，順序需與 scanPostRows 一致
+const postListColumns = `id, slug, title, subtitle, state, style, "isMember", "isAdult", "publishedDate", "updatedAt", COALESCE("heroCaption",'') as heroCaption, COALESCE("extend_byline",'') as extend_byline, "heroImage", "heroVideo", brief, content, COALESCE(redirect,'') as redirect, COALESCE(og_title,'') as og_title, COALESCE(og_description,'') as og_description, "hiddenAdvertised", "isAdvertised", "isFeatured", topics, "og_image", "relatedsOne", "relatedsTwo"`
+
+// scanPostRows 讀取以 postListColumns 查詢的結果，尚未組裝關聯
+func (r *Repo) scanPostRows(rows *sql.Rows) ([]Post, error) {
 	posts := []Post{}
 	for rows.Next() {
 		var (
@@ -549,10 +647,10 @@ func (r *Repo) QueryPosts(ctx context.Context, where *PostWhereInput, orders []O
 		}
 		p.ID = strconv.Itoa(dbID)
 		if publishedAt.Valid {
-			p.PublishedDate = publishedAt.Time.UTC().Format(timeLayoutMilli)
+			p.PublishedDate = r.formatTime(publishedAt.Time)
 		}
 		if updatedAt.Valid {
-			p.UpdatedAt = updatedAt.Time.UTC().Format(timeLayoutMilli)
+			p.UpdatedAt = r.formatTime(updatedAt.Time)
 		}
 		p.Brief = decodeJSONBytes(briefRaw)
 		p.Content = decodeJSONBytes(contentRaw)
@@ -564,127 +662,290 @@ func (r *Repo) QueryPosts(ctx context.Context, where *PostWhereInput, orders []O
 			"topicsID":      nullableInt(topicsID),
 			"relatedsOneID": nullableInt(relatedsOneID),
 			"relatedsTwoID": nullableInt(relatedsTwoID),
+			// 原始的 updatedAt，格式化後的字串可能損失精度，export 的 cursor 需要完整的值
+			"updatedAt": updatedAt.Time,
 		}
 		posts = append(posts, p)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	return posts, nil
+}
 
-	if len(posts) == 0 {
-		return posts, nil
+// QueryPostsBySlugs returns the published posts matching slugs in the order
+// the slugs were given. Unknown slugs are skipped and duplicates collapse.
+func (r *Repo) QueryPostsBySlugs(ctx context.Context, slugs []string) ([]Post, error) {
+	ctx = withOp(ctx, "posts_by_slugs")
+	unique := make([]string, 0, len(slugs))
+	seen := map[string]bool{}
+	for _, slug := range slugs {
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		unique = append(unique, slug)
 	}
-	if err := r.enrichPosts(ctx, posts); err != nil {
-		return nil, err
+	if len(unique) == 0 {
+		return []Post{}, nil
 	}
 
-	// 寫入 cache
-	if r.cache != nil && r.cache.Enabled() {
-		cacheKey := GenerateCacheKey("posts", map[string]interface{}{
-			"where":  where,
-			"orders": orders,
-			"take":   take,
-			"skip":   skip,
-		})
-		_ = r.cache.Set(ctx, cacheKey, posts)
+	posts, err := r.QueryPosts(ctx, &PostWhereInput{Slug: &StringFilter{In: unique}}, nil, len(unique), 0)
+	if err != nil {
+		return nil, err
 	}
-
-	return posts, nil
+	bySlug := make(map[string]Post, len(posts))
+	for _, p := range posts {
+		bySlug[p.Slug] = p
+	}
+	// 依輸入順序排列
+	result := make([]Post, 0, len(posts))
+	for _, slug := range unique {
+		if p, ok := bySlug[slug]; ok {
+			result = append(result, p)
+		}
+	}
+	return result, nil
 }
 
 func (r *Repo) QueryPostsCount(ctx context.Context, where *PostWhereInput) (int, error) {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	ctx = withOp(ctx, "posts_count")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
 	defer cancel()
 
 	where = ensurePostPublished(where)
+	if r.mock != nil {
+		return len(r.mock.filterPosts(where)), nil
+	}
 
 	sb := strings.Builder{}
 	sb.WriteString(`SELECT COUNT(*) FROM "Post" p`)
 
-	conds := []string{}
-	args := []interface{}{}
-	argIdx := 1
-	buildStringFilter := func(field string, f *StringFilter) {
-		if f == nil {
-			return
-		}
-		if f.Equals != nil {
-			conds = append(conds, fmt.Sprintf(`%s = $%d`, field, argIdx))
-			args = append(args, *f.Equals)
-			argIdx++
+	conds, args, _, err := buildPostConds(where)
+	if err != nil {
+		return 0, err
+	}
+	if len(conds) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(conds, " AND "))
+	}
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, sb.String(), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// SectionPostCount is the number of posts in one section.
+type SectionPostCount struct {
+	Section Section `json:"section"`
+	Count   int     `json:"count"`
+}
+
+// QueryPostsCountBySection counts posts matching where, grouped by section, in
+// a single query. Sections without matching posts are omitted.
+func (r *Repo) QueryPostsCountBySection(ctx context.Context, where *PostWhereInput) ([]SectionPostCount, error) {
+	ctx = withOp(ctx, "posts_count_by_section")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
+	defer cancel()
+
+	where = ensurePostPublished(where)
+	if r.mock != nil {
+		return r.mock.postsCountBySection(where), nil
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(`SELECT sec.id, sec.name, sec.slug, sec.state, COUNT(DISTINCT p.id) FROM "Post" p JOIN "_Post_sections" psec ON psec."A" = p.id JOIN "Section" sec ON sec.id = psec."B"`)
+
+	conds, args, _, err := buildPostConds(where)
+	if err != nil {
+		return nil, err
+	}
+	if len(conds) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(conds, " AND "))
+	}
+	sb.WriteString(" GROUP BY sec.id, sec.name, sec.slug, sec.state ORDER BY sec.id")
+
+	rows, err := r.db.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []SectionPostCount{}
+	for rows.Next() {
+		var c SectionPostCount
+		if err := rows.Scan(&c.Section.ID, &c.Section.Name, &c.Section.Slug, &c.Section.State, &c.Count); err != nil {
+			return nil, err
 		}
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
+
+// buildPostConds 組出 posts 列表與計數查詢共用的 WHERE 條件，欄位皆以 p. 限定，
+// 可與其他資料表 JOIN 使用。idOrderArg 為 id in 參數的位置（沒有時為 0），
+// 供列表在未指定 orderBy 時依輸入順序排序
+func buildPostConds(where *PostWhereInput) (conds []string, args []interface{}, idOrderArg int, err error) {
+	conds = []string{}
+	args = []interface{}{}
+	argIdx := 1
+	// addStringFilter 將 f 的條件加入 target（WHERE 或巢狀 relation 的子查詢），參數接續編號
+	addStringFilter := func(target *[]string, field string, f *StringFilter) {
+		c, a := stringFilterConds(field, f, argIdx)
+		*target = append(*target, c...)
+		args = append(args, a...)
+		argIdx += len(a)
 	}
 	if where != nil {
-		buildStringFilter("slug", where.Slug)
-		buildStringFilter("state", where.State)
+		if where.ID != nil {
+			if where.ID.Equals != nil {
+				id, err := parseIDs([]string{*where.ID.Equals})
+				if err != nil {
+					return nil, nil, 0, err
+				}
+				conds = append(conds, fmt.Sprintf(`p.id = $%d`, argIdx))
+				args = append(args, id[0])
+				argIdx++
+			}
+			if len(where.ID.In) > 0 {
+				ids, err := parseIDs(where.ID.In)
+				if err != nil {
+					return nil, nil, 0, err
+				}
+				conds = append(conds, fmt.Sprintf(`p.id = ANY($%d)`, argIdx))
+				args = append(args, pqIntArray(ids))
+				idOrderArg = argIdx
+				argIdx++
+			}
+			if len(where.ID.NotIn) > 0 {
+				ids, err := parseIDs(where.ID.NotIn)
+				if err != nil {
+					return nil, nil, 0, err
+				}
+				conds = append(conds, fmt.Sprintf(`p.id <> ALL($%d)`, argIdx))
+				args = append(args, pqIntArray(ids))
+				argIdx++
+			}
+		}
+		addStringFilter(&conds, "p.slug", where.Slug)
+		addStringFilter(&conds, "p.state", where.State)
+		addStringFilter(&conds, postStyleExpr, where.Style)
 		if where.IsAdult != nil && where.IsAdult.Equals != nil {
-			conds = append(conds, fmt.Sprintf(`"isAdult" = $%d`, argIdx))
+			conds = append(conds, fmt.Sprintf(`p."isAdult" = $%d`, argIdx))
 			args = append(args, *where.IsAdult.Equals)
 			argIdx++
 		}
 		if where.IsMember != nil && where.IsMember.Equals != nil {
-			conds = append(conds, fmt.Sprintf(`"isMember" = $%d`, argIdx))
+			conds = append(conds, fmt.Sprintf(`p."isMember" = $%d`, argIdx))
 			args = append(args, *where.IsMember.Equals)
 			argIdx++
 		}
-		if where.Sections != nil && where.Sections.Some != nil {
-			sub := "EXISTS (SELECT 1 FROM \"_Post_sections\" ps JOIN \"Section\" s ON s.id = ps.\"B\" WHERE ps.\"A\" = p.id"
-			if where.Sections.Some.Slug != nil && where.Sections.Some.Slug.Equals != nil {
-				sub += fmt.Sprintf(" AND s.slug = $%d", argIdx)
-				args = append(args, *where.Sections.Some.Slug.Equals)
-				argIdx++
+		if where.HasVideo != nil && where.HasVideo.Equals != nil {
+			if *where.HasVideo.Equals {
+				conds = append(conds, `p."heroVideo" IS NOT NULL`)
+			} else {
+				conds = append(conds, `p."heroVideo" IS NULL`)
 			}
-			if where.Sections.Some.State != nil && where.Sections.Some.State.Equals != nil {
-				sub += fmt.Sprintf(" AND s.state = $%d", argIdx)
-				args = append(args, *where.Sections.Some.State.Equals)
-				argIdx++
+		}
+		if where.Sections != nil && where.Sections.Some != nil {
+			w := where.Sections.Some
+			sub := []string{`ps."A" = p.id`}
+			addStringFilter(&sub, "s.name", w.Name)
+			addStringFilter(&sub, "s.slug", w.Slug)
+			addStringFilter(&sub, "s.state", w.State)
+			conds = append(conds, `EXISTS (SELECT 1 FROM "_Post_sections" ps JOIN "Section" s ON s.id = ps."B" WHERE `+strings.Join(sub, " AND ")+`)`)
+		}
+		if where.TagsAlgo != nil && where.TagsAlgo.Some != nil {
+			w := where.TagsAlgo.Some
+			sub := []string{`pt."A" = p.id`}
+			addStringFilter(&sub, "tg.slug", w.Slug)
+			addStringFilter(&sub, "tg.name", w.Name)
+			conds = append(conds, `EXISTS (SELECT 1 FROM "_Post_tags_algo" pt JOIN "Tag" tg ON tg.id = pt."B" WHERE `+strings.Join(sub, " AND ")+`)`)
+		}
+		if where.Topics != nil {
+			topicConds, topicArgs, err := postTopicsConds(where.Topics, argIdx)
+			if err != nil {
+				return nil, nil, 0, err
 			}
-			sub += ")"
-			conds = append(conds, sub)
+			conds = append(conds, topicConds...)
+			args = append(args, topicArgs...)
+			argIdx += len(topicArgs)
 		}
 		if where.Categories != nil && where.Categories.Some != nil {
-			sub := "EXISTS (SELECT 1 FROM \"_Category_posts\" cp JOIN \"Category\" c ON c.id = cp.\"A\" WHERE cp.\"B\" = p.id"
-			if where.Categories.Some.Slug != nil && where.Categories.Some.Slug.Equals != nil {
-				sub += fmt.Sprintf(" AND c.slug = $%d", argIdx)
-				args = append(args, *where.Categories.Some.Slug.Equals)
+			w := where.Categories.Some
+			sub := []string{`cp."B" = p.id`}
+			addStringFilter(&sub, "c.name", w.Name)
+			addStringFilter(&sub, "c.slug", w.Slug)
+			addStringFilter(&sub, "c.state", w.State)
+			if w.IsMemberOnly != nil && w.IsMemberOnly.Equals != nil {
+				sub = append(sub, fmt.Sprintf(`c."isMemberOnly" = $%d`, argIdx))
+				args = append(args, *w.IsMemberOnly.Equals)
 				argIdx++
 			}
-			if where.Categories.Some.State != nil && where.Categories.Some.State.Equals != nil {
-				sub += fmt.Sprintf(" AND c.state = $%d", argIdx)
-				args = append(args, *where.Categories.Some.State.Equals)
-				argIdx++
+			conds = append(conds, `EXISTS (SELECT 1 FROM "_Category_posts" cp JOIN "Category" c ON c.id = cp."A" WHERE `+strings.Join(sub, " AND ")+`)`)
+		}
+	}
+	return conds, args, idOrderArg, nil
+}
+
+// postTopicsConds 組出 post.topics 的條件，參數自 $argIdx 起編號；slug 需對照 "Topic"
+func postTopicsConds(f *PostTopicsWhereInput, argIdx int) ([]string, []interface{}, error) {
+	conds := []string{}
+	args := []interface{}{}
+	if f.ID != nil {
+		if f.ID.Equals != nil {
+			ids, err := parseIDs([]string{*f.ID.Equals})
+			if err != nil {
+				return nil, nil, err
 			}
-			if where.Categories.Some.IsMemberOnly != nil && where.Categories.Some.IsMemberOnly.Equals != nil {
-				sub += fmt.Sprintf(" AND c.\"isMemberOnly\" = $%d", argIdx)
-				args = append(args, *where.Categories.Some.IsMemberOnly.Equals)
-				argIdx++
+			conds = append(conds, fmt.Sprintf(`p.topics = $%d`, argIdx))
+			args = append(args, ids[0])
+			argIdx++
+		}
+		if len(f.ID.In) > 0 {
+			ids, err := parseIDs(f.ID.In)
+			if err != nil {
+				return nil, nil, err
 			}
-			sub += ")"
-			conds = append(conds, sub)
+			conds = append(conds, fmt.Sprintf(`p.topics = ANY($%d)`, argIdx))
+			args = append(args, pqIntArray(ids))
+			argIdx++
+		}
+		if len(f.ID.NotIn) > 0 {
+			ids, err := parseIDs(f.ID.NotIn)
+			if err != nil {
+				return nil, nil, err
+			}
+			conds = append(conds, fmt.Sprintf(`p.topics <> ALL($%d)`, argIdx))
+			args = append(args, pqIntArray(ids))
+			argIdx++
 		}
 	}
-	if len(conds) > 0 {
-		sb.WriteString(" WHERE ")
-		sb.WriteString(strings.Join(conds, " AND "))
-	}
-
-	var count int
-	if err := r.db.QueryRowContext(ctx, sb.String(), args...).Scan(&count); err != nil {
-		return 0, err
+	// 與 id 條件相同，沒有 topic 的文章不符合任何 slug 條件（包括 notIn）
+	if slugConds, slugArgs := stringFilterConds("tp.slug", f.Slug, argIdx); len(slugConds) > 0 {
+		conds = append(conds, `EXISTS (SELECT 1 FROM "Topic" tp WHERE tp.id = p.topics AND `+strings.Join(slugConds, " AND ")+`)`)
+		args = append(args, slugArgs...)
 	}
-	return count, nil
+	return conds, args, nil
 }
 
 func (r *Repo) QueryPostByUnique(ctx context.Context, where *PostWhereUniqueInput) (*Post, error) {
+	ctx = withOp(ctx, "post_unique")
 	if where == nil {
 		return nil, nil
 	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	if r.mock != nil {
+		return r.mock.postByUnique(where), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
 	defer cancel()
 
 	// 嘗試從 cache 讀取
-	if r.cache != nil && r.cache.Enabled() {
-		cacheKey := GenerateCacheKey("post:unique", where)
+	if r.cache != nil && r.cache.Enabled() && !cacheRefreshing(ctx) {
+		cacheKey := GenerateCacheKey(postUniqueCachePrefix, where)
 		var cachedPost *Post
 		if found, _ := r.cache.Get(ctx, cacheKey, &cachedPost); found {
 			return cachedPost, nil
@@ -759,10 +1020,10 @@ func (r *Repo) QueryPostByUnique(ctx context.Context, where *PostWhereUniqueInpu
 	}
 	p.ID = strconv.Itoa(dbID)
 	if publishedAt.Valid {
-		p.PublishedDate = publishedAt.Time.UTC().Format(timeLayoutMilli)
+		p.PublishedDate = r.formatTime(publishedAt.Time)
 	}
 	if updatedAt.Valid {
-		p.UpdatedAt = updatedAt.Time.UTC().Format(timeLayoutMilli)
+		p.UpdatedAt = r.formatTime(updatedAt.Time)
 	}
 	p.Brief = decodeJSONBytes(briefRaw)
 	p.Content = decodeJSONBytes(contentRaw)
@@ -783,7 +1044,7 @@ func (r *Repo) QueryPostByUnique(ctx context.Context, where *PostWhereUniqueInpu
 
 	// 寫入 cache
 	if r.cache != nil && r.cache.Enabled() {
-		cacheKey := GenerateCacheKey("post:unique", where)
+		cacheKey := GenerateCacheKey(postUniqueCachePrefix, where)
 		_ = r.cache.Set(ctx, cacheKey, &p)
 	}
 
@@ -791,14 +1052,18 @@ func (r *Repo) QueryPostByUnique(ctx context.Context, where *PostWhereUniqueInpu
 }
 
 func (r *Repo) QueryExternals(ctx context.Context, where *ExternalWhereInput, orders []OrderRule, take, skip int) ([]External, error) {
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	ctx = withOp(ctx, "externals_list")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
 	defer cancel()
 
 	where = ensureExternalPublished(where)
+	if r.mock != nil {
+		return r.mock.queryExternals(where, orders, take, skip), nil
+	}
 
 	// 嘗試從 cache 讀取
 	if r.cache != nil && r.cache.Enabled() {
-		cacheKey := GenerateCacheKey("externals", map[string]interface{}{
+		cacheKey := GenerateCacheKey(externalsCachePrefix, map[string]interface{}{
 			"where":  where,
 			"orders": orders,
 			"take":   take,
@@ -813,61 +1078,23 @@ func (r *Repo) QueryExternals(ctx context.Context, where *ExternalWhereInput, or
 	sb := strings.Builder{}
 	sb.WriteString(`SELECT e.id, e.slug, e.title, e.state, e."publishedDate", e."extend_byline", e.thumb, e."thumbCaption", e.brief, e.content, e.partner, e."updatedAt" FROM "External" e`)
 
-	conds := []string{}
-	args := []interface{}{}
-	argIdx := 1
+	joins, conds, args := buildExternalConds(where)
+	sb.WriteString(joins)
 	orderUsesPublished := len(orders) == 0 || (len(orders) > 0 && orders[0].Field == "publishedDate")
 	if orderUsesPublished {
 		conds = append(conds, `e."publishedDate" IS NOT NULL`)
 	}
-
-	buildStringFilter := func(field string, f *StringFilter) {
-		if f == nil {
-			return
-		}
-		if f.Equals != nil {
-			conds = append(conds, fmt.Sprintf(`%s = $%d`, field, argIdx))
-			args = append(args, *f.Equals)
-			argIdx++
-		}
-	}
-	if where != nil {
-		buildStringFilter("e.slug", where.Slug)
-		buildStringFilter("e.state", where.State)
-		if where.PublishedDate != nil {
-			if where.PublishedDate.Equals != nil {
-				conds = append(conds, fmt.Sprintf(`e."publishedDate" = $%d`, argIdx))
-				args = append(args, *where.PublishedDate.Equals)
-				argIdx++
-			}
-			if where.PublishedDate.Not != nil {
-				if where.PublishedDate.Not.Equals == nil {
-					conds = append(conds, `e."publishedDate" IS NOT NULL`)
-				} else {
-					conds = append(conds, fmt.Sprintf(`e."publishedDate" <> $%d`, argIdx))
-					args = append(args, *where.PublishedDate.Not.Equals)
-					argIdx++
-				}
-			}
-		}
-		if where.Partner != nil && where.Partner.Slug != nil && where.Partner.Slug.Equals != nil {
-			sb.WriteString(` JOIN "Partner" p ON p.id = e.partner`)
-			conds = append(conds, fmt.Sprintf(`p.slug = $%d`, argIdx))
-			args = append(args, *where.Partner.Slug.Equals)
-			argIdx++
-		}
+	orderClause, needsPartner := buildExternalOrder(orders)
+	if needsPartner {
+		sb.WriteString(` LEFT JOIN "Partner" po ON po.id = e.partner`)
 	}
 	if len(conds) > 0 {
 		sb.WriteString(" WHERE ")
 		sb.WriteString(strings.Join(conds, " AND "))
 	}
-	if len(orders) > 0 {
-		sb.WriteString(" ORDER BY ")
-		sb.WriteString(buildExternalOrder(orders[0]))
-	} else {
-		sb.WriteString(` ORDER BY e."publishedDate" DESC`)
-	}
-	if take > 0 {
+	sb.WriteString(" ORDER BY ")
+	sb.WriteString(orderClause)
+	if take >= 0 {
 		sb.WriteString(fmt.Sprintf(" LIMIT %d", take))
 	}
 	if skip > 0 {
@@ -893,10 +1120,10 @@ func (r *Repo) QueryExternals(ctx context.Context, where *ExternalWhereInput, or
 		}
 		ext.ID = strconv.Itoa(dbID)
 		if pubAt.Valid {
-			ext.PublishedDate = pubAt.Time.UTC().Format(timeLayoutMilli)
+			ext.PublishedDate = r.formatTime(pubAt.Time)
 		}
 		if updAt.Valid {
-			ext.UpdatedAt = updAt.Time.UTC().Format(timeLayoutMilli)
+			ext.UpdatedAt = r.formatTime(updAt.Time)
 		}
 		externalIDs = append(externalIDs, dbID)
 		if partnerID.Valid {
@@ -911,17 +1138,32 @@ func (r *Repo) QueryExternals(ctx context.Context, where *ExternalWhereInput, or
 
 	partners, _ := r.fetchPartners(ctx, partnerIDs)
 	tagsMap, _ := r.fetchExternalTags(ctx, "_External_tags", externalIDs)
+	relatedsMap, relatedImageIDs, err := r.fetchExternalRelateds(ctx, externalIDs)
+	if err != nil {
+		return nil, err
+	}
+	imageMap, err := r.fetchImages(ctx, relatedImageIDs)
+	if err != nil {
+		return nil, err
+	}
 	for i := range result {
 		if pid := getMetaInt(result[i].Metadata, "partnerID"); pid > 0 {
 			result[i].Partner = partners[pid]
 		}
 		idInt, _ := strconv.Atoi(result[i].ID)
 		result[i].Tags = tagsMap[idInt]
+		relateds := relatedsMap[idInt]
+		for j := range relateds {
+			if idImg := getMetaInt(relateds[j].Metadata, "heroImageID"); idImg > 0 {
+				relateds[j].HeroImage = imageMap[idImg]
+			}
+		}
+		result[i].Relateds = relateds
 	}
 
 	// 寫入 cache
 	if r.cache != nil && r.cache.Enabled() {
-		cacheKey := GenerateCacheKey("externals", map[string]interface{}{
+		cacheKey := GenerateCacheKey(externalsCachePrefix, map[string]interface{}{
 			"where":  where,
 			"orders": orders,
 			"take":   take,
@@ -934,34 +1176,17 @@ func (r *Repo) QueryExternals(ctx context.Context, where *ExternalWhereInput, or
 }
 
 func (r *Repo) QueryExternalsCount(ctx context.Context, where *ExternalWhereInput) (int, error) {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	ctx = withOp(ctx, "externals_count")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
 	defer cancel()
 	where = ensureExternalPublished(where)
+	if r.mock != nil {
+		return len(r.mock.filterExternals(where)), nil
+	}
 	sb := strings.Builder{}
 	sb.WriteString(`SELECT COUNT(*) FROM "External" e`)
-	conds := []string{}
-	args := []interface{}{}
-	argIdx := 1
-	buildStringFilter := func(field string, f *StringFilter) {
-		if f == nil {
-			return
-		}
-		if f.Equals != nil {
-			conds = append(conds, fmt.Sprintf(`%s = $%d`, field, argIdx))
-			args = append(args, *f.Equals)
-			argIdx++
-		}
-	}
-	if where != nil {
-		buildStringFilter("e.slug", where.Slug)
-		buildStringFilter("e.state", where.State)
-		if where.Partner != nil && where.Partner.Slug != nil && where.Partner.Slug.Equals != nil {
-			sb.WriteString(` JOIN "Partner" p ON p.id = e.partner`)
-			conds = append(conds, fmt.Sprintf(`p.slug = $%d`, argIdx))
-			args = append(args, *where.Partner.Slug.Equals)
-			argIdx++
-		}
-	}
+	joins, conds, args := buildExternalConds(where)
+	sb.WriteString(joins)
 	if len(conds) > 0 {
 		sb.WriteString(" WHERE ")
 		sb.WriteString(strings.Join(conds, " AND "))
@@ -973,9 +1198,154 @@ func (r *Repo) QueryExternalsCount(ctx context.Context, where *ExternalWhereInpu
 	return count, nil
 }
 
+// PartnerExternalCount is the number of externals of one partner.
+type PartnerExternalCount struct {
+	Partner *Partner `json:"partner"`
+	Count   int      `json:"count"`
+}
+
+// QueryExternalsCountByPartner counts externals matching where, grouped by
+// partner, in a single query. Partners without matching externals are omitted.
+func (r *Repo) QueryExternalsCountByPartner(ctx context.Context, where *ExternalWhereInput) ([]PartnerExternalCount, error) {
+	ctx = withOp(ctx, "externals_count_by_partner")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
+	defer cancel()
+	where = ensureExternalPublished(where)
+	if r.mock != nil {
+		return r.mock.externalsCountByPartner(where), nil
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(`SELECT pa.id, pa.slug, pa.name, pa."showOnIndex", COALESCE(pa."showThumb", true), COALESCE(pa."showBrief", false), COUNT(*) FROM "External" e JOIN "Partner" pa ON pa.id = e.partner`)
+	joins, conds, args := buildExternalConds(where)
+	sb.WriteString(joins)
+	if len(conds) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(conds, " AND "))
+	}
+	sb.WriteString(" GROUP BY pa.id ORDER BY pa.id")
+
+	rows, err := r.db.QueryContext(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []PartnerExternalCount{}
+	for rows.Next() {
+		var (
+			p    Partner
+			dbID int
+			c    PartnerExternalCount
+		)
+		if err := rows.Scan(&dbID, &p.Slug, &p.Name, &p.ShowOnIndex, &p.ShowThumb, &p.ShowBrief, &c.Count); err != nil {
+			return nil, err
+		}
+		p.ID = strconv.Itoa(dbID)
+		c.Partner = &p
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
+
+// buildExternalConds 組出 externals 列表與計數查詢共用的 JOIN 與 WHERE 條件，External 的 alias 為 e
+func buildExternalConds(where *ExternalWhereInput) (string, []string, []interface{}) {
+	joins := ""
+	conds := []string{}
+	args := []interface{}{}
+	argIdx := 1
+	// addStringFilter 將 f 的條件加入 target（WHERE 或巢狀 relation 的子查詢），參數接續編號
+	addStringFilter := func(target *[]string, field string, f *StringFilter) {
+		c, a := stringFilterConds(field, f, argIdx)
+		*target = append(*target, c...)
+		args = append(args, a...)
+		argIdx += len(a)
+	}
+	if where != nil {
+		addStringFilter(&conds, "e.slug", where.Slug)
+		addStringFilter(&conds, "e.state", where.State)
+		dateConds, dateArgs := dateTimeFilterConds(`e."publishedDate"`, where.PublishedDate, argIdx)
+		conds = append(conds, dateConds...)
+		args = append(args, dateArgs...)
+		argIdx += len(dateArgs)
+		if where.Tags != nil && where.Tags.Some != nil {
+			w := where.Tags.Some
+			sub := []string{`et."A" = e.id`}
+			addStringFilter(&sub, "tg.slug", w.Slug)
+			addStringFilter(&sub, "tg.name", w.Name)
+			conds = append(conds, `EXISTS (SELECT 1 FROM "_External_tags" et JOIN "Tag" tg ON tg.id = et."B" WHERE `+strings.Join(sub, " AND ")+`)`)
+		}
+		if where.Partner != nil {
+			partnerConds := []string{}
+			addStringFilter(&partnerConds, "p.slug", where.Partner.Slug)
+			if len(partnerConds) > 0 {
+				joins += ` JOIN "Partner" p ON p.id = e.partner`
+				conds = append(conds, partnerConds...)
+			}
+		}
+	}
+	return joins, conds, args
+}
+
+// stringFilterConds 組出字串欄位 equals / in / notIn 的條件，參數自 $argIdx 起編號
+func stringFilterConds(field string, f *StringFilter, argIdx int) ([]string, []interface{}) {
+	conds := []string{}
+	args := []interface{}{}
+	if f == nil {
+		return conds, args
+	}
+	if f.Equals != nil {
+		conds = append(conds, fmt.Sprintf(`%s = $%d`, field, argIdx))
+		args = append(args, *f.Equals)
+		argIdx++
+	}
+	if len(f.In) > 0 {
+		conds = append(conds, fmt.Sprintf(`%s = ANY($%d)`, field, argIdx))
+		args = append(args, f.In)
+		argIdx++
+	}
+	if len(f.NotIn) > 0 {
+		conds = append(conds, fmt.Sprintf(`%s <> ALL($%d)`, field, argIdx))
+		args = append(args, f.NotIn)
+	}
+	return conds, args
+}
+
+// dateTimeFilterConds 組出時間欄位的條件，參數自 $argIdx 起編號；not 未指定 equals 時表示非 null
+func dateTimeFilterConds(field string, f *DateTimeNullableFilter, argIdx int) ([]string, []interface{}) {
+	conds := []string{}
+	args := []interface{}{}
+	if f == nil {
+		return conds, args
+	}
+	for _, c := range []struct {
+		op    string
+		value *string
+	}{{"=", f.Equals}, {">", f.Gt}, {">=", f.Gte}, {"<", f.Lt}, {"<=", f.Lte}} {
+		if c.value != nil {
+			conds = append(conds, fmt.Sprintf(`%s %s $%d`, field, c.op, argIdx))
+			args = append(args, *c.value)
+			argIdx++
+		}
+	}
+	if f.Not != nil {
+		if f.Not.Equals == nil {
+			conds = append(conds, field+` IS NOT NULL`)
+		} else {
+			conds = append(conds, fmt.Sprintf(`%s <> $%d`, field, argIdx))
+			args = append(args, *f.Not.Equals)
+		}
+	}
+	return conds, args
+}
+
 func (r *Repo) QueryTopics(ctx context.Context, where *TopicWhereInput, orders []OrderRule, take, skip int) ([]Topic, error) {
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	ctx = withOp(ctx, "topics_list")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
 	defer cancel()
+	if r.mock != nil {
+		return r.mock.queryTopics(where, orders, take, skip), nil
+	}
 
 	// 嘗試從 cache 讀取
 	if r.cache != nil && r.cache.Enabled() {
@@ -992,7 +1362,7 @@ func (r *Repo) QueryTopics(ctx context.Context, where *TopicWhereInput, orders [
 	}
 
 	sb := strings.Builder{}
-	sb.WriteString(`SELECT id, name, slug, "sortOrder", state, brief, "heroImage", "heroUrl", "leading", "og_title", "og_description", "og_image", "isFeatured", "title_style", type, style, javascript, dfp, "mobile_dfp", "createdAt", "updatedAt" FROM "Topic" t`)
+	sb.WriteString(topicSelect + ` t`)
 
 	conds := []string{}
 	args := []interface{}{}
@@ -1012,9 +1382,46 @@ func (r *Repo) QueryTopics(ctx context.Context, where *TopicWhereInput, orders [
 			args = append(args, f.In)
 			argIdx++
 		}
+		if len(f.NotIn) > 0 {
+			conds = append(conds, fmt.Sprintf(`%s <> ALL($%d)`, field, argIdx))
+			args = append(args, f.NotIn)
+			argIdx++
+		}
 	}
 
+	// idOrderArg 記錄 id in 參數位置，未指定 orderBy 時依輸入順序排序
+	idOrderArg := 0
 	if where != nil {
+		if where.ID != nil {
+			if where.ID.Equals != nil {
+				id, err := parseIDs([]string{*where.ID.Equals})
+				if err != nil {
+					return nil, err
+				}
+				conds = append(conds, fmt.Sprintf(`id = $%d`, argIdx))
+				args = append(args, id[0])
+				argIdx++
+			}
+			if len(where.ID.In) > 0 {
+				ids, err := parseIDs(where.ID.In)
+				if err != nil {
+					return nil, err
+				}
+				conds = append(conds, fmt.Sprintf(`id = ANY($%d)`, argIdx))
+				args = append(args, pqIntArray(ids))
+				idOrderArg = argIdx
+				argIdx++
+			}
+			if len(where.ID.NotIn) > 0 {
+				ids, err := parseIDs(where.ID.NotIn)
+				if err != nil {
+					return nil, err
+				}
+				conds = append(conds, fmt.Sprintf(`id <> ALL($%d)`, argIdx))
+				args = append(args, pqIntArray(ids))
+				argIdx++
+			}
+		}
 		buildStringFilter("slug", where.Slug)
 		buildStringFilter("name", where.Name)
 		buildStringFilter("state", where.State)
@@ -1035,11 +1442,13 @@ func (r *Repo) QueryTopics(ctx context.Context, where *TopicWhereInput, orders [
 	if len(orders) > 0 {
 		sb.WriteString(" ORDER BY ")
 		sb.WriteString(buildTopicOrderClause(orders[0]))
+	} else if idOrderArg > 0 {
+		sb.WriteString(fmt.Sprintf(` ORDER BY array_position($%d::bigint[], id::bigint)`, idOrderArg))
 	} else {
 		sb.WriteString(` ORDER BY "sortOrder" ASC NULLS LAST, "createdAt" DESC`)
 	}
 
-	if take > 0 {
+	if take >= 0 {
 		sb.WriteString(fmt.Sprintf(" LIMIT %d", take))
 	}
 	if skip > 0 {
@@ -1054,97 +1463,10 @@ func (r *Repo) QueryTopics(ctx context.Context, where *TopicWhereInput, orders [
 
 	topics := []Topic{}
 	for rows.Next() {
-		var (
-			t           Topic
-			dbID        int
-			sortOrder   sql.NullInt64
-			heroImageID sql.NullInt64
-			ogImageID   sql.NullInt64
-			briefRaw    []byte
-			createdAt   sql.NullTime
-			updatedAt   sql.NullTime
-			heroURL     sql.NullString
-			leading     sql.NullString
-			ogTitle     sql.NullString
-			ogDesc      sql.NullString
-			titleStyle  sql.NullString
-			typeVal     sql.NullString
-			styleVal    sql.NullString
-			javascript  sql.NullString
-			dfp         sql.NullString
-			mobileDfp   sql.NullString
-		)
-		if err := rows.Scan(
-			&dbID,
-			&t.Name,
-			&t.Slug,
-			&sortOrder,
-			&t.State,
-			&briefRaw,
-			&heroImageID,
-			&heroURL,
-			&leading,
-			&ogTitle,
-			&ogDesc,
-			&ogImageID,
-			&t.IsFeatured,
-			&titleStyle,
-			&typeVal,
-			&styleVal,
-			&javascript,
-			&dfp,
-			&mobileDfp,
-			&createdAt,
-			&updatedAt,
-		); err != nil {
+		t, err := r.scanTopic(rows)
+		if err != nil {
 			return nil, err
 		}
-		t.ID = strconv.Itoa(dbID)
-		if sortOrder.Valid {
-			val := int(sortOrder.Int64)
-			t.SortOrder = &val
-		}
-		if createdAt.Valid {
-			t.CreatedAt = createdAt.Time.UTC().Format(timeLayoutMilli)
-		}
-		if updatedAt.Valid {
-			t.UpdatedAt = updatedAt.Time.UTC().Format(timeLayoutMilli)
-		}
-		t.Brief = decodeJSONBytes(briefRaw)
-		if heroURL.Valid {
-			t.HeroURL = heroURL.String
-		}
-		if leading.Valid {
-			t.Leading = leading.String
-		}
-		if ogTitle.Valid {
-			t.OgTitle = ogTitle.String
-		}
-		if ogDesc.Valid {
-			t.OgDescription = ogDesc.String
-		}
-		if titleStyle.Valid {
-			t.TitleStyle = titleStyle.String
-		}
-		if typeVal.Valid {
-			t.Type = typeVal.String
-		}
-		if styleVal.Valid {
-			t.Style = styleVal.String
-		}
-		if javascript.Valid {
-			t.Javascript = javascript.String
-		}
-		if dfp.Valid {
-			t.Dfp = dfp.String
-		}
-		if mobileDfp.Valid {
-			t.MobileDfp = mobileDfp.String
-		}
-		t.Metadata = map[string]any{
-			"heroImageID": nullableInt(heroImageID),
-			"ogImageID":   nullableInt(ogImageID),
-		}
 		topics = append(topics, t)
 	}
 	if err := rows.Err(); err != nil {
@@ -1173,8 +1495,12 @@ func (r *Repo) QueryTopics(ctx context.Context, where *TopicWhereInput, orders [
 }
 
 func (r *Repo) QueryTopicsCount(ctx context.Context, where *TopicWhereInput) (int, error) {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	ctx = withOp(ctx, "topics_count")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
 	defer cancel()
+	if r.mock != nil {
+		return len(r.mock.filterTopics(where)), nil
+	}
 
 	// 嘗試從 cache 讀取
 	if r.cache != nil && r.cache.Enabled() {
@@ -1201,9 +1527,48 @@ func (r *Repo) QueryTopicsCount(ctx context.Context, where *TopicWhereInput) (in
 			args = append(args, *f.Equals)
 			argIdx++
 		}
+		if len(f.In) > 0 {
+			conds = append(conds, fmt.Sprintf(`%s = ANY($%d)`, field, argIdx))
+			args = append(args, f.In)
+			argIdx++
+		}
+		if len(f.NotIn) > 0 {
+			conds = append(conds, fmt.Sprintf(`%s <> ALL($%d)`, field, argIdx))
+			args = append(args, f.NotIn)
+			argIdx++
+		}
 	}
 
 	if where != nil {
+		if where.ID != nil {
+			if where.ID.Equals != nil {
+				id, err := parseIDs([]string{*where.ID.Equals})
+				if err != nil {
+					return 0, err
+				}
+				conds = append(conds, fmt.Sprintf(`id = $%d`, argIdx))
+				args = append(args, id[0])
+				argIdx++
+			}
+			if len(where.ID.In) > 0 {
+				ids, err := parseIDs(where.ID.In)
+				if err != nil {
+					return 0, err
+				}
+				conds = append(conds, fmt.Sprintf(`id = ANY($%d)`, argIdx))
+				args = append(args, pqIntArray(ids))
+				argIdx++
+			}
+			if len(where.ID.NotIn) > 0 {
+				ids, err := parseIDs(where.ID.NotIn)
+				if err != nil {
+					return 0, err
+				}
+				conds = append(conds, fmt.Sprintf(`id <> ALL($%d)`, argIdx))
+				args = append(args, pqIntArray(ids))
+				argIdx++
+			}
+		}
 		buildStringFilter("slug", where.Slug)
 		buildStringFilter("name", where.Name)
 		buildStringFilter("state", where.State)
@@ -1236,14 +1601,18 @@ func (r *Repo) QueryTopicsCount(ctx context.Context, where *TopicWhereInput) (in
 }
 
 func (r *Repo) QueryTopicByUnique(ctx context.Context, where *TopicWhereUniqueInput) (*Topic, error) {
+	ctx = withOp(ctx, "topic_unique")
 	if where == nil {
 		return nil, nil
 	}
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	if r.mock != nil {
+		return r.mock.topicByUnique(where), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(10*time.Second))
 	defer cancel()
 
 	// 嘗試從 cache 讀取
-	if r.cache != nil && r.cache.Enabled() {
+	if r.cache != nil && r.cache.Enabled() && !cacheRefreshing(ctx) {
 		cacheKey := GenerateCacheKey("topic:unique", where)
 		var cachedTopic *Topic
 		if found, _ := r.cache.Get(ctx, cacheKey, &cachedTopic); found {
@@ -1252,7 +1621,7 @@ func (r *Repo) QueryTopicByUnique(ctx context.Context, where *TopicWhereUniqueIn
 	}
 
 	sb := strings.Builder{}
-	sb.WriteString(`SELECT id, name, slug, "sortOrder", state, brief, "heroImage", "heroUrl", "leading", "og_title", "og_description", "og_image", "isFeatured", "title_style", type, style, javascript, dfp, "mobile_dfp", "createdAt", "updatedAt" FROM "Topic" t WHERE `)
+	sb.WriteString(topicSelect + ` t WHERE `)
 	args := []interface{}{}
 	argIdx := 1
 	if where.ID != nil {
@@ -1272,102 +1641,13 @@ func (r *Repo) QueryTopicByUnique(ctx context.Context, where *TopicWhereUniqueIn
 	}
 	sb.WriteString(" LIMIT 1")
 
-	var (
-		t           Topic
-		dbID        int
-		sortOrder   sql.NullInt64
-		heroImageID sql.NullInt64
-		ogImageID   sql.NullInt64
-		briefRaw    []byte
-		createdAt   sql.NullTime
-		updatedAt   sql.NullTime
-		heroURL     sql.NullString
-		leading     sql.NullString
-		ogTitle     sql.NullString
-		ogDesc      sql.NullString
-		titleStyle  sql.NullString
-		typeVal     sql.NullString
-		styleVal    sql.NullString
-		javascript  sql.NullString
-		dfp         sql.NullString
-		mobileDfp   sql.NullString
-	)
-
-	err := r.db.QueryRowContext(ctx, sb.String(), args...).Scan(
-		&dbID,
-		&t.Name,
-		&t.Slug,
-		&sortOrder,
-		&t.State,
-		&briefRaw,
-		&heroImageID,
-		&heroURL,
-		&leading,
-		&ogTitle,
-		&ogDesc,
-		&ogImageID,
-		&t.IsFeatured,
-		&titleStyle,
-		&typeVal,
-		&styleVal,
-		&javascript,
-		&dfp,
-		&mobileDfp,
-		&createdAt,
-		&updatedAt,
-	)
+	t, err := r.scanTopic(r.db.QueryRowContext(ctx, sb.String(), args...))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
-	t.ID = strconv.Itoa(dbID)
-	if sortOrder.Valid {
-		val := int(sortOrder.Int64)
-		t.SortOrder = &val
-	}
-	if createdAt.Valid {
-		t.CreatedAt = createdAt.Time.UTC().Format(timeLayoutMilli)
-	}
-	if updatedAt.Valid {
-		t.UpdatedAt = updatedAt.Time.UTC().Format(timeLayoutMilli)
-	}
-	t.Brief = decodeJSONBytes(briefRaw)
-	if heroURL.Valid {
-		t.HeroURL = heroURL.String
-	}
-	if leading.Valid {
-		t.Leading = leading.String
-	}
-	if ogTitle.Valid {
-		t.OgTitle = ogTitle.String
-	}
-	if ogDesc.Valid {
-		t.OgDescription = ogDesc.String
-	}
-	if titleStyle.Valid {
-		t.TitleStyle = titleStyle.String
-	}
-	if typeVal.Valid {
-		t.Type = typeVal.String
-	}
-	if styleVal.Valid {
-		t.Style = styleVal.String
-	}
-	if javascript.Valid {
-		t.Javascript = javascript.String
-	}
-	if dfp.Valid {
-		t.Dfp = dfp.String
-	}
-	if mobileDfp.Valid {
-		t.MobileDfp = mobileDfp.String
-	}
-	t.Metadata = map[string]any{
-		"heroImageID": nullableInt(heroImageID),
-		"ogImageID":   nullableInt(ogImageID),
-	}
 
 	topics := []Topic{t}
 	if err := r.enrichTopics(ctx, topics); err != nil {
@@ -1419,6 +1699,19 @@ func ensureExternalPublished(where *ExternalWhereInput) *ExternalWhereInput {
 
 func ptrString(s string) *string { return &s }
 
+// parseIDs 將 GraphQL ID 轉為整數，格式錯誤時回傳 error
+func parseIDs(raw []string) ([]int, error) {
+	ids := make([]int, 0, len(raw))
+	for _, v := range raw {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %q", v)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
 func decodeJSONBytes(raw []byte) map[string]any {
 	if len(raw) == 0 {
 		return nil
@@ -1430,6 +1723,18 @@ func decodeJSONBytes(raw []byte) map[string]any {
 	return m
 }
 
+// decodeJSONValue 解析任意 JSON 值，保留陣列與純量（decodeJSONBytes 只接受 object）
+func decodeJSONValue(raw []byte) any {
+	if len(raw) == 0 {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil
+	}
+	return v
+}
+
 func nullableInt(v sql.NullInt64) int {
 	if v.Valid {
 		return int(v.Int64)
@@ -1469,19 +1774,33 @@ func buildOrderClause(rule OrderRule) string {
 	}
 }
 
-func buildExternalOrder(rule OrderRule) string {
-	dir := strings.ToUpper(rule.Direction)
-	if dir != "ASC" && dir != "DESC" {
-		dir = "DESC"
+// buildExternalOrder 組合多個排序規則，依序作為 ORDER BY 的鍵；
+// partnerName 需要 LEFT JOIN "Partner"（alias po），needsPartner 表示呼叫端需加上 join
+func buildExternalOrder(rules []OrderRule) (clause string, needsPartner bool) {
+	parts := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		dir := strings.ToUpper(rule.Direction)
+		if dir != "ASC" && dir != "DESC" {
+			dir = "DESC"
+		}
+		switch rule.Field {
+		case "publishedDate":
+			parts = append(parts, fmt.Sprintf(`e."publishedDate" %s`, dir))
+		case "updatedAt":
+			parts = append(parts, fmt.Sprintf(`e."updatedAt" %s`, dir))
+		case "createdAt":
+			parts = append(parts, fmt.Sprintf(`e."createdAt" %s`, dir))
+		case "title":
+			parts = append(parts, fmt.Sprintf(`e.title %s`, dir))
+		case "partnerName":
+			parts = append(parts, fmt.Sprintf(`po.name %s NULLS LAST`, dir))
+			needsPartner = true
+		}
 	}
-	switch rule.Field {
-	case "publishedDate":
-		return fmt.Sprintf(`e."publishedDate" %s`, dir)
-	case "updatedAt":
-		return fmt.Sprintf(`e."updatedAt" %s`, dir)
-	default:
-		return `e."publishedDate" DESC`
+	if len(parts) == 0 {
+		return `e."publishedDate" DESC`, false
 	}
+	return strings.Join(parts, ", "), needsPartner
 }
 
 func buildTopicOrderClause(rule OrderRule) string {
@@ -1517,7 +1836,7 @@ func (r *Repo) enrichPosts(ctx context.Context, posts []Post) error {
 		}
 		postIDs = append(postIDs, id)
 	}
-	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(15*time.Second))
 	defer cancel()
 
 	sectionsMap, err := r.fetchSections(ctx, postIDs)
@@ -1528,6 +1847,13 @@ func (r *Repo) enrichPosts(ctx context.Context, posts []Post) error {
 	if err != nil {
 		return err
 	}
+	categoryLists := make([][]Category, 0, len(categoriesMap))
+	for _, list := range categoriesMap {
+		categoryLists = append(categoryLists, list)
+	}
+	if err := r.attachCategorySections(ctx, categoryLists...); err != nil {
+		return err
+	}
 	roleMapWriters, _ := r.fetchContacts(ctx, "_Post_writers", postIDs)
 	roleMapPhotographers, _ := r.fetchContacts(ctx, "_Post_photographers", postIDs)
 	roleMapCamera, _ := r.fetchContacts(ctx, "_Post_camera_man", postIDs)
@@ -1587,11 +1913,51 @@ func (r *Repo) enrichPosts(ctx context.Context, posts []Post) error {
 
 	videoMap, videoImageIDs, _ := r.fetchVideos(ctx, videoIDs)
 	imageIDs = append(imageIDs, videoImageIDs...)
+	audioRefs, _ := r.fetchPostAudioRefs(ctx, postIDs)
+	audioIDs := []int{}
+	for _, ref := range audioRefs {
+		if ref.HeroAudioID > 0 {
+			audioIDs = append(audioIDs, ref.HeroAudioID)
+		}
+		if ref.AudioID > 0 {
+			audioIDs = append(audioIDs, ref.AudioID)
+		}
+	}
+	audioMap, audioImageIDs, _ := r.fetchAudios(ctx, audioIDs)
+	imageIDs = append(imageIDs, audioImageIDs...)
 	topicMap, _ := r.fetchTopics(ctx, topicIDs)
+	for _, t := range topicMap {
+		if idImg := getMetaInt(t.Metadata, "heroImageID"); idImg > 0 {
+			imageIDs = append(imageIDs, idImg)
+		}
+		if idImg := getMetaInt(t.Metadata, "ogImageID"); idImg > 0 {
+			imageIDs = append(imageIDs, idImg)
+		}
+	}
 	imageMap, err := r.fetchImages(ctx, imageIDs)
 	if err != nil {
 		return err
 	}
+	for id, t := range topicMap {
+		if idImg := getMetaInt(t.Metadata, "heroImageID"); idImg > 0 {
+			t.HeroImage = imageMap[idImg]
+		}
+		if idImg := getMetaInt(t.Metadata, "ogImageID"); idImg > 0 {
+			t.OgImage = imageMap[idImg]
+		}
+		topicMap[id] = t
+	}
+
+	for _, v := range videoMap {
+		if idImg := getMetaInt(v.Metadata, "heroImageID"); idImg > 0 {
+			v.HeroImage = imageMap[idImg]
+		}
+	}
+	for _, a := range audioMap {
+		if idImg := getMetaInt(a.Metadata, "heroImageID"); idImg > 0 {
+			a.HeroImage = imageMap[idImg]
+		}
+	}
 
 	for i := range posts {
 		p := &posts[i]
@@ -1620,6 +1986,10 @@ func (r *Repo) enrichPosts(ctx context.Context, posts []Post) error {
 		if vid := getMetaInt(p.Metadata, "heroVideoID"); vid > 0 {
 			p.HeroVideo = videoMap[vid]
 		}
+		if ref, ok := audioRefs[id]; ok {
+			p.HeroAudio = audioMap[ref.HeroAudioID]
+			p.Audio = audioMap[ref.AudioID]
+		}
 		if tid := getMetaInt(p.Metadata, "topicsID"); tid > 0 {
 			if t, ok := topicMap[tid]; ok {
 				p.Topics = &t
@@ -1635,6 +2005,9 @@ func (r *Repo) enrichPosts(ctx context.Context, posts []Post) error {
 				p.RelatedsTwo = &rp
 			}
 		}
+		stats := apidata.CountText(p.Content)
+		p.ReadingTime = apidata.ReadingTime(stats)
+		p.WordCount = stats.WordCount()
 	}
 	return nil
 }
@@ -1651,7 +2024,7 @@ func (r *Repo) enrichTopics(ctx context.Context, topics []Topic) error {
 		}
 		topicIDs = append(topicIDs, id)
 	}
-	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(15*time.Second))
 	defer cancel()
 
 	// 獲取 heroImage 和 og_image
@@ -1672,6 +2045,28 @@ func (r *Repo) enrichTopics(ctx context.Context, topics []Topic) error {
 	slideshowMap, slideshowImageIDs, _ := r.fetchTopicSlideshowImages(ctx, topicIDs)
 	imageIDs = append(imageIDs, slideshowImageIDs...)
 
+	// 獲取 parentTopic 和 subtopics（只展開一層）
+	parentIDs := []int{}
+	for _, t := range topics {
+		if id := getMetaInt(t.Metadata, "parentTopicID"); id > 0 {
+			parentIDs = append(parentIDs, id)
+		}
+	}
+	parentMap, _ := r.fetchParentTopics(ctx, parentIDs)
+	subtopicsMap, _ := r.fetchSubtopics(ctx, topicIDs)
+	for _, t := range parentMap {
+		if id := getMetaInt(t.Metadata, "heroImageID"); id > 0 {
+			imageIDs = append(imageIDs, id)
+		}
+	}
+	for _, list := range subtopicsMap {
+		for _, t := range list {
+			if id := getMetaInt(t.Metadata, "heroImageID"); id > 0 {
+				imageIDs = append(imageIDs, id)
+			}
+		}
+	}
+
 	// 獲取 images
 	imageMap, err := r.fetchImages(ctx, imageIDs)
 	if err != nil {
@@ -1699,6 +2094,17 @@ func (r *Repo) enrichTopics(ctx context.Context, topics []Topic) error {
 		// 設置 slideshow_images
 		t.SlideshowImages = slideshowMap[id]
 		t.SlideshowImagesInOrder = slideshowMap[id]
+
+		// 設置 parentTopic 和 subtopics
+		if parent, ok := parentMap[getMetaInt(t.Metadata, "parentTopicID")]; ok {
+			parent.HeroImage = imageMap[getMetaInt(parent.Metadata, "heroImageID")]
+			t.ParentTopic = &parent
+		}
+		subtopics := subtopicsMap[id]
+		for j := range subtopics {
+			subtopics[j].HeroImage = imageMap[getMetaInt(subtopics[j].Metadata, "heroImageID")]
+		}
+		t.Subtopics = subtopics
 	}
 	return nil
 }
@@ -1791,6 +2197,8 @@ func (r *Repo) fetchTags(ctx context.Context, table string, postIDs []int) (map[
 	return result, rows.Err()
 }
 
+// fetchRelatedPosts 取得雙向的相關文章，依 manualOrderOfRelateds 排序並去除重複。
+// 所有狀態的文章都會回傳（State 有值），是否只顯示 published 由 resolver 決定。
 func (r *Repo) fetchRelatedPosts(ctx context.Context, postIDs []int) (map[int][]Post, []int, error) {
 	result := map[int][]Post{}
 	imageIDs := []int{}
@@ -1798,29 +2206,35 @@ func (r *Repo) fetchRelatedPosts(ctx context.Context, postIDs []int) (map[int][]
 		return result, imageIDs, nil
 	}
 	query := `
-		SELECT r."A" as post_id, p.id, p.slug, p.title, p."heroImage"
+		SELECT r."A" as post_id, p.id, p.slug, p.title, p.state, p."heroImage"
 		FROM "_Post_relateds" r
 		JOIN "Post" p ON p.id = r."B"
 		WHERE r."A" = ANY($1)
 		UNION
-		SELECT r."B" as post_id, p.id, p.slug, p.title, p."heroImage"
+		SELECT r."B" as post_id, p.id, p.slug, p.title, p.state, p."heroImage"
 		FROM "_Post_relateds" r
 		JOIN "Post" p ON p.id = r."A"
 		WHERE r."B" = ANY($1)
+		ORDER BY post_id, id
 	`
 	rows, err := r.db.QueryContext(ctx, query, pqIntArray(postIDs))
 	if err != nil {
 		return result, imageIDs, err
 	}
 	defer rows.Close()
+	seen := map[[2]int]bool{}
 	for rows.Next() {
 		var pid int
 		var rp Post
 		var dbID int
 		var heroID sql.NullInt64
-		if err := rows.Scan(&pid, &dbID, &rp.Slug, &rp.Title, &heroID); err != nil {
+		if err := rows.Scan(&pid, &dbID, &rp.Slug, &rp.Title, &rp.State, &heroID); err != nil {
 			return result, imageIDs, err
 		}
+		if seen[[2]int{pid, dbID}] || pid == dbID {
+			continue
+		}
+		seen[[2]int{pid, dbID}] = true
 		rp.ID = strconv.Itoa(dbID)
 		if heroID.Valid {
 			imageIDs = append(imageIDs, int(heroID.Int64))
@@ -1828,7 +2242,64 @@ func (r *Repo) fetchRelatedPosts(ctx context.Context, postIDs []int) (map[int][]
 		}
 		result[pid] = append(result[pid], rp)
 	}
-	return result, imageIDs, rows.Err()
+	if err := rows.Err(); err != nil {
+		return result, imageIDs, err
+	}
+
+	// 編輯排序讀取失敗時維持 id 排序
+	orders, _ := r.fetchRelatedOrder(ctx, postIDs)
+	for pid, list := range result {
+		order := orders[pid]
+		if len(order) == 0 {
+			continue
+		}
+		rank := make(map[string]int, len(order))
+		for i, id := range order {
+			if _, ok := rank[id]; !ok {
+				rank[id] = i
+			}
+		}
+		sort.SliceStable(list, func(i, j int) bool {
+			ri, iok := rank[list[i].ID]
+			rj, jok := rank[list[j].ID]
+			if iok && jok {
+				return ri < rj
+			}
+			// 不在排序清單中的排在最後
+			return iok && !jok
+		})
+	}
+	return result, imageIDs, nil
+}
+
+// fetchRelatedOrder 讀取 Post."manualOrderOfRelateds"（[{id, ...}] 格式）中的文章 ID 順序
+func (r *Repo) fetchRelatedOrder(ctx context.Context, postIDs []int) (map[int][]string, error) {
+	result := map[int][]string{}
+	rows, err := r.db.QueryContext(ctx, `SELECT id, "manualOrderOfRelateds" FROM "Post" WHERE id = ANY($1) AND "manualOrderOfRelateds" IS NOT NULL`, pqIntArray(postIDs))
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var pid int
+		var raw []byte
+		if err := rows.Scan(&pid, &raw); err != nil {
+			return result, err
+		}
+		var items []map[string]any
+		if err := json.Unmarshal(raw, &items); err != nil {
+			continue
+		}
+		for _, item := range items {
+			switch id := item["id"].(type) {
+			case string:
+				result[pid] = append(result[pid], id)
+			case float64:
+				result[pid] = append(result[pid], strconv.Itoa(int(id)))
+			}
+		}
+	}
+	return result, rows.Err()
 }
 
 func (r *Repo) fetchPostsByIDs(ctx context.Context, ids []int) ([]Post, []int, error) {
@@ -1837,7 +2308,7 @@ func (r *Repo) fetchPostsByIDs(ctx context.Context, ids []int) ([]Post, []int, e
 	if len(ids) == 0 {
 		return result, imageIDs, nil
 	}
-	rows, err := r.db.QueryContext(ctx, `SELECT id, slug, title, "heroImage" FROM "Post" WHERE id = ANY($1)`, pqIntArray(ids))
+	rows, err := r.db.QueryContext(ctx, `SELECT id, slug, title, state, "heroImage" FROM "Post" WHERE id = ANY($1)`, pqIntArray(ids))
 	if err != nil {
 		return result, imageIDs, err
 	}
@@ -1846,7 +2317,7 @@ func (r *Repo) fetchPostsByIDs(ctx context.Context, ids []int) ([]Post, []int, e
 		var p Post
 		var dbID int
 		var hero sql.NullInt64
-		if err := rows.Scan(&dbID, &p.Slug, &p.Title, &hero); err != nil {
+		if err := rows.Scan(&dbID, &p.Slug, &p.Title, &p.State, &hero); err != nil {
 			return result, imageIDs, err
 		}
 		p.ID = strconv.Itoa(dbID)
@@ -1859,13 +2330,14 @@ func (r *Repo) fetchPostsByIDs(ctx context.Context, ids []int) ([]Post, []int, e
 	return result, imageIDs, rows.Err()
 }
 
+// fetchVideos 回傳影片與其 heroImage（poster）id，poster 由呼叫端以 fetchImages 統一查詢
 func (r *Repo) fetchVideos(ctx context.Context, videoIDs []int) (map[int]*Video, []int, error) {
 	result := map[int]*Video{}
 	imageIDs := []int{}
 	if len(videoIDs) == 0 {
 		return result, imageIDs, nil
 	}
-	rows, err := r.db.QueryContext(ctx, `SELECT id, "urlOriginal", "heroImage" FROM "Video" WHERE id = ANY($1)`, pqIntArray(videoIDs))
+	rows, err := r.db.QueryContext(ctx, `SELECT id, COALESCE(name, ''), COALESCE(state, ''), COALESCE("urlOriginal", ''), COALESCE("file_filename", ''), COALESCE(duration, 0), "heroImage" FROM "Video" WHERE id = ANY($1)`, pqIntArray(videoIDs))
 	if err != nil {
 		return result, imageIDs, err
 	}
@@ -1873,38 +2345,136 @@ func (r *Repo) fetchVideos(ctx context.Context, videoIDs []int) (map[int]*Video,
 	for rows.Next() {
 		var v Video
 		var dbID int
+		var filename string
 		var hero sql.NullInt64
-		if err := rows.Scan(&dbID, &v.VideoSrc, &hero); err != nil {
+		if err := rows.Scan(&dbID, &v.Name, &v.State, &v.VideoSrc, &filename, &v.Duration, &hero); err != nil {
 			return result, imageIDs, err
 		}
 		v.ID = strconv.Itoa(dbID)
+		fileURL := r.buildFileURL(filename)
+		if v.VideoSrc == "" {
+			v.VideoSrc = fileURL
+		}
+		for _, src := range []string{v.VideoSrc, fileURL} {
+			switch ext := strings.ToLower(path.Ext(strings.SplitN(src, "?", 2)[0])); {
+			case ext == ".m3u8" && v.HlsSrc == "":
+				v.HlsSrc = src
+			case ext == ".mp4" && v.Mp4Src == "":
+				v.Mp4Src = src
+			}
+		}
 		if hero.Valid {
 			imageIDs = append(imageIDs, int(hero.Int64))
-			v.HeroImage = &Photo{}
-			v.HeroImage.ImageFile = ImageFile{}
-			v.HeroImage.Metadata = map[string]any{"heroImageID": int(hero.Int64)}
+			v.Metadata = map[string]any{"heroImageID": int(hero.Int64)}
 		}
 		result[dbID] = &v
 	}
 	return result, imageIDs, rows.Err()
 }
 
+// topicSelect 是 topic 完整欄位的 SELECT，欄位順序須與 scanTopic 一致
+const topicSelect = `SELECT id, name, slug, "sortOrder", state, brief, "heroImage", "heroUrl", "leading", "og_title", "og_description", "og_image", "isFeatured", "title_style", type, style, javascript, dfp, "mobile_dfp", "manualOrderOfSlideshowImages", "createdAt", "updatedAt", "parentTopic" FROM "Topic"`
+
+// scanTopic 讀取 topicSelect 的一列，圖片與上層 topic 的 id 放在 Metadata 中
+func (r *Repo) scanTopic(row interface{ Scan(dest ...any) error }) (Topic, error) {
+	var (
+		t           Topic
+		dbID        int
+		sortOrder   sql.NullInt64
+		heroImageID sql.NullInt64
+		ogImageID   sql.NullInt64
+		parentID    sql.NullInt64
+		briefRaw    []byte
+		manualOrder []byte
+		createdAt   sql.NullTime
+		updatedAt   sql.NullTime
+		heroURL     sql.NullString
+		leading     sql.NullString
+		ogTitle     sql.NullString
+		ogDesc      sql.NullString
+		titleStyle  sql.NullString
+		typeVal     sql.NullString
+		styleVal    sql.NullString
+		javascript  sql.NullString
+		dfp         sql.NullString
+		mobileDfp   sql.NullString
+	)
+	if err := row.Scan(
+		&dbID,
+		&t.Name,
+		&t.Slug,
+		&sortOrder,
+		&t.State,
+		&briefRaw,
+		&heroImageID,
+		&heroURL,
+		&leading,
+		&ogTitle,
+		&ogDesc,
+		&ogImageID,
+		&t.IsFeatured,
+		&titleStyle,
+		&typeVal,
+		&styleVal,
+		&javascript,
+		&dfp,
+		&mobileDfp,
+		&manualOrder,
+		&createdAt,
+		&updatedAt,
+		&parentID,
+	); err != nil {
+		return t, err
+	}
+	t.ID = strconv.Itoa(dbID)
+	if sortOrder.Valid {
+		val := int(sortOrder.Int64)
+		t.SortOrder = &val
+	}
+	if createdAt.Valid {
+		t.CreatedAt = r.formatTime(createdAt.Time)
+	}
+	if updatedAt.Valid {
+		t.UpdatedAt = r.formatTime(updatedAt.Time)
+	}
+	t.Brief = decodeJSONBytes(briefRaw)
+	t.ManualOrderOfSlideshowImages = decodeJSONValue(manualOrder)
+	t.HeroURL = heroURL.String
+	t.Leading = leading.String
+	t.OgTitle = ogTitle.String
+	t.OgDescription = ogDesc.String
+	t.TitleStyle = titleStyle.String
+	t.Type = typeVal.String
+	t.Style = styleVal.String
+	t.Javascript = javascript.String
+	t.Dfp = dfp.String
+	t.MobileDfp = mobileDfp.String
+	t.Metadata = map[string]any{
+		"heroImageID":   nullableInt(heroImageID),
+		"ogImageID":     nullableInt(ogImageID),
+		"parentTopicID": nullableInt(parentID),
+	}
+	return t, nil
+}
+
+// fetchTopics 依 id 取得 post.topics 的完整欄位；heroImage 與 og_image 由
+// enrichPosts 與 post 的圖片一起載入
 func (r *Repo) fetchTopics(ctx context.Context, ids []int) (map[int]Topic, error) {
 	result := map[int]Topic{}
 	if len(ids) == 0 {
 		return result, nil
 	}
-	rows, err := r.db.QueryContext(ctx, `SELECT id, slug FROM "Topic" WHERE id = ANY($1)`, pqIntArray(ids))
+	rows, err := r.db.QueryContext(ctx, topicSelect+` WHERE id = ANY($1)`, pqIntArray(ids))
 	if err != nil {
 		return result, err
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var id int
-		var t Topic
-		if err := rows.Scan(&id, &t.Slug); err != nil {
+		t, err := r.scanTopic(rows)
+		if err != nil {
 			return result, err
 		}
+		id, _ := strconv.Atoi(t.ID)
 		result[id] = t
 	}
 	return result, rows.Err()
@@ -1915,7 +2485,7 @@ func (r *Repo) fetchImages(ctx context.Context, ids []int) (map[int]*Photo, erro
 	if len(ids) == 0 {
 		return result, nil
 	}
-	rows, err := r.db.QueryContext(ctx, `SELECT id, COALESCE("imageFile_id", ''), COALESCE("imageFile_extension", ''), "imageFile_width", "imageFile_height" FROM "Image" WHERE id = ANY($1)`, pqIntArray(ids))
+	rows, err := r.db.QueryContext(ctx, `SELECT id, COALESCE("imageFile_id", ''), COALESCE("imageFile_extension", ''), "imageFile_width", "imageFile_height"`+r.imageFormatSelect("")+` FROM "Image" WHERE id = ANY($1)`, pqIntArray(ids))
 	if err != nil {
 		return result, err
 	}
@@ -1928,7 +2498,9 @@ func (r *Repo) fetchImages(ctx context.Context, ids []int) (map[int]*Photo, erro
 			width  sql.NullInt64
 			height sql.NullInt64
 		}
-		if err := rows.Scan(&im.id, &im.fileID, &im.ext, &im.width, &im.height); err != nil {
+		var formats imageFormats
+		dest := append([]any{&im.id, &im.fileID, &im.ext, &im.width, &im.height}, r.imageFormatDest(&formats)...)
+		if err := rows.Scan(dest...); err != nil {
 			return result, err
 		}
 		photo := Photo{
@@ -1938,8 +2510,7 @@ func (r *Repo) fetchImages(ctx context.Context, ids []int) (map[int]*Photo, erro
 				Height: int(im.height.Int64),
 			},
 		}
-		photo.Resized = r.buildResizedURLs(im.fileID, im.ext)
-		photo.ResizedWebp = r.buildResizedURLs(im.fileID, "webp")
+		r.setResizedURLs(&photo, im.fileID, im.ext, formats)
 		result[im.id] = &photo
 	}
 	return result, rows.Err()
@@ -1988,6 +2559,38 @@ func (r *Repo) fetchExternalTags(ctx context.Context, table string, externalIDs
 	return result, rows.Err()
 }
 
+// fetchExternalRelateds 取得 external 的相關文章，heroImage 的 ID 另外回傳供批次查詢
+func (r *Repo) fetchExternalRelateds(ctx context.Context, externalIDs []int) (map[int][]Post, []int, error) {
+	result := map[int][]Post{}
+	imageIDs := []int{}
+	if len(externalIDs) == 0 {
+		return result, imageIDs, nil
+	}
+	// External.relateds 沒有 where 參數，直接在 SQL 只保留已發布的文章
+	query := `SELECT t."A" as external_id, p.id, p.slug, p.title, p.state, p."heroImage" FROM "_External_relateds" t JOIN "Post" p ON p.id = t."B" WHERE t."A" = ANY($1) AND p.state = 'published'`
+	rows, err := r.db.QueryContext(ctx, query, pqIntArray(externalIDs))
+	if err != nil {
+		return result, imageIDs, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var eid int
+		var rp Post
+		var dbID int
+		var heroID sql.NullInt64
+		if err := rows.Scan(&eid, &dbID, &rp.Slug, &rp.Title, &rp.State, &heroID); err != nil {
+			return result, imageIDs, err
+		}
+		rp.ID = strconv.Itoa(dbID)
+		if heroID.Valid {
+			imageIDs = append(imageIDs, int(heroID.Int64))
+			rp.Metadata = map[string]any{"heroImageID": int(heroID.Int64)}
+		}
+		result[eid] = append(result[eid], rp)
+	}
+	return result, imageIDs, rows.Err()
+}
+
 func (r *Repo) fetchTopicTags(ctx context.Context, topicIDs []int) (map[int][]Tag, error) {
 	result := map[int][]Tag{}
 	if len(topicIDs) == 0 {
@@ -2016,7 +2619,7 @@ func (r *Repo) fetchTopicSlideshowImages(ctx context.Context, topicIDs []int) (m
 	if len(topicIDs) == 0 {
 		return result, imageIDs, nil
 	}
-	query := `SELECT t."A" as topic_id, im.id, COALESCE(im."imageFile_id", ''), COALESCE(im."imageFile_extension", ''), im."imageFile_width", im."imageFile_height", COALESCE(im.name, '') as name, COALESCE(im."topicKeywords", '') as topicKeywords FROM "Topic_slideshow_images" t JOIN "Image" im ON im.id = t."B" WHERE t."A" = ANY($1)`
+	query := `SELECT t."A" as topic_id, im.id, COALESCE(im."imageFile_id", ''), COALESCE(im."imageFile_extension", ''), im."imageFile_width", im."imageFile_height", COALESCE(im.name, '') as name, COALESCE(im."topicKeywords", '') as topicKeywords` + r.imageFormatSelect("im.") + ` FROM "Topic_slideshow_images" t JOIN "Image" im ON im.id = t."B" WHERE t."A" = ANY($1)`
 	rows, err := r.db.QueryContext(ctx, query, pqIntArray(topicIDs))
 	if err != nil {
 		return result, imageIDs, err
@@ -2033,7 +2636,9 @@ func (r *Repo) fetchTopicSlideshowImages(ctx context.Context, topicIDs []int) (m
 			name          string
 			topicKeywords string
 		}
-		if err := rows.Scan(&tid, &im.id, &im.fileID, &im.ext, &im.width, &im.height, &im.name, &im.topicKeywords); err != nil {
+		var formats imageFormats
+		dest := append([]any{&tid, &im.id, &im.fileID, &im.ext, &im.width, &im.height, &im.name, &im.topicKeywords}, r.imageFormatDest(&formats)...)
+		if err := rows.Scan(dest...); err != nil {
 			return result, imageIDs, err
 		}
 		imageIDs = append(imageIDs, im.id)
@@ -2046,8 +2651,7 @@ func (r *Repo) fetchTopicSlideshowImages(ctx context.Context, topicIDs []int) (m
 				Height: int(im.height.Int64),
 			},
 		}
-		photo.Resized = r.buildResizedURLs(im.fileID, im.ext)
-		photo.ResizedWebp = r.buildResizedURLs(im.fileID, "webp")
+		r.setResizedURLs(&photo, im.fileID, im.ext, formats)
 		result[tid] = append(result[tid], photo)
 	}
 	return result, imageIDs, rows.Err()
@@ -2061,6 +2665,51 @@ func pqIntArray(ids []int) interface{} {
 	return arr
 }
 
+// imageFormats 記錄 Image 的衍生格式是否存在
+type imageFormats struct {
+	webp bool
+	avif bool
+}
+
+// imageFormatSelect 回傳讀取格式欄位的 SELECT 片段，alias 為 table 的前綴（例如 "im."）；
+// NULL 視為不存在，改用原始格式較不會 404
+func (r *Repo) imageFormatSelect(alias string) string {
+	sb := strings.Builder{}
+	for _, format := range []string{"webp", "avif"} {
+		if col, ok := r.opts.ImageFormatColumns[format]; ok {
+			sb.WriteString(fmt.Sprintf(`, COALESCE(%s"%s", false)`, alias, col))
+		}
+	}
+	return sb.String()
+}
+
+// imageFormatDest 回傳與 imageFormatSelect 欄位對應的 scan 目標；未設定欄位的格式視為存在
+func (r *Repo) imageFormatDest(f *imageFormats) []any {
+	f.webp, f.avif = true, true
+	dest := []any{}
+	if _, ok := r.opts.ImageFormatColumns["webp"]; ok {
+		dest = append(dest, &f.webp)
+	}
+	if _, ok := r.opts.ImageFormatColumns["avif"]; ok {
+		dest = append(dest, &f.avif)
+	}
+	return dest
+}
+
+// setResizedURLs 填入 photo 各格式的縮圖 URL；webp 不存在時改用原始格式，avif 不存在時為 nil
+func (r *Repo) setResizedURLs(photo *Photo, fileID, ext string, formats imageFormats) {
+	photo.Resized = r.buildResizedURLs(fileID, ext)
+	if formats.webp {
+		photo.ResizedWebp = r.buildResizedURLs(fileID, "webp")
+	} else {
+		photo.ResizedWebp = photo.Resized
+	}
+	if r.opts.AvifImages && formats.avif && fileID != "" {
+		avif := r.buildResizedURLs(fileID, "avif")
+		photo.ResizedAvif = &avif
+	}
+}
+
 func (r *Repo) buildResizedURLs(fileID, ext string) Resized {
 	if fileID == "" {
 		return Resized{}
@@ -0,0 +1,113 @@
+package data
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// likeEscaper 跳脫 LIKE 的萬用字元，讓使用者輸入只做字面比對
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// QueryTagSuggestions returns up to take tags whose name contains prefix
+// (case-insensitive), names starting with prefix first and shorter names
+// before longer ones. The LIKE pattern can use a pg_trgm index on
+// "Tag".name; results are cached like other queries.
+func (r *Repo) QueryTagSuggestions(ctx context.Context, prefix string, take int) ([]Tag, error) {
+	ctx = withOp(ctx, "tag_suggest")
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" || take <= 0 {
+		return []Tag{}, nil
+	}
+	if r.mock != nil {
+		return r.mock.tagSuggestions(prefix, take), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
+	defer cancel()
+
+	// 嘗試從 cache 讀取；prefix 不分大小寫，以小寫作為 key
+	cacheKey := GenerateCacheKey("tagSuggest", map[string]interface{}{
+		"prefix": strings.ToLower(prefix),
+		"take":   take,
+	})
+	if r.cache != nil && r.cache.Enabled() {
+		var cached []Tag
+		if found, _ := r.cache.Get(ctx, cacheKey, &cached); found {
+			return cached, nil
+		}
+	}
+
+	escaped := likeEscaper.Replace(prefix)
+	rows, err := r.db.QueryContext(ctx, `SELECT id, COALESCE(name, ''), COALESCE(slug, '') FROM "Tag"
+WHERE name ILIKE $1
+ORDER BY name ILIKE $2 DESC, length(name), name
+LIMIT $3`, "%"+escaped+"%", escaped+"%", take)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Tag{}
+	for rows.Next() {
+		var (
+			t    Tag
+			dbID int
+		)
+		if err := rows.Scan(&dbID, &t.Name, &t.Slug); err != nil {
+			return nil, err
+		}
+		t.ID = strconv.Itoa(dbID)
+		result = append(result, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// 寫入 cache
+	if r.cache != nil && r.cache.Enabled() {
+		_ = r.cache.Set(ctx, cacheKey, result)
+	}
+	return result, nil
+}
+
+// QueryContacts returns up to take contacts (writers, photographers, ...)
+// whose name contains q, ignoring case and spaces, so "王 小明" also finds
+// "王小明". Exact and prefix matches come first. Results are not cached, so
+// newly added contacts can be linked immediately.
+func (r *Repo) QueryContacts(ctx context.Context, q string, take int) ([]Contact, error) {
+	ctx = withOp(ctx, "contact_search")
+	q = strings.Join(strings.Fields(q), "")
+	if q == "" || take <= 0 {
+		return []Contact{}, nil
+	}
+	if r.mock != nil {
+		return r.mock.searchContacts(q, take), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
+	defer cancel()
+
+	escaped := likeEscaper.Replace(q)
+	rows, err := r.db.QueryContext(ctx, `SELECT id, COALESCE(name, '') FROM "Contact" c
+WHERE replace(c.name, ' ', '') ILIKE $1
+ORDER BY replace(c.name, ' ', '') ILIKE $2 DESC, replace(c.name, ' ', '') ILIKE $3 DESC, length(c.name), c.name, c.id
+LIMIT $4`, "%"+escaped+"%", escaped, escaped+"%", take)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Contact{}
+	for rows.Next() {
+		var (
+			c    Contact
+			dbID int
+		)
+		if err := rows.Scan(&dbID, &c.Name); err != nil {
+			return nil, err
+		}
+		c.ID = strconv.Itoa(dbID)
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
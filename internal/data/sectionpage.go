@@ -0,0 +1,167 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SectionPageItem is one entry of a section stream: either a post or a partner external.
+type SectionPageItem struct {
+	Kind          string    `json:"kind"`
+	Post          *Post     `json:"post,omitempty"`
+	External      *External `json:"external,omitempty"`
+	PublishedDate string    `json:"publishedDate"`
+}
+
+// SectionPage bundles what the section page renders.
+type SectionPage struct {
+	Section    Section           `json:"section"`
+	Categories []Category        `json:"categories"`
+	Items      []SectionPageItem `json:"items"`
+}
+
+// QuerySectionPage returns an active section, its active categories and one
+// page of its published posts merged with the published externals of
+// partners, newest first. The page is picked with a single UNION query and
+// loaded with one QueryPosts and one QueryExternals call. It returns nil when
+// the section does not exist or is inactive.
+func (r *Repo) QuerySectionPage(ctx context.Context, slug string, partners []string, take, skip int) (*SectionPage, error) {
+	ctx = withOp(ctx, "section_page")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(15*time.Second))
+	defer cancel()
+	if r.mock != nil {
+		return r.mock.sectionPage(slug, partners, take, skip), nil
+	}
+
+	var (
+		page      SectionPage
+		sectionID int
+	)
+	err := r.db.QueryRowContext(ctx, `SELECT id, name, slug, state FROM "Section" WHERE slug = $1 AND state = 'active'`, slug).
+		Scan(&sectionID, &page.Section.Name, &page.Section.Slug, &page.Section.State)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	page.Section.ID = strconv.Itoa(sectionID)
+
+	categories, err := r.fetchSectionCategories(ctx, sectionID)
+	if err == nil {
+		err = r.attachCategorySections(ctx, categories)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("section categories: %w", err)
+	}
+	page.Categories = categories
+
+	// 文章以 id、外稿以 slug 取得，兩者依發布時間合併後分頁
+	query := `SELECT kind, ref FROM (
+		SELECT 'post' AS kind, p.id::text AS ref, p.id AS id, p."publishedDate" AS published
+		FROM "Post" p JOIN "_Post_sections" ps ON ps."A" = p.id
+		WHERE ps."B" = $1 AND p.state = 'published'
+		UNION ALL
+		SELECT 'external', e.slug, e.id, e."publishedDate"
+		FROM "External" e JOIN "Partner" pa ON pa.id = e.partner
+		WHERE pa.slug = ANY($2) AND e.state = 'published'
+	) stream ORDER BY published DESC NULLS LAST, kind DESC, id DESC`
+	if take >= 0 {
+		query += fmt.Sprintf(" LIMIT %d", take)
+	}
+	if skip > 0 {
+		query += fmt.Sprintf(" OFFSET %d", skip)
+	}
+	if partners == nil {
+		partners = []string{}
+	}
+	rows, err := r.db.QueryContext(ctx, query, sectionID, partners)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type streamRef struct{ kind, ref string }
+	refs := []streamRef{}
+	postIDs, externalSlugs := []string{}, []string{}
+	for rows.Next() {
+		var ref streamRef
+		if err := rows.Scan(&ref.kind, &ref.ref); err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+		if ref.kind == ChangeKindPost {
+			postIDs = append(postIDs, ref.ref)
+		} else {
+			externalSlugs = append(externalSlugs, ref.ref)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	postsByID := map[string]Post{}
+	if len(postIDs) > 0 {
+		posts, err := r.QueryPosts(ctx, &PostWhereInput{ID: &IDFilter{In: postIDs}}, nil, -1, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range posts {
+			postsByID[p.ID] = p
+		}
+	}
+	externalsBySlug := map[string]External{}
+	if len(externalSlugs) > 0 {
+		externals, err := r.QueryExternals(ctx, &ExternalWhereInput{Slug: &StringFilter{In: externalSlugs}}, nil, -1, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range externals {
+			externalsBySlug[e.Slug] = e
+		}
+	}
+
+	page.Items = make([]SectionPageItem, 0, len(refs))
+	for _, ref := range refs {
+		if ref.kind == ChangeKindPost {
+			if p, ok := postsByID[ref.ref]; ok {
+				page.Items = append(page.Items, SectionPageItem{Kind: ChangeKindPost, Post: &p, PublishedDate: p.PublishedDate})
+			}
+			continue
+		}
+		if e, ok := externalsBySlug[ref.ref]; ok {
+			page.Items = append(page.Items, SectionPageItem{Kind: ChangeKindExternal, External: &e, PublishedDate: e.PublishedDate})
+		}
+	}
+	return &page, nil
+}
+
+// fetchSectionCategories 取得 section 底下啟用中的 category，依 id 排序
+func (r *Repo) fetchSectionCategories(ctx context.Context, sectionID int) ([]Category, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT c.id, c.name, c.slug, c.state, c."isMemberOnly"
+FROM "Category" c JOIN "_Category_sections" cs ON cs."A" = c.id
+WHERE cs."B" = $1 AND c.state = 'active'
+ORDER BY c.id`, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Category{}
+	for rows.Next() {
+		var (
+			c    Category
+			dbID int
+		)
+		if err := rows.Scan(&dbID, &c.Name, &c.Slug, &c.State, &c.IsMemberOnly); err != nil {
+			return nil, err
+		}
+		c.ID = strconv.Itoa(dbID)
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
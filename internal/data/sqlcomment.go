@@ -0,0 +1,86 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+type sqlTraceKey struct{}
+
+// sqlTrace 是附加在 SQL 註解中的追蹤資訊
+type sqlTrace struct {
+	Op        string
+	RequestID string
+}
+
+// WithRequestID attaches the request id that is written into SQL trace
+// comments of every query made with ctx.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	t, _ := ctx.Value(sqlTraceKey{}).(sqlTrace)
+	t.RequestID = id
+	return context.WithValue(ctx, sqlTraceKey{}, t)
+}
+
+// withOp 標記目前的 repo 操作（例如 posts_list）。已有 op 時保留外層的值，
+// 因此 homepage 等組合查詢與關聯組裝的子查詢都會標記為最外層的操作
+func withOp(ctx context.Context, op string) context.Context {
+	t, _ := ctx.Value(sqlTraceKey{}).(sqlTrace)
+	if t.Op != "" {
+		return ctx
+	}
+	t.Op = op
+	return context.WithValue(ctx, sqlTraceKey{}, t)
+}
+
+// tracedDB 在啟用時於每個 SQL 後面加上 /*op=...,req=...*/，
+// 讓 DBA 能在 pg_stat_activity 對應到 GraphQL 操作與 request；
+// 同時統計查詢數與延遲（見 dbLoadTracker）
+type tracedDB struct {
+	*sql.DB
+	enabled bool
+	load    dbLoadTracker
+}
+
+func (d *tracedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	defer d.load.begin()()
+	return d.DB.QueryContext(ctx, d.annotate(ctx, query), args...)
+}
+
+func (d *tracedDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	defer d.load.begin()()
+	return d.DB.QueryRowContext(ctx, d.annotate(ctx, query), args...)
+}
+
+func (d *tracedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer d.load.begin()()
+	return d.DB.ExecContext(ctx, d.annotate(ctx, query), args...)
+}
+
+func (d *tracedDB) annotate(ctx context.Context, query string) string {
+	if !d.enabled {
+		return query
+	}
+	t, _ := ctx.Value(sqlTraceKey{}).(sqlTrace)
+	parts := []string{}
+	if op := commentValue(t.Op); op != "" {
+		parts = append(parts, "op="+op)
+	}
+	if req := commentValue(t.RequestID); req != "" {
+		parts = append(parts, "req="+req)
+	}
+	if len(parts) == 0 {
+		return query
+	}
+	return query + " /*" + strings.Join(parts, ",") + "*/"
+}
+
+// commentValue 只保留英數與 -_.: 字元，避免外部帶入的 request id 提前結束註解
+func commentValue(v string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' || r == ':' {
+			return r
+		}
+		return -1
+	}, v)
+}
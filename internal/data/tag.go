@@ -0,0 +1,76 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TagWhereUniqueInput selects one tag by id or slug.
+type TagWhereUniqueInput struct {
+	ID   *string `mapstructure:"id"`
+	Slug *string `mapstructure:"slug"`
+}
+
+func DecodeTagWhereUnique(input interface{}) (*TagWhereUniqueInput, error) {
+	if input == nil {
+		return nil, nil
+	}
+	var where TagWhereUniqueInput
+	if err := decodeInto(input, &where); err != nil {
+		return nil, fmt.Errorf("tag unique where: %w", err)
+	}
+	return &where, nil
+}
+
+// QueryTag returns the tag matching where, or nil when none does.
+func (r *Repo) QueryTag(ctx context.Context, where *TagWhereUniqueInput) (*Tag, error) {
+	ctx = withOp(ctx, "tag")
+	if where == nil || (where.ID == nil && where.Slug == nil) {
+		return nil, nil
+	}
+	if r.mock != nil {
+		return r.mock.tagByUnique(where), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
+	defer cancel()
+
+	var (
+		t    Tag
+		dbID int
+		row  *sql.Row
+	)
+	if where.ID != nil {
+		ids, err := parseIDs([]string{*where.ID})
+		if err != nil {
+			return nil, err
+		}
+		row = r.db.QueryRowContext(ctx, `SELECT id, COALESCE(name, ''), COALESCE(slug, '') FROM "Tag" WHERE id = $1`, ids[0])
+	} else {
+		row = r.db.QueryRowContext(ctx, `SELECT id, COALESCE(name, ''), COALESCE(slug, '') FROM "Tag" WHERE slug = $1 ORDER BY id LIMIT 1`, *where.Slug)
+	}
+	if err := row.Scan(&dbID, &t.Name, &t.Slug); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	t.ID = strconv.Itoa(dbID)
+	return &t, nil
+}
+
+// QueryTagPosts returns, for each of tagIDs, the page of posts tagged with it
+// that match where, in a single query over "_Post_tags". Each tag is paged
+// separately with take and skip; tags without posts map to an empty list.
+func (r *Repo) QueryTagPosts(ctx context.Context, tagIDs []string, where *PostWhereInput, orders []OrderRule, take, skip int) (map[string][]Post, error) {
+	return r.queryRelatedPosts(withOp(ctx, "tag_posts"), postTagsRelation, tagIDs, where, orders, take, skip)
+}
+
+// QueryTagPostsCount counts the posts matching where for each of tagIDs in a
+// single GROUP BY over "_Post_tags".
+func (r *Repo) QueryTagPostsCount(ctx context.Context, tagIDs []string, where *PostWhereInput) (map[string]int, error) {
+	return r.queryRelatedPostsCount(withOp(ctx, "tag_posts_count"), postTagsRelation, tagIDs, where)
+}
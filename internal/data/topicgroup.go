@@ -0,0 +1,86 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+)
+
+// relatedTopicSelect 是 parentTopic / subtopics 載入的欄位；關聯的 topic 只展開一層，
+// 不再載入其 tags、slideshow 與下一層關聯，避免 CMS 設定成環狀時無限展開
+const relatedTopicSelect = `SELECT id, name, slug, "sortOrder", state, brief, "heroImage", "heroUrl", type, style, "parentTopic" FROM "Topic" `
+
+// fetchParentTopics 依 id 取得已發布的上層 topic
+func (r *Repo) fetchParentTopics(ctx context.Context, ids []int) (map[int]Topic, error) {
+	result := map[int]Topic{}
+	if len(ids) == 0 {
+		return result, nil
+	}
+	topics, err := r.fetchRelatedTopics(ctx, `WHERE id = ANY($1) AND state = 'published'`, ids)
+	if err != nil {
+		return result, err
+	}
+	for _, t := range topics {
+		id, _ := strconv.Atoi(t.ID)
+		result[id] = t
+	}
+	return result, nil
+}
+
+// fetchSubtopics 取得各 topic 底下已發布的子 topic，依 sortOrder 排序
+func (r *Repo) fetchSubtopics(ctx context.Context, parentIDs []int) (map[int][]Topic, error) {
+	result := map[int][]Topic{}
+	if len(parentIDs) == 0 {
+		return result, nil
+	}
+	topics, err := r.fetchRelatedTopics(ctx, `WHERE "parentTopic" = ANY($1) AND state = 'published' ORDER BY "sortOrder" ASC NULLS LAST, id ASC`, parentIDs)
+	if err != nil {
+		return result, err
+	}
+	for _, t := range topics {
+		parentID := getMetaInt(t.Metadata, "parentTopicID")
+		result[parentID] = append(result[parentID], t)
+	}
+	return result, nil
+}
+
+func (r *Repo) fetchRelatedTopics(ctx context.Context, cond string, ids []int) ([]Topic, error) {
+	rows, err := r.db.QueryContext(ctx, relatedTopicSelect+cond, pqIntArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	topics := []Topic{}
+	for rows.Next() {
+		var (
+			t           Topic
+			dbID        int
+			sortOrder   sql.NullInt64
+			briefRaw    []byte
+			heroImageID sql.NullInt64
+			heroURL     sql.NullString
+			typeVal     sql.NullString
+			styleVal    sql.NullString
+			parentID    sql.NullInt64
+		)
+		if err := rows.Scan(&dbID, &t.Name, &t.Slug, &sortOrder, &t.State, &briefRaw, &heroImageID, &heroURL, &typeVal, &styleVal, &parentID); err != nil {
+			return nil, err
+		}
+		t.ID = strconv.Itoa(dbID)
+		if sortOrder.Valid {
+			val := int(sortOrder.Int64)
+			t.SortOrder = &val
+		}
+		t.Brief = decodeJSONBytes(briefRaw)
+		t.HeroURL = heroURL.String
+		t.Type = typeVal.String
+		t.Style = styleVal.String
+		t.Metadata = map[string]any{
+			"heroImageID":   nullableInt(heroImageID),
+			"parentTopicID": nullableInt(parentID),
+		}
+		topics = append(topics, t)
+	}
+	return topics, rows.Err()
+}
@@ -0,0 +1,211 @@
+package data
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// viewsPendingKey 尚未寫入 DB 的瀏覽次數（hash，field 為 post id）
+	viewsPendingKey = "views:pending"
+	// viewsTotalPrefix DB 中累計瀏覽次數的 read-through 快取，key 為 views:total:<post id>
+	viewsTotalPrefix = "views:total"
+	// viewsTotalTTL 累計次數快取的保留時間，flush 時會重設；沒有瀏覽的文章過期後不再佔用 Redis
+	viewsTotalTTL = time.Hour
+	// viewsFlushingTTL flush 中途 crash 時，暫存 key 的保留時間
+	viewsFlushingTTL = 24 * time.Hour
+)
+
+// upsertPostViewsSQL 累加瀏覽次數；JOIN "Post" 過濾不存在的 id，避免整批寫入失敗
+const upsertPostViewsSQL = `INSERT INTO "PostViews" (post, views, "updatedAt")
+SELECT v.post, v.views, now() FROM unnest($1::int[], $2::bigint[]) AS v(post, views)
+JOIN "Post" p ON p.id = v.post
+ON CONFLICT (post) DO UPDATE SET views = "PostViews".views + EXCLUDED.views, "updatedAt" = EXCLUDED."updatedAt"
+RETURNING post, views`
+
+// RecordPostView counts one view of the post with id and reports whether it
+// was counted; views of posts that don't exist or aren't published are not.
+// With the cache enabled the view is buffered in Redis until the next
+// FlushPostViews; otherwise it is written to the PostViews table directly.
+func (r *Repo) RecordPostView(ctx context.Context, id string) (bool, error) {
+	postID, err := strconv.Atoi(id)
+	if err != nil || postID <= 0 {
+		return false, fmt.Errorf("invalid post id %q", id)
+	}
+	// 文章頁剛查過同一篇文章，通常會命中 post:unique 的 cache；只有已發布的文章才計入，
+	// 避免任意 id 灌入 views:pending
+	post, err := r.QueryPostByUnique(ctx, &PostWhereUniqueInput{ID: &id})
+	if err != nil {
+		return false, fmt.Errorf("record post view: %w", err)
+	}
+	if post == nil || post.State != "published" {
+		return false, nil
+	}
+	if r.mock != nil {
+		r.mock.recordView(id)
+		return true, nil
+	}
+	if r.cache != nil && r.cache.Enabled() {
+		if err := r.cache.client.HIncrBy(ctx, r.cache.key(viewsPendingKey), id, 1).Err(); err == nil {
+			return true, nil
+		}
+		// Redis 失敗時改為直接寫入 DB
+	}
+	ctx = withOp(ctx, "post_views_record")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
+	defer cancel()
+	rows, err := r.db.QueryContext(ctx, upsertPostViewsSQL, []int64{int64(postID)}, []int64{1})
+	if err != nil {
+		return false, fmt.Errorf("record post view: %w", err)
+	}
+	return true, rows.Close()
+}
+
+// PostViews returns the views of the post with id stored in the PostViews
+// table; views still buffered in Redis are not included.
+func (r *Repo) PostViews(ctx context.Context, id string) (int, error) {
+	if r.mock != nil {
+		return r.mock.postViews(id), nil
+	}
+	if r.cache != nil && r.cache.Enabled() {
+		if v, err := r.cache.client.Get(ctx, r.cache.key(viewsTotalPrefix+":"+id)).Int(); err == nil {
+			return v, nil
+		}
+	}
+	ctx = withOp(ctx, "post_views")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(5*time.Second))
+	defer cancel()
+	var views int
+	err := r.db.QueryRowContext(ctx, `SELECT views FROM "PostViews" WHERE post = $1`, id).Scan(&views)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("query post views: %w", err)
+	}
+	if r.cache != nil && r.cache.Enabled() {
+		_ = r.cache.client.Set(ctx, r.cache.key(viewsTotalPrefix+":"+id), views, viewsTotalTTL).Err()
+	}
+	return views, nil
+}
+
+// FlushPostViews moves the views buffered in Redis into the PostViews table
+// and returns the number of posts updated. Several instances may flush at
+// once; each buffered view is written by exactly one of them.
+func (r *Repo) FlushPostViews(ctx context.Context) (int, error) {
+	if r.cache == nil || !r.cache.Enabled() {
+		return 0, nil
+	}
+	client := r.cache.client
+
+	// 先 RENAME 再讀取，flush 期間的新瀏覽會累加到新的 pending hash
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	pendingKey := r.cache.key(viewsPendingKey)
+	flushing := r.cache.key("views:flushing:" + hex.EncodeToString(buf))
+	if err := client.Rename(ctx, pendingKey, flushing).Err(); err != nil {
+		if strings.Contains(err.Error(), "no such key") {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("rename pending views: %w", err)
+	}
+	_ = client.Expire(ctx, flushing, viewsFlushingTTL).Err()
+
+	pending, err := client.HGetAll(ctx, flushing).Result()
+	if err != nil {
+		return 0, fmt.Errorf("read pending views: %w", err)
+	}
+	ids := make([]int64, 0, len(pending))
+	counts := make([]int64, 0, len(pending))
+	for field, value := range pending {
+		id, err1 := strconv.ParseInt(field, 10, 64)
+		n, err2 := strconv.ParseInt(value, 10, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		ids = append(ids, id)
+		counts = append(counts, n)
+	}
+
+	totals, err := r.upsertPostViews(ctx, ids, counts)
+	if err != nil {
+		// 寫入失敗時將次數加回 pending，下次 flush 重試
+		pipe := client.Pipeline()
+		for i, id := range ids {
+			pipe.HIncrBy(ctx, pendingKey, strconv.FormatInt(id, 10), counts[i])
+		}
+		pipe.Del(ctx, flushing)
+		if _, perr := pipe.Exec(ctx); perr != nil {
+			log.Printf("[Views] Failed to restore %d pending posts: %v", len(ids), perr)
+		}
+		return 0, err
+	}
+
+	pipe := client.Pipeline()
+	for id, views := range totals {
+		pipe.Set(ctx, r.cache.key(viewsTotalPrefix+":"+id), views, viewsTotalTTL)
+	}
+	pipe.Del(ctx, flushing)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("[Views] Failed to update cached totals: %v", err)
+	}
+	return len(totals), nil
+}
+
+// upsertPostViews 累加瀏覽次數，回傳更新後的累計值（post id → views）
+func (r *Repo) upsertPostViews(ctx context.Context, ids, counts []int64) (map[string]int64, error) {
+	ctx = withOp(ctx, "post_views_flush")
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(30*time.Second))
+	defer cancel()
+	rows, err := r.db.QueryContext(ctx, upsertPostViewsSQL, ids, counts)
+	if err != nil {
+		return nil, fmt.Errorf("flush post views: %w", err)
+	}
+	defer rows.Close()
+	totals := make(map[string]int64, len(ids))
+	for rows.Next() {
+		var id, views int64
+		if err := rows.Scan(&id, &views); err != nil {
+			return nil, fmt.Errorf("scan post views: %w", err)
+		}
+		totals[strconv.FormatInt(id, 10)] = views
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("flush post views: %w", err)
+	}
+	return totals, nil
+}
+
+// ViewFlusher periodically writes the views buffered by RecordPostView to
+// the PostViews table.
+type ViewFlusher struct {
+	Repo     *Repo
+	Interval time.Duration
+}
+
+// Start begins flushing every Interval until ctx is done.
+func (f *ViewFlusher) Start(ctx context.Context) {
+	if f.Interval <= 0 {
+		return
+	}
+	log.Printf("[Views] Flushing post views every %v", f.Interval)
+	go func() {
+		ticker := time.NewTicker(f.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := f.Repo.FlushPostViews(ctx); err != nil {
+					log.Printf("[Views] %v", err)
+				}
+			}
+		}
+	}()
+}
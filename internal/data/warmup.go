@@ -0,0 +1,32 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// WarmPool opens n connections at once and runs a trivial query on each, so
+// TLS and authentication are done before the first user request. The
+// connections stay in the pool as idle connections (up to MaxIdleConns).
+func WarmPool(ctx context.Context, db *sql.DB, n int) error {
+	// 同時持有 n 條連線，避免 database/sql 重複使用同一條 idle 連線
+	conns := make([]*sql.Conn, 0, n)
+	defer func() {
+		for _, c := range conns {
+			_ = c.Close()
+		}
+	}()
+	for i := 0; i < n; i++ {
+		c, err := db.Conn(ctx)
+		if err != nil {
+			return fmt.Errorf("open connection %d/%d: %w", i+1, n, err)
+		}
+		conns = append(conns, c)
+		var one int
+		if err := c.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+			return fmt.Errorf("warm connection %d/%d: %w", i+1, n, err)
+		}
+	}
+	return nil
+}
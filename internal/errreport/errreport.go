@@ -0,0 +1,109 @@
+// Package errreport reports errors to GCP Error Reporting through structured
+// logs, so no client library or credentials are needed on Cloud Run.
+package errreport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go-story/internal/tracecontext"
+)
+
+// reportedErrorEventType 讓 Cloud Logging 將這筆 log 轉給 Error Reporting
+const reportedErrorEventType = "type.googleapis.com/google.devtools.clouderrorreporting.v1beta1.ReportedErrorEvent"
+
+// Reporter writes error events as JSON lines. A nil Reporter discards
+// everything, so callers do not need to check whether reporting is enabled.
+type Reporter struct {
+	service string
+	version string
+	// project 為 GCP project id，設定時 trace 以 Cloud Logging 的 trace 欄位輸出
+	project string
+
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// New creates a Reporter writing to stderr. project (which may be empty) is
+// the GCP project the request traces belong to.
+func New(service, version, project string) *Reporter {
+	return &Reporter{service: service, version: version, project: project, out: os.Stderr}
+}
+
+// RequestInfo identifies the request an error happened in.
+type RequestInfo struct {
+	RequestID     string
+	OperationName string
+	Method        string
+	URL           string
+	UserAgent     string
+}
+
+type requestInfoKey struct{}
+
+// WithRequest attaches request info to ctx for later reports.
+func WithRequest(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestFromContext returns the request info attached by WithRequest.
+func RequestFromContext(ctx context.Context) (RequestInfo, bool) {
+	if ctx == nil {
+		return RequestInfo{}, false
+	}
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
+
+// Report sends err with the request info found in ctx. stack is appended to
+// the message when given (e.g. from a recovered panic), which lets Error
+// Reporting group events by stack. Canceled requests are not reported.
+func (r *Reporter) Report(ctx context.Context, err error, stack []byte) {
+	if r == nil || err == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+
+	message := err.Error()
+	if len(stack) > 0 {
+		message += "\n\n" + string(stack)
+	}
+	event := map[string]any{
+		"severity":  "ERROR",
+		"@type":     reportedErrorEventType,
+		"message":   message,
+		"eventTime": time.Now().UTC().Format(time.RFC3339Nano),
+		"serviceContext": map[string]string{
+			"service": r.service,
+			"version": r.version,
+		},
+	}
+	if info, ok := RequestFromContext(ctx); ok {
+		event["context"] = map[string]any{
+			"httpRequest": map[string]string{
+				"method":    info.Method,
+				"url":       info.URL,
+				"userAgent": info.UserAgent,
+			},
+		}
+		event["logging.googleapis.com/labels"] = map[string]string{
+			"operationName": info.OperationName,
+			"requestId":     info.RequestID,
+		}
+	}
+	for key, value := range tracecontext.LogFields(ctx, r.project) {
+		event[key] = value
+	}
+
+	line, mErr := json.Marshal(event)
+	if mErr != nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, _ = r.out.Write(append(line, '\n'))
+}
@@ -0,0 +1,61 @@
+// Package gcpauth fetches access tokens of the instance's service account
+// from the GCE metadata server for the clients that call Google APIs over
+// REST (Secret Manager, Cloud Storage, Pub/Sub). Tokens are cached and
+// shared until shortly before they expire.
+package gcpauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const metadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// refreshMargin 在 token 到期前多久重新取得，避免請求途中過期
+const refreshMargin = 5 * time.Minute
+
+var (
+	client = &http.Client{Timeout: 10 * time.Second}
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+)
+
+// AccessToken returns an access token of the default service account,
+// reusing the cached one until it is about to expire.
+func AccessToken(ctx context.Context) (string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if token != "" && time.Until(expiry) > refreshMargin {
+		return token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataTokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch metadata token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetch metadata token: status %d", resp.StatusCode)
+	}
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode metadata token: %w", err)
+	}
+	token = body.AccessToken
+	expiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	return token, nil
+}
@@ -0,0 +1,245 @@
+package grpcapi
+
+import (
+	"encoding/json"
+
+	"go-story/internal/data"
+	"go-story/internal/storypb"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func toPost(p *data.Post) *storypb.Post {
+	if p == nil {
+		return nil
+	}
+	out := &storypb.Post{
+		Id:               p.ID,
+		Slug:             p.Slug,
+		Title:            p.Title,
+		Subtitle:         p.Subtitle,
+		State:            p.State,
+		Style:            p.Style,
+		PublishedDate:    p.PublishedDate,
+		UpdatedAt:        p.UpdatedAt,
+		IsMember:         p.IsMember,
+		IsAdult:          p.IsAdult,
+		Sections:         toSections(p.Sections),
+		Categories:       toCategories(p.Categories),
+		Writers:          toContacts(p.Writers),
+		Photographers:    toContacts(p.Photographers),
+		CameraMan:        toContacts(p.CameraMan),
+		Designers:        toContacts(p.Designers),
+		Engineers:        toContacts(p.Engineers),
+		Vocals:           toContacts(p.Vocals),
+		ExtendByline:     p.ExtendByline,
+		Tags:             toTags(p.Tags),
+		TagsAlgo:         toTags(p.TagsAlgo),
+		HeroVideo:        toVideo(p.HeroVideo),
+		HeroAudio:        toAudio(p.HeroAudio),
+		Audio:            toAudio(p.Audio),
+		HeroImage:        toPhoto(p.HeroImage),
+		HeroCaption:      p.HeroCaption,
+		Brief:            toStruct(p.Brief),
+		TrimmedContent:   toStruct(p.TrimmedContent),
+		Content:          toStruct(p.Content),
+		Redirect:         p.Redirect,
+		OgTitle:          p.OgTitle,
+		OgImage:          toPhoto(p.OgImage),
+		OgDescription:    p.OgDescription,
+		HiddenAdvertised: p.HiddenAdvertised,
+		IsAdvertised:     p.IsAdvertised,
+		IsFeatured:       p.IsFeatured,
+		ReadingTime:      int32(p.ReadingTime),
+		WordCount:        int32(p.WordCount),
+	}
+	if p.Topics != nil {
+		out.TopicId = p.Topics.ID
+	}
+	for i := range p.Relateds {
+		out.Relateds = append(out.Relateds, toPost(&p.Relateds[i]))
+	}
+	return out
+}
+
+func toTopic(t *data.Topic) *storypb.Topic {
+	out := &storypb.Topic{
+		Id:            t.ID,
+		Name:          t.Name,
+		Slug:          t.Slug,
+		State:         t.State,
+		Brief:         toStruct(t.Brief),
+		HeroImage:     toPhoto(t.HeroImage),
+		HeroUrl:       t.HeroURL,
+		Leading:       t.Leading,
+		OgTitle:       t.OgTitle,
+		OgDescription: t.OgDescription,
+		OgImage:       toPhoto(t.OgImage),
+		IsFeatured:    t.IsFeatured,
+		TitleStyle:    t.TitleStyle,
+		Type:          t.Type,
+		Style:         t.Style,
+		Tags:          toTags(t.Tags),
+		CreatedAt:     t.CreatedAt,
+		UpdatedAt:     t.UpdatedAt,
+	}
+	if t.SortOrder != nil {
+		v := int32(*t.SortOrder)
+		out.SortOrder = &v
+	}
+	for i := range t.SlideshowImages {
+		out.SlideshowImages = append(out.SlideshowImages, toPhoto(&t.SlideshowImages[i]))
+	}
+	return out
+}
+
+func toExternal(e *data.External) *storypb.External {
+	out := &storypb.External{
+		Id:            e.ID,
+		Slug:          e.Slug,
+		Title:         e.Title,
+		State:         e.State,
+		PublishedDate: e.PublishedDate,
+		ExtendByline:  e.ExtendByline,
+		Thumb:         e.Thumb,
+		ThumbCaption:  e.ThumbCaption,
+		Brief:         e.Brief,
+		Content:       e.Content,
+		UpdatedAt:     e.UpdatedAt,
+		Tags:          toTags(e.Tags),
+	}
+	if p := e.Partner; p != nil {
+		out.Partner = &storypb.Partner{
+			Id:          p.ID,
+			Slug:        p.Slug,
+			Name:        p.Name,
+			ShowOnIndex: p.ShowOnIndex,
+			ShowThumb:   p.ShowThumb,
+			ShowBrief:   p.ShowBrief,
+		}
+	}
+	for i := range e.Relateds {
+		out.Relateds = append(out.Relateds, toPost(&e.Relateds[i]))
+	}
+	return out
+}
+
+func toPhoto(p *data.Photo) *storypb.Photo {
+	if p == nil {
+		return nil
+	}
+	out := &storypb.Photo{
+		Id:            p.ID,
+		Name:          p.Name,
+		TopicKeywords: p.TopicKeywords,
+		ImageFile:     &storypb.ImageFile{Width: int32(p.ImageFile.Width), Height: int32(p.ImageFile.Height)},
+		Resized:       toResized(p.Resized),
+		ResizedWebp:   toResized(p.ResizedWebp),
+	}
+	if p.ResizedAvif != nil {
+		out.ResizedAvif = toResized(*p.ResizedAvif)
+	}
+	return out
+}
+
+func toResized(r data.Resized) *storypb.Resized {
+	return &storypb.Resized{
+		Original: r.Original,
+		W480:     r.W480,
+		W800:     r.W800,
+		W1200:    r.W1200,
+		W1600:    r.W1600,
+		W2400:    r.W2400,
+	}
+}
+
+func toSections(in []data.Section) []*storypb.Section {
+	out := make([]*storypb.Section, 0, len(in))
+	for _, s := range in {
+		out = append(out, &storypb.Section{Id: s.ID, Name: s.Name, Slug: s.Slug, State: s.State})
+	}
+	return out
+}
+
+func toCategories(in []data.Category) []*storypb.Category {
+	out := make([]*storypb.Category, 0, len(in))
+	for _, c := range in {
+		out = append(out, &storypb.Category{
+			Id:           c.ID,
+			Name:         c.Name,
+			Slug:         c.Slug,
+			State:        c.State,
+			IsMemberOnly: c.IsMemberOnly,
+			Sections:     toSections(c.Sections),
+		})
+	}
+	return out
+}
+
+func toContacts(in []data.Contact) []*storypb.Contact {
+	out := make([]*storypb.Contact, 0, len(in))
+	for _, c := range in {
+		out = append(out, &storypb.Contact{Id: c.ID, Name: c.Name})
+	}
+	return out
+}
+
+func toTags(in []data.Tag) []*storypb.Tag {
+	out := make([]*storypb.Tag, 0, len(in))
+	for _, t := range in {
+		out = append(out, &storypb.Tag{Id: t.ID, Name: t.Name, Slug: t.Slug})
+	}
+	return out
+}
+
+func toVideo(v *data.Video) *storypb.Video {
+	if v == nil {
+		return nil
+	}
+	return &storypb.Video{
+		Id:        v.ID,
+		Name:      v.Name,
+		State:     v.State,
+		VideoSrc:  v.VideoSrc,
+		Mp4Src:    v.Mp4Src,
+		HlsSrc:    v.HlsSrc,
+		Duration:  int32(v.Duration),
+		HeroImage: toPhoto(v.HeroImage),
+	}
+}
+
+func toAudio(a *data.Audio) *storypb.Audio {
+	if a == nil {
+		return nil
+	}
+	return &storypb.Audio{
+		Id:        a.ID,
+		Name:      a.Name,
+		File:      &storypb.AudioFile{Filename: a.File.Filename, Filesize: int32(a.File.Filesize), Url: a.File.URL},
+		HeroImage: toPhoto(a.HeroImage),
+	}
+}
+
+// toStruct 轉換 Draft.js 等 JSON 欄位；structpb 只接受 JSON 解碼後的型別，
+// 遇到其他型別（例如組裝時產生的 []map[string]any）先以 JSON 轉一次
+func toStruct(m map[string]any) *structpb.Struct {
+	if m == nil {
+		return nil
+	}
+	if s, err := structpb.NewStruct(m); err == nil {
+		return s
+	}
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil
+	}
+	s, err := structpb.NewStruct(decoded)
+	if err != nil {
+		return nil
+	}
+	return s
+}
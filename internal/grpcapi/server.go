@@ -0,0 +1,202 @@
+// Package grpcapi serves the core read operations of the repo over gRPC
+// (see proto/story/v1/story.proto).
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"go-story/internal/data"
+	"go-story/internal/errreport"
+	"go-story/internal/storypb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Options tunes the gRPC service; zero values keep the defaults.
+type Options struct {
+	// MaxTake 單次列表的筆數上限，take 為 0 時也使用此值，預設 100
+	MaxTake int
+	// MaxSkip skip 上限，預設 10000
+	MaxSkip int
+	// Reporter 設定後會回報 Internal 錯誤與 panic
+	Reporter *errreport.Reporter
+}
+
+// service implements storypb.StoryServiceServer on top of the repo.
+type service struct {
+	storypb.UnimplementedStoryServiceServer
+	repo *data.Repo
+	opts Options
+}
+
+// NewServer returns a gRPC server with StoryService registered.
+func NewServer(repo *data.Repo, opts Options) *grpc.Server {
+	if opts.MaxTake <= 0 {
+		opts.MaxTake = 100
+	}
+	if opts.MaxSkip <= 0 {
+		opts.MaxSkip = 10000
+	}
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(requestInterceptor(opts.Reporter)))
+	storypb.RegisterStoryServiceServer(s, &service{repo: repo, opts: opts})
+	return s
+}
+
+// requestInterceptor 帶入 request id，並將 Internal 錯誤與 panic 送到 reporter
+func requestInterceptor(reporter *errreport.Reporter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		var requestID string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if ids := md.Get("x-request-id"); len(ids) > 0 {
+				requestID = ids[0]
+			}
+		}
+		ctx = errreport.WithRequest(ctx, errreport.RequestInfo{
+			RequestID:     requestID,
+			OperationName: info.FullMethod,
+			Method:        "gRPC",
+			URL:           info.FullMethod,
+		})
+		ctx = data.WithRequestID(ctx, requestID)
+
+		defer func() {
+			if rec := recover(); rec != nil {
+				reporter.Report(ctx, fmt.Errorf("%s: panic: %v", info.FullMethod, rec), debug.Stack())
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		resp, err = handler(ctx, req)
+		if status.Code(err) == codes.Internal {
+			reporter.Report(ctx, fmt.Errorf("%s: %w", info.FullMethod, err), nil)
+		}
+		return resp, err
+	}
+}
+
+func (s *service) GetPost(ctx context.Context, req *storypb.GetPostRequest) (*storypb.Post, error) {
+	where := &data.PostWhereUniqueInput{}
+	switch key := req.GetKey().(type) {
+	case *storypb.GetPostRequest_Id:
+		where.ID = &key.Id
+	case *storypb.GetPostRequest_Slug:
+		where.Slug = &key.Slug
+	default:
+		return nil, status.Error(codes.InvalidArgument, "id or slug is required")
+	}
+	p, err := s.repo.QueryPostByUnique(ctx, where)
+	if err != nil {
+		return nil, repoError(err)
+	}
+	// 與列表一致，只回傳已發布的文章
+	if p == nil || p.State != "published" {
+		return nil, status.Error(codes.NotFound, "post not found")
+	}
+	return toPost(p), nil
+}
+
+func (s *service) ListPosts(ctx context.Context, req *storypb.ListPostsRequest) (*storypb.ListPostsResponse, error) {
+	take, skip, err := s.pagination(req.GetTake(), req.GetSkip())
+	if err != nil {
+		return nil, err
+	}
+	// 與 GetPost 一致，ids 等條件也只對已發布的文章生效
+	published := "published"
+	where := &data.PostWhereInput{State: &data.StringFilter{Equals: &published}}
+	if slug := req.GetSectionSlug(); slug != "" {
+		where.Sections = &data.SectionManyRelationFilter{Some: &data.SectionWhereInput{Slug: &data.StringFilter{Equals: &slug}}}
+	}
+	if slug := req.GetCategorySlug(); slug != "" {
+		where.Categories = &data.CategoryManyRelationFilter{Some: &data.CategoryWhereInput{Slug: &data.StringFilter{Equals: &slug}}}
+	}
+	if id := req.GetTopicId(); id != "" {
+		where.Topics = &data.PostTopicsWhereInput{ID: &data.IDFilter{Equals: &id}}
+	}
+	if ids := req.GetIds(); len(ids) > 0 {
+		where.ID = &data.IDFilter{In: ids}
+	}
+	posts, err := s.repo.QueryPosts(ctx, where, []data.OrderRule{{Field: "publishedDate", Direction: "desc"}}, take, skip)
+	if err != nil {
+		return nil, repoError(err)
+	}
+	resp := &storypb.ListPostsResponse{Posts: make([]*storypb.Post, 0, len(posts))}
+	for i := range posts {
+		resp.Posts = append(resp.Posts, toPost(&posts[i]))
+	}
+	return resp, nil
+}
+
+func (s *service) ListTopics(ctx context.Context, req *storypb.ListTopicsRequest) (*storypb.ListTopicsResponse, error) {
+	take, skip, err := s.pagination(req.GetTake(), req.GetSkip())
+	if err != nil {
+		return nil, err
+	}
+	where := &data.TopicWhereInput{}
+	if state := req.GetState(); state != "" {
+		where.State = &data.StringFilter{Equals: &state}
+	}
+	if req.GetFeaturedOnly() {
+		featured := true
+		where.IsFeatured = &data.BooleanFilter{Equals: &featured}
+	}
+	topics, err := s.repo.QueryTopics(ctx, where, nil, take, skip)
+	if err != nil {
+		return nil, repoError(err)
+	}
+	resp := &storypb.ListTopicsResponse{Topics: make([]*storypb.Topic, 0, len(topics))}
+	for i := range topics {
+		resp.Topics = append(resp.Topics, toTopic(&topics[i]))
+	}
+	return resp, nil
+}
+
+func (s *service) ListExternals(ctx context.Context, req *storypb.ListExternalsRequest) (*storypb.ListExternalsResponse, error) {
+	take, skip, err := s.pagination(req.GetTake(), req.GetSkip())
+	if err != nil {
+		return nil, err
+	}
+	published := "published"
+	where := &data.ExternalWhereInput{State: &data.StringFilter{Equals: &published}}
+	if slug := req.GetPartnerSlug(); slug != "" {
+		where.Partner = &data.PartnerWhereInput{Slug: &data.StringFilter{Equals: &slug}}
+	}
+	externals, err := s.repo.QueryExternals(ctx, where, nil, take, skip)
+	if err != nil {
+		return nil, repoError(err)
+	}
+	resp := &storypb.ListExternalsResponse{Externals: make([]*storypb.External, 0, len(externals))}
+	for i := range externals {
+		resp.Externals = append(resp.Externals, toExternal(&externals[i]))
+	}
+	return resp, nil
+}
+
+// pagination 與 GraphQL 相同的 take / skip 限制；take 為 0 時使用 MaxTake
+func (s *service) pagination(take, skip int32) (int, int, error) {
+	if take < 0 || int(take) > s.opts.MaxTake {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "invalid take %d: must be between 0 and %d", take, s.opts.MaxTake)
+	}
+	if skip < 0 || int(skip) > s.opts.MaxSkip {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "invalid skip %d: must be between 0 and %d", skip, s.opts.MaxSkip)
+	}
+	if take == 0 {
+		take = int32(s.opts.MaxTake)
+	}
+	return int(take), int(skip), nil
+}
+
+// repoError 將 repo 錯誤轉為 gRPC status
+func repoError(err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
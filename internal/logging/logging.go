@@ -0,0 +1,118 @@
+// Package logging configures the process-wide log output: plain text lines
+// or one JSON object per line in the structured format of Cloud Logging, so
+// severity, trace and httpRequest fields are parsed instead of showing up
+// as flat text.
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go-story/internal/tracecontext"
+)
+
+var (
+	// structured 為 Setup 後是否輸出 JSON，project 為 trace 欄位使用的 GCP project id
+	structured bool
+	project    string
+	out        = &jsonWriter{out: os.Stderr}
+)
+
+// Setup switches the standard logger to format ("text" or "json"). With
+// "json" every log.Printf line becomes a Cloud Logging entry whose severity
+// is guessed from the message. gcpProject (which may be empty) is used for
+// the trace field of request entries. Call it once at startup.
+func Setup(format, gcpProject string) {
+	project = gcpProject
+	if format != "json" {
+		return
+	}
+	structured = true
+	log.SetFlags(0)
+	log.SetOutput(out)
+}
+
+// HTTPRequest is the httpRequest field of a Cloud Logging entry.
+type HTTPRequest struct {
+	RequestMethod string `json:"requestMethod,omitempty"`
+	RequestURL    string `json:"requestUrl,omitempty"`
+	UserAgent     string `json:"userAgent,omitempty"`
+	RemoteIP      string `json:"remoteIp,omitempty"`
+	Referer       string `json:"referer,omitempty"`
+	// Latency 為 Cloud Logging 的 duration 格式，例如 "0.012s"
+	Latency string `json:"latency,omitempty"`
+}
+
+// Request logs message for the request in ctx. In JSON format the entry
+// carries req as httpRequest, the trace of ctx and labels; in text format
+// only message is printed.
+func Request(ctx context.Context, message string, req HTTPRequest, labels map[string]string) {
+	if !structured {
+		log.Print(message)
+		return
+	}
+	entry := map[string]any{
+		"severity":    "INFO",
+		"message":     message,
+		"time":        time.Now().UTC().Format(time.RFC3339Nano),
+		"httpRequest": req,
+	}
+	if len(labels) > 0 {
+		entry["logging.googleapis.com/labels"] = labels
+	}
+	for key, value := range tracecontext.LogFields(ctx, project) {
+		entry[key] = value
+	}
+	out.writeEntry(entry)
+}
+
+// Latency formats d as a Cloud Logging duration.
+func Latency(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+// jsonWriter 將 log 套件輸出的每一行轉為 Cloud Logging 的 JSON entry
+type jsonWriter struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func (w *jsonWriter) Write(p []byte) (int, error) {
+	message := strings.TrimRight(string(p), "\n")
+	w.writeEntry(map[string]any{
+		"severity": severityOf(message),
+		"message":  message,
+		"time":     time.Now().UTC().Format(time.RFC3339Nano),
+	})
+	return len(p), nil
+}
+
+func (w *jsonWriter) writeEntry(entry map[string]any) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, _ = w.out.Write(append(line, '\n'))
+}
+
+// severityOf 依訊息內容推測 severity；既有的 log 以 warning: 開頭或包含 error / failed 表示問題
+func severityOf(message string) string {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.HasPrefix(lower, "warning") || strings.Contains(lower, " warning:"):
+		return "WARNING"
+	case strings.Contains(lower, "error") || strings.Contains(lower, "failed") || strings.Contains(lower, "panic"):
+		return "ERROR"
+	default:
+		return "INFO"
+	}
+}
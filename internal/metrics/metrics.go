@@ -0,0 +1,277 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Registry holds metric families and renders them in Prometheus text format.
+type Registry struct {
+	mu       sync.Mutex
+	families []family
+}
+
+type family interface {
+	write(sb *strings.Builder)
+}
+
+// Default is the process-wide registry served by Handler.
+var Default = &Registry{}
+
+func (r *Registry) register(f family) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.families = append(r.families, f)
+}
+
+// Handler serves all metrics of the default registry.
+func Handler() http.Handler {
+	return Default.Handler()
+}
+
+// Handler serves all metrics of the registry.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		families := append([]family{}, r.families...)
+		r.mu.Unlock()
+
+		sb := strings.Builder{}
+		for _, f := range families {
+			f.write(&sb)
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		_, _ = w.Write([]byte(sb.String()))
+	})
+}
+
+// vec stores one float value per label combination.
+type vec struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+
+	mu     sync.Mutex
+	values map[string]float64
+	labels map[string][]string
+}
+
+func newVec(name, help, kind string, labelNames []string) *vec {
+	return &vec{
+		name:       name,
+		help:       help,
+		kind:       kind,
+		labelNames: labelNames,
+		values:     map[string]float64{},
+		labels:     map[string][]string{},
+	}
+}
+
+func (v *vec) key(labelValues []string) string {
+	if len(labelValues) != len(v.labelNames) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", v.name, len(v.labelNames), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+func (v *vec) set(val float64, labelValues []string) {
+	k := v.key(labelValues)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.values[k] = val
+	v.labels[k] = append([]string{}, labelValues...)
+}
+
+func (v *vec) add(delta float64, labelValues []string) {
+	k := v.key(labelValues)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.values[k] += delta
+	v.labels[k] = append([]string{}, labelValues...)
+}
+
+func (v *vec) get(labelValues []string) float64 {
+	k := v.key(labelValues)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.values[k]
+}
+
+func (v *vec) write(sb *strings.Builder) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	fmt.Fprintf(sb, "# HELP %s %s\n", v.name, v.help)
+	fmt.Fprintf(sb, "# TYPE %s %s\n", v.name, v.kind)
+	keys := make([]string, 0, len(v.values))
+	for k := range v.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sb.WriteString(v.name)
+		sb.WriteString(formatLabels(v.labelNames, v.labels[k]))
+		sb.WriteString(" ")
+		sb.WriteString(formatFloat(v.values[k]))
+		sb.WriteString("\n")
+	}
+}
+
+// GaugeVec is a gauge partitioned by labels.
+type GaugeVec struct{ v *vec }
+
+// NewGauge registers a gauge on the default registry.
+func NewGauge(name, help string, labelNames ...string) *GaugeVec {
+	g := &GaugeVec{v: newVec(name, help, "gauge", labelNames)}
+	Default.register(g.v)
+	return g
+}
+
+// Set sets the gauge value for the given label values.
+func (g *GaugeVec) Set(val float64, labelValues ...string) {
+	g.v.set(val, labelValues)
+}
+
+// CounterVec is a monotonically increasing counter partitioned by labels.
+type CounterVec struct{ v *vec }
+
+// NewCounter registers a counter on the default registry.
+func NewCounter(name, help string, labelNames ...string) *CounterVec {
+	c := &CounterVec{v: newVec(name, help, "counter", labelNames)}
+	Default.register(c.v)
+	return c
+}
+
+// Inc increments the counter by one.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.v.add(1, labelValues)
+}
+
+// Add increments the counter by delta (negative values are ignored).
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		return
+	}
+	c.v.add(delta, labelValues)
+}
+
+// Value returns the current counter value for the given label values.
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	return c.v.get(labelValues)
+}
+
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf(`%s="%s"`, name, escapeLabel(values[i]))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func escapeLabel(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// DefaultBuckets are latency buckets in seconds, from 1ms to 10s.
+var DefaultBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// HistogramVec is a histogram partitioned by labels.
+type HistogramVec struct {
+	name       string
+	help       string
+	labelNames []string
+	buckets    []float64
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+// histogramSeries 單一 label 組合的累計值，counts[i] 為落在 buckets[i] 以下（非累計）的次數
+type histogramSeries struct {
+	labels []string
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHistogram registers a histogram on the default registry. buckets must
+// be sorted ascending; nil uses DefaultBuckets.
+func NewHistogram(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	h := &HistogramVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		buckets:    buckets,
+		series:     map[string]*histogramSeries{},
+	}
+	Default.register(h)
+	return h
+}
+
+// Observe records one value for the given label values.
+func (h *HistogramVec) Observe(val float64, labelValues ...string) {
+	if len(labelValues) != len(h.labelNames) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", h.name, len(h.labelNames), len(labelValues)))
+	}
+	k := strings.Join(labelValues, "\xff")
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.series[k]
+	if !ok {
+		s = &histogramSeries{
+			labels: append([]string{}, labelValues...),
+			counts: make([]uint64, len(h.buckets)),
+		}
+		h.series[k] = s
+	}
+	for i, upper := range h.buckets {
+		if val <= upper {
+			s.counts[i]++
+			break
+		}
+	}
+	s.count++
+	s.sum += val
+}
+
+func (h *HistogramVec) write(sb *strings.Builder) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	fmt.Fprintf(sb, "# HELP %s %s\n", h.name, h.help)
+	fmt.Fprintf(sb, "# TYPE %s histogram\n", h.name)
+	keys := make([]string, 0, len(h.series))
+	for k := range h.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	bucketLabels := append(append([]string{}, h.labelNames...), "le")
+	for _, k := range keys {
+		s := h.series[k]
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += s.counts[i]
+			values := append(append([]string{}, s.labels...), formatFloat(upper))
+			fmt.Fprintf(sb, "%s_bucket%s %d\n", h.name, formatLabels(bucketLabels, values), cumulative)
+		}
+		values := append(append([]string{}, s.labels...), "+Inf")
+		fmt.Fprintf(sb, "%s_bucket%s %d\n", h.name, formatLabels(bucketLabels, values), s.count)
+		fmt.Fprintf(sb, "%s_sum%s %s\n", h.name, formatLabels(h.labelNames, s.labels), formatFloat(s.sum))
+		fmt.Fprintf(sb, "%s_count%s %d\n", h.name, formatLabels(h.labelNames, s.labels), s.count)
+	}
+}
@@ -0,0 +1,205 @@
+// Package persisted implements the persisted-query allowlist: only
+// operations listed in a signed manifest may be executed.
+package persisted
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go-story/internal/gcpauth"
+)
+
+// ErrNotAllowed is returned by Resolve for operations missing from the allowlist.
+var ErrNotAllowed = errors.New("operation is not in the persisted query allowlist")
+
+const (
+	gcsPrefix    = "gs://"
+	gcsObjectAPI = "https://storage.googleapis.com/storage/v1/b/%s/o/%s?alt=media"
+)
+
+// manifest 為 Apollo persisted query manifest 格式
+type manifest struct {
+	Format     string `json:"format"`
+	Version    int    `json:"version"`
+	Operations []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Type string `json:"type"`
+		Body string `json:"body"`
+	} `json:"operations"`
+}
+
+// Allowlist holds the allowed operations keyed by the sha256 of their body.
+// In enforcing mode other queries are rejected; otherwise they are only logged.
+type Allowlist struct {
+	source  string
+	key     []byte
+	enforce bool
+	http    *http.Client
+
+	mu     sync.RWMutex
+	ops    map[string]string
+	digest string
+}
+
+// Load reads and verifies the manifest at source (a local path or
+// gs://bucket/object). The HMAC-SHA256 of the manifest, hex encoded, must be
+// stored next to it as <source>.sig.
+func Load(ctx context.Context, source string, key []byte, enforce bool) (*Allowlist, error) {
+	if len(key) == 0 {
+		return nil, errors.New("persisted queries: signing key is required")
+	}
+	a := &Allowlist{
+		source:  source,
+		key:     key,
+		enforce: enforce,
+		http:    &http.Client{Timeout: 10 * time.Second},
+	}
+	if err := a.reload(ctx); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Enforcing reports whether queries outside the allowlist are rejected.
+func (a *Allowlist) Enforcing() bool {
+	return a != nil && a.enforce
+}
+
+// Resolve returns the query to execute for a request carrying query and/or
+// hash (the sha256 of an Apollo persisted query). A query string is allowed
+// when its own sha256 is listed, so clients may also send the full text. A
+// nil Allowlist allows everything.
+func (a *Allowlist) Resolve(query, hash string) (string, error) {
+	if a == nil {
+		return query, nil
+	}
+	if hash == "" && query != "" {
+		hash = Hash(query)
+	}
+	a.mu.RLock()
+	body, ok := a.ops[strings.ToLower(hash)]
+	a.mu.RUnlock()
+	if ok {
+		return body, nil
+	}
+	if !a.enforce && query != "" {
+		log.Printf("[Persisted] operation %s is not in the allowlist", hash)
+		return query, nil
+	}
+	return "", ErrNotAllowed
+}
+
+// Watch reloads the manifest every interval until ctx is done. A manifest
+// that fails to load or verify is ignored and the previous one is kept.
+func (a *Allowlist) Watch(ctx context.Context, interval time.Duration) {
+	if a == nil || interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if err := a.reload(ctx); err != nil {
+				log.Printf("[Persisted] failed to reload allowlist: %v", err)
+			}
+		}
+	}()
+}
+
+// Hash returns the hex sha256 of an operation body, as used by Apollo
+// persisted queries.
+func Hash(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+func (a *Allowlist) reload(ctx context.Context) error {
+	raw, err := a.read(ctx, a.source)
+	if err != nil {
+		return fmt.Errorf("read allowlist: %w", err)
+	}
+	sig, err := a.read(ctx, a.source+".sig")
+	if err != nil {
+		return fmt.Errorf("read allowlist signature: %w", err)
+	}
+	want, err := hex.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil {
+		return fmt.Errorf("decode allowlist signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write(raw)
+	if !hmac.Equal(mac.Sum(nil), want) {
+		return errors.New("allowlist signature mismatch")
+	}
+
+	var m manifest
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return fmt.Errorf("decode allowlist: %w", err)
+	}
+	ops := make(map[string]string, len(m.Operations))
+	for _, op := range m.Operations {
+		// id 必須是 body 的 sha256，避免 manifest 中的 id 與實際執行的查詢不一致
+		if Hash(op.Body) != strings.ToLower(op.ID) {
+			return fmt.Errorf("operation %s (%s): id does not match the sha256 of its body", op.ID, op.Name)
+		}
+		ops[strings.ToLower(op.ID)] = op.Body
+	}
+
+	digest := Hash(string(raw))
+	a.mu.Lock()
+	changed := digest != a.digest
+	a.ops, a.digest = ops, digest
+	a.mu.Unlock()
+	if changed {
+		log.Printf("[Persisted] loaded %d operations from %s", len(ops), a.source)
+	}
+	return nil
+}
+
+// read 讀取本機檔案或 gs:// 物件
+func (a *Allowlist) read(ctx context.Context, source string) ([]byte, error) {
+	if !strings.HasPrefix(source, gcsPrefix) {
+		return os.ReadFile(source)
+	}
+	bucket, object, ok := strings.Cut(strings.TrimPrefix(source, gcsPrefix), "/")
+	if !ok || bucket == "" || object == "" {
+		return nil, fmt.Errorf("invalid GCS path %q (want gs://<bucket>/<object>)", source)
+	}
+	token, err := gcpauth.AccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(gcsObjectAPI, url.PathEscape(bucket), url.PathEscape(object)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := a.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", source, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %s: status %d", source, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,172 @@
+package probe
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Seeds lists the slugs a crawl generates page queries for.
+type Seeds struct {
+	Posts     []string
+	Externals []string
+	Topics    []string
+}
+
+// KindSummary counts matches for one kind of page query (post, external, topic).
+type KindSummary struct {
+	Kind    string `json:"kind"`
+	Total   int    `json:"total"`
+	Matched int    `json:"matched"`
+}
+
+// Mismatch describes one crawled query whose responses differ.
+type Mismatch struct {
+	Comparison
+	Diff []string `json:"diff,omitempty"`
+}
+
+// Report is the aggregate outcome of a crawl.
+type Report struct {
+	Total      int           `json:"total"`
+	Matched    int           `json:"matched"`
+	Kinds      []KindSummary `json:"kinds"`
+	Mismatches []Mismatch    `json:"mismatches"`
+}
+
+// postPageQuery 文章頁實際使用的欄位，涵蓋關聯、作者群與圖片尺寸
+const postPageQuery = `query ($slug:String){
+	post(where:{slug:$slug}){
+		id slug title subtitle state style publishedDate updatedAt isMember isAdult
+		sections(where:{state:{equals:"active"}}){ id name slug state }
+		categories(where:{state:{equals:"active"}}){ id name slug state }
+		writers{ id name } photographers{ id name } camera_man{ id name }
+		designers{ id name } engineers{ id name } vocals{ id name }
+		extend_byline
+		tags{ id name slug }
+		heroVideo{ id name videoSrc }
+		heroImage{ id imageFile{ width height } resized{ original w480 w800 w1200 w1600 w2400 } resizedWebp{ original w480 w800 w1200 w1600 w2400 } }
+		heroCaption brief content
+		relateds{ id slug title heroImage{ id resized{ original w480 w800 w1200 } } }
+		og_title og_description
+		og_image{ id resized{ original w1200 } }
+		hiddenAdvertised isAdvertised isFeatured
+		topics{ id slug name }
+	}
+}`
+
+// CrawlSuite returns the standard frontend page queries for every seed slug.
+// Test names are "<kind>:<slug>"; ignore is applied to every test.
+func CrawlSuite(seeds Seeds, ignore []string) []Test {
+	tests := make([]Test, 0, len(seeds.Posts)+len(seeds.Externals)+len(seeds.Topics))
+	for _, slug := range seeds.Posts {
+		tests = append(tests, Test{
+			Name:      "post:" + slug,
+			Query:     postPageQuery,
+			Variables: map[string]any{"slug": slug},
+			Ignore:    ignore,
+		})
+	}
+
+	// 外稿與專題沿用內建測試的查詢，只替換 slug
+	external := defaultTest("external_by_slug")
+	for _, slug := range seeds.Externals {
+		tests = append(tests, Test{
+			Name:      "external:" + slug,
+			Query:     external.Query,
+			Variables: map[string]any{"slug": slug},
+			Ignore:    ignore,
+		})
+	}
+	topic := defaultTest("topic_by_slug")
+	for _, slug := range seeds.Topics {
+		vars := make(map[string]any, len(topic.Variables))
+		for k, v := range topic.Variables {
+			vars[k] = v
+		}
+		vars["topicFilter"] = map[string]any{"slug": map[string]any{"equals": slug}}
+		tests = append(tests, Test{
+			Name:      "topic:" + slug,
+			Query:     topic.Query,
+			Variables: vars,
+			Ignore:    ignore,
+		})
+	}
+	return tests
+}
+
+// Crawl runs tests against both endpoints with the given concurrency and
+// aggregates the comparisons per kind. Up to diffLimit differing paths are
+// kept for every mismatch.
+func Crawl(target, self string, tests []Test, headers map[string]string, concurrency, diffLimit int) Report {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	comparisons := make([]Mismatch, len(tests))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				t := tests[i]
+				tr := Run(target, []Test{t}, headers)[0]
+				sr := Run(self, []Test{t}, headers)[0]
+				match, note := Compare(tr, sr, t.Ignore)
+				m := Mismatch{Comparison: Comparison{
+					Name:         t.Name,
+					Match:        match,
+					TargetStatus: tr.StatusCode,
+					SelfStatus:   sr.StatusCode,
+					TargetError:  tr.Error,
+					SelfError:    sr.Error,
+					Note:         note,
+				}}
+				if !match {
+					m.Diff = Diff(tr, sr, t.Ignore, diffLimit)
+				}
+				comparisons[i] = m
+			}
+		}()
+	}
+	for i := range tests {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	report := Report{Mismatches: []Mismatch{}}
+	kinds := map[string]*KindSummary{}
+	for _, c := range comparisons {
+		kind, _, _ := strings.Cut(c.Name, ":")
+		k := kinds[kind]
+		if k == nil {
+			k = &KindSummary{Kind: kind}
+			kinds[kind] = k
+		}
+		report.Total++
+		k.Total++
+		if c.Match {
+			report.Matched++
+			k.Matched++
+			continue
+		}
+		report.Mismatches = append(report.Mismatches, c)
+	}
+	for _, k := range kinds {
+		report.Kinds = append(report.Kinds, *k)
+	}
+	sort.Slice(report.Kinds, func(i, j int) bool { return report.Kinds[i].Kind < report.Kinds[j].Kind })
+	return report
+}
+
+// defaultTest 依名稱取出內建測試
+func defaultTest(name string) Test {
+	for _, t := range DefaultSuite() {
+		if t.Name == name {
+			return t
+		}
+	}
+	return Test{}
+}
@@ -0,0 +1,114 @@
+package probe
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// LoadSuite reads a JSON array of tests from path.
+func LoadSuite(path string) ([]Test, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read suite: %w", err)
+	}
+	var tests []Test
+	if err := json.Unmarshal(raw, &tests); err != nil {
+		return nil, fmt.Errorf("parse suite: %w", err)
+	}
+	for i, t := range tests {
+		if t.Name == "" || t.Query == "" {
+			return nil, fmt.Errorf("suite entry %d: name and query are required", i)
+		}
+	}
+	return tests, nil
+}
+
+// Diff lists the JSON paths whose values differ between target and self bodies,
+// skipping the ignore paths. At most limit entries are returned (limit <= 0 means no limit).
+func Diff(target Result, self Result, ignore []string, limit int) []string {
+	tObj, tErr := normalizeJSON(target.Body)
+	sObj, sErr := normalizeJSON(self.Body)
+	if tErr != nil || sErr != nil {
+		return nil
+	}
+	tObj = stripPaths(tObj, ignore)
+	sObj = stripPaths(sObj, ignore)
+	diffs := []string{}
+	diffValues("", tObj, sObj, &diffs, limit)
+	return diffs
+}
+
+func diffValues(path string, t, s interface{}, diffs *[]string, limit int) {
+	if limit > 0 && len(*diffs) >= limit {
+		return
+	}
+	switch tv := t.(type) {
+	case map[string]interface{}:
+		sv, ok := s.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := map[string]struct{}{}
+		for k := range tv {
+			keys[k] = struct{}{}
+		}
+		for k := range sv {
+			keys[k] = struct{}{}
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			diffValues(joinPath(path, k), tv[k], sv[k], diffs, limit)
+		}
+		return
+	case []interface{}:
+		sv, ok := s.([]interface{})
+		if !ok {
+			break
+		}
+		if len(tv) != len(sv) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: length target=%d self=%d", displayPath(path), len(tv), len(sv)))
+			return
+		}
+		for i := range tv {
+			diffValues(path+"["+strconv.Itoa(i)+"]", tv[i], sv[i], diffs, limit)
+		}
+		return
+	}
+	if !reflect.DeepEqual(t, s) {
+		*diffs = append(*diffs, fmt.Sprintf("%s: target=%s self=%s", displayPath(path), compactJSON(t), compactJSON(s)))
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+func compactJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	const maxLen = 120
+	if len(b) > maxLen {
+		return string(b[:maxLen]) + "..."
+	}
+	return string(b)
+}
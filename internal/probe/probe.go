@@ -0,0 +1,338 @@
+package probe
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Test is a single GraphQL query sent to both endpoints.
+type Test struct {
+	Name      string         `json:"name"`
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+	// Ignore lists JSON paths stripped from both bodies before comparison,
+	// e.g. "data.posts.*.updatedAt" ("*" matches any key or list index).
+	Ignore []string `json:"ignore,omitempty"`
+}
+
+// Result is the raw response of one test against one endpoint.
+type Result struct {
+	Name       string          `json:"name"`
+	StatusCode int             `json:"statusCode"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// Comparison is the outcome of one test against target and self.
+type Comparison struct {
+	Name         string `json:"name"`
+	Match        bool   `json:"match"`
+	TargetStatus int    `json:"targetStatus"`
+	SelfStatus   int    `json:"selfStatus"`
+	TargetError  string `json:"targetError,omitempty"`
+	SelfError    string `json:"selfError,omitempty"`
+	Note         string `json:"note,omitempty"`
+}
+
+// DefaultSuite returns the built-in GQL queries covering posts, externals and topics.
+func DefaultSuite() []Test {
+	return []Test{
+		{
+			Name: "posts_list",
+			Query: `query ($take:Int,$skip:Int,$orderBy:[PostOrderByInput!]!,$filter:PostWhereInput!){
+					postsCount(where:$filter)
+					posts(take:$take,skip:$skip,orderBy:$orderBy,where:$filter){
+						id slug title publishedDate state
+					}
+				}`,
+			Variables: map[string]any{
+				"take":    3,
+				"skip":    0,
+				"orderBy": []map[string]string{{"publishedDate": "desc"}},
+				"filter": map[string]any{
+					"state": map[string]any{"equals": "published"},
+				},
+			},
+		},
+		{
+			Name:  "post_by_slug",
+			Query: `query ($slug:String){ post(where:{slug:$slug}){ id slug title state } }`,
+			Variables: map[string]any{
+				"slug": "20251212-4-173036",
+			},
+		},
+		{
+			Name: "externals_list",
+			Query: `query ($take:Int,$skip:Int,$orderBy:[ExternalOrderByInput!]!,$filter:ExternalWhereInput!){
+					externals(take:$take,skip:$skip,orderBy:$orderBy,where:$filter){
+						id slug title thumb brief publishedDate partner{ id slug name showOnIndex }
+					}
+				}`,
+			Variables: map[string]any{
+				"take":    3,
+				"skip":    0,
+				"orderBy": []map[string]string{{"publishedDate": "desc"}},
+				"filter": map[string]any{
+					"state":         map[string]any{"equals": "published"},
+					"publishedDate": map[string]any{"not": map[string]any{"equals": nil}},
+				},
+			},
+		},
+		{
+			Name: "external_by_slug",
+			Query: `query ($slug:String){
+					externals(where:{slug:{equals:$slug},state:{equals:"published"}}){
+						id slug title thumb brief content publishedDate extend_byline thumbCaption
+						partner{ id slug name showOnIndex showThumb showBrief }
+						updatedAt
+					}
+				}`,
+			Variables: map[string]any{
+				"slug": "mirrordaily_35695",
+			},
+		},
+		{
+			Name: "topics_list",
+			Query: `query ($take:Int,$skip:Int,$orderBy:[TopicOrderByInput!]!,$filter:TopicWhereInput!){
+					topicsCount(where:$filter)
+					topics(take:$take,skip:$skip,orderBy:$orderBy,where:$filter){
+						id slug name brief createdAt style
+						heroImage{ id imageFile{ width height } resized{ original w480 w800 w1200 w1600 w2400 } resizedWebp{ original w480 w800 w1200 w1600 w2400 } }
+						og_image{ id imageFile{ width height } resized{ original w480 w800 w1200 w1600 w2400 } resizedWebp{ original w480 w800 w1200 w1600 w2400 } }
+					}
+				}`,
+			Variables: map[string]any{
+				"take":    3,
+				"skip":    0,
+				"orderBy": []map[string]string{{"sortOrder": "asc"}},
+				"filter": map[string]any{
+					"state": map[string]any{"equals": "published"},
+				},
+			},
+		},
+		{
+			Name: "topic_by_slug",
+			Query: `query ($topicFilter:TopicWhereInput!,$postsFilter:PostWhereInput!,$featuredPostsCountFilter:PostWhereInput,$postsOrderBy:[PostOrderByInput!]!,$postsTake:Int,$postsSkip:Int!){
+					topics(where:$topicFilter){
+						id slug name brief createdAt style heroUrl leading type
+						heroImage{ id imageFile{ width height } resized{ original w480 w800 w1200 w1600 w2400 } resizedWebp{ original w480 w800 w1200 w1600 w2400 } }
+						og_image{ id imageFile{ width height } resized{ original w480 w800 w1200 w1600 w2400 } resizedWebp{ original w480 w800 w1200 w1600 w2400 } }
+						og_description
+						postsCount(where:$postsFilter)
+						featuredPostsCount: postsCount(where:$featuredPostsCountFilter)
+						tags{ id name slug }
+						slideshow_images{ id name topicKeywords resized{ original w480 w800 w1200 w1600 w2400 } }
+						manualOrderOfSlideshowImages
+						dfp
+						posts(where:$postsFilter,orderBy:$postsOrderBy,take:$postsTake,skip:$postsSkip){
+							id slug title publishedDate updatedAt brief state
+							categories(where:{state:{equals:"active"}}){ id name slug state }
+							sections(where:{state:{equals:"active"}}){ id name slug state }
+							heroImage{ id imageFile{ width height } resized{ original w480 w800 w1200 w1600 w2400 } resizedWebp{ original w480 w800 w1200 w1600 w2400 } }
+							tags{ id name slug }
+							isFeatured
+						}
+					}
+				}`,
+			Variables: map[string]any{
+				"topicFilter": map[string]any{
+					"slug": map[string]any{"equals": "test-topic"},
+				},
+				"postsFilter": map[string]any{
+					"state": map[string]any{"equals": "published"},
+				},
+				"featuredPostsCountFilter": map[string]any{
+					"state":      map[string]any{"equals": "published"},
+					"isFeatured": map[string]any{"equals": true},
+				},
+				"postsOrderBy": []map[string]string{{"publishedDate": "desc"}},
+				"postsTake":    10,
+				"postsSkip":    0,
+			},
+		},
+		{
+			Name: "topic_post_count",
+			Query: `query ($topicFilter:TopicWhereUniqueInput!,$postsCountFilter:PostWhereInput){
+					topic(where:$topicFilter){
+						postsCount(where:$postsCountFilter)
+					}
+				}`,
+			Variables: map[string]any{
+				"topicFilter": map[string]any{
+					"slug": "test-topic",
+				},
+				"postsCountFilter": map[string]any{
+					"state": map[string]any{"equals": "published"},
+				},
+			},
+		},
+	}
+}
+
+// Run executes every test against target and returns the raw results.
+// headers (e.g. Authorization, Cookie) are added to every request.
+func Run(target string, tests []Test, headers map[string]string) []Result {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	results := make([]Result, 0, len(tests))
+	for _, t := range tests {
+		res := Result{Name: t.Name}
+		b, _ := json.Marshal(map[string]any{
+			"query":     t.Query,
+			"variables": t.Variables,
+		})
+		req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(b))
+		if err != nil {
+			res.Error = err.Error()
+			results = append(results, res)
+			continue
+		}
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			res.Error = err.Error()
+			results = append(results, res)
+			continue
+		}
+		res.StatusCode = resp.StatusCode
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			res.Error = err.Error()
+		} else {
+			res.Body = json.RawMessage(body)
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+// RunComparison runs tests against both endpoints and compares the responses pairwise.
+func RunComparison(targetURL, selfURL string, tests []Test, headers map[string]string) []Comparison {
+	targetResults := Run(targetURL, tests, headers)
+	selfResults := Run(selfURL, tests, headers)
+
+	selfMap := map[string]Result{}
+	for _, r := range selfResults {
+		selfMap[r.Name] = r
+	}
+	ignoreMap := map[string][]string{}
+	for _, t := range tests {
+		ignoreMap[t.Name] = t.Ignore
+	}
+
+	results := []Comparison{}
+	for _, tr := range targetResults {
+		sr := selfMap[tr.Name]
+		match, note := Compare(tr, sr, ignoreMap[tr.Name])
+		results = append(results, Comparison{
+			Name:         tr.Name,
+			Match:        match,
+			TargetStatus: tr.StatusCode,
+			SelfStatus:   sr.StatusCode,
+			TargetError:  tr.Error,
+			SelfError:    sr.Error,
+			Note:         note,
+		})
+	}
+	return results
+}
+
+// Compare reports whether two results are equivalent, with a short note when they differ.
+// Values at the ignore paths are removed from both bodies before comparing.
+func Compare(target Result, self Result, ignore []string) (bool, string) {
+	// If either has transport error
+	if target.Error != "" || self.Error != "" {
+		return target.Error == "" && self.Error == "", "transport error"
+	}
+	if target.StatusCode != self.StatusCode {
+		return false, "status code differ"
+	}
+
+	tObj, tErr := normalizeJSON(target.Body)
+	sObj, sErr := normalizeJSON(self.Body)
+	if tErr == nil && sErr == nil {
+		tObj = stripPaths(tObj, ignore)
+		sObj = stripPaths(sObj, ignore)
+		if reflect.DeepEqual(tObj, sObj) {
+			return true, ""
+		}
+		return false, "body JSON differ"
+	}
+
+	// fallback raw compare
+	if bytes.Equal(target.Body, self.Body) {
+		return true, ""
+	}
+	return false, "body differ"
+}
+
+func normalizeJSON(raw []byte) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// stripPaths removes every value matched by the dot-separated paths.
+func stripPaths(v interface{}, paths []string) interface{} {
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		removePath(v, strings.Split(p, "."))
+	}
+	return v
+}
+
+func removePath(v interface{}, segments []string) {
+	if len(segments) == 0 {
+		return
+	}
+	seg, rest := segments[0], segments[1:]
+	switch node := v.(type) {
+	case map[string]interface{}:
+		if len(rest) == 0 {
+			if seg == "*" {
+				for k := range node {
+					delete(node, k)
+				}
+			} else {
+				delete(node, seg)
+			}
+			return
+		}
+		if seg == "*" {
+			for _, child := range node {
+				removePath(child, rest)
+			}
+			return
+		}
+		removePath(node[seg], rest)
+	case []interface{}:
+		// list 只能往下走，不刪除元素本身以免改變長度比對
+		if len(rest) == 0 {
+			return
+		}
+		if seg == "*" {
+			for _, child := range node {
+				removePath(child, rest)
+			}
+			return
+		}
+		if idx, err := strconv.Atoi(seg); err == nil && idx >= 0 && idx < len(node) {
+			removePath(node[idx], rest)
+		}
+	}
+}
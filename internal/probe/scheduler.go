@@ -0,0 +1,91 @@
+package probe
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go-story/internal/metrics"
+)
+
+var (
+	testPassGauge = metrics.NewGauge(
+		"go_story_probe_test_pass",
+		"Whether the last scheduled probe run matched the reference endpoint (1) or not (0).",
+		"test",
+	)
+	lastRunGauge = metrics.NewGauge(
+		"go_story_probe_last_run_timestamp_seconds",
+		"Unix time of the last completed scheduled probe run.",
+	)
+	regressionsCounter = metrics.NewCounter(
+		"go_story_probe_regressions_total",
+		"Number of times a probe test went from matching to mismatching.",
+		"test",
+	)
+)
+
+// Scheduler periodically runs a probe suite against a reference endpoint.
+type Scheduler struct {
+	ReferenceURL string
+	SelfURL      string
+	Interval     time.Duration
+	Suite        []Test
+
+	// 上一次執行的結果，用來判斷是否為新的 regression
+	lastMatch map[string]bool
+}
+
+// Start runs the suite every Interval until ctx is done.
+func (s *Scheduler) Start(ctx context.Context) {
+	if s.Interval <= 0 || s.ReferenceURL == "" || s.SelfURL == "" {
+		return
+	}
+	if len(s.Suite) == 0 {
+		s.Suite = DefaultSuite()
+	}
+	s.lastMatch = map[string]bool{}
+
+	log.Printf("[Probe] Scheduled parity checks against %s every %v", s.ReferenceURL, s.Interval)
+	go func() {
+		ticker := time.NewTicker(s.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runOnce()
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) runOnce() {
+	results := RunComparison(s.ReferenceURL, s.SelfURL, s.Suite, nil)
+	failed := 0
+	for _, r := range results {
+		if r.Match {
+			testPassGauge.Set(1, r.Name)
+		} else {
+			testPassGauge.Set(0, r.Name)
+			failed++
+		}
+
+		prev, seen := s.lastMatch[r.Name]
+		if !r.Match && (!seen || prev) {
+			if seen {
+				regressionsCounter.Inc(r.Name)
+			}
+			log.Printf("[Probe] Regression in %s: %s (target status %d, self status %d)", r.Name, r.Note, r.TargetStatus, r.SelfStatus)
+		}
+		if r.Match && seen && !prev {
+			log.Printf("[Probe] Recovered: %s", r.Name)
+		}
+		s.lastMatch[r.Name] = r.Match
+	}
+	lastRunGauge.Set(float64(time.Now().Unix()))
+	if failed > 0 {
+		log.Printf("[Probe] Scheduled run finished: %d/%d tests mismatched", failed, len(results))
+	}
+}
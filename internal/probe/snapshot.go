@@ -0,0 +1,69 @@
+package probe
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SaveSnapshots writes each result to dir/<name>.json as a golden snapshot.
+func SaveSnapshots(dir string, results []Result) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create snapshot dir: %w", err)
+	}
+	for _, r := range results {
+		if r.Error != "" {
+			return fmt.Errorf("snapshot %s: %s", r.Name, r.Error)
+		}
+		snap := r
+		// body 以縮排格式儲存，方便在 git 中檢視差異
+		if len(r.Body) > 0 && json.Valid(r.Body) {
+			var buf bytes.Buffer
+			if err := json.Indent(&buf, r.Body, "", "  "); err == nil {
+				snap.Body = buf.Bytes()
+			}
+		}
+		data, err := json.MarshalIndent(snap, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal snapshot %s: %w", r.Name, err)
+		}
+		if err := os.WriteFile(snapshotPath(dir, r.Name), append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("write snapshot %s: %w", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// LoadSnapshots reads the golden snapshot of every test from dir.
+// A missing snapshot yields a result carrying an error so the test is reported as mismatched.
+func LoadSnapshots(dir string, tests []Test) []Result {
+	results := make([]Result, 0, len(tests))
+	for _, t := range tests {
+		res := Result{Name: t.Name}
+		raw, err := os.ReadFile(snapshotPath(dir, t.Name))
+		if err != nil {
+			res.Error = fmt.Sprintf("read snapshot: %v", err)
+			results = append(results, res)
+			continue
+		}
+		if err := json.Unmarshal(raw, &res); err != nil {
+			res.Error = fmt.Sprintf("parse snapshot: %v", err)
+		}
+		res.Name = t.Name
+		results = append(results, res)
+	}
+	return results
+}
+
+func snapshotPath(dir, name string) string {
+	safe := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, name)
+	return filepath.Join(dir, safe+".json")
+}
@@ -0,0 +1,157 @@
+// Package pubsub is a minimal GCP Pub/Sub REST client. It authenticates
+// with the service account of the metadata server through gcpauth, so no
+// SDK or key file is needed; PUBSUB_EMULATOR_HOST points it at a local
+// emulator instead.
+package pubsub
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"go-story/internal/gcpauth"
+)
+
+const (
+	pubsubAPI = "https://pubsub.googleapis.com/v1/"
+	// maxPublishBatch Pub/Sub 單次 publish 的訊息數上限
+	maxPublishBatch = 1000
+)
+
+// Message is a Pub/Sub message.
+type Message struct {
+	Data       []byte
+	Attributes map[string]string
+}
+
+// Client calls the Pub/Sub REST API.
+type Client struct {
+	http     *http.Client
+	endpoint string
+	// emulator 不需要 access token
+	emulator bool
+}
+
+// NewClient returns a client for the production API, or for the emulator
+// when PUBSUB_EMULATOR_HOST is set.
+func NewClient() *Client {
+	c := &Client{http: &http.Client{Timeout: 30 * time.Second}, endpoint: pubsubAPI}
+	if host := os.Getenv("PUBSUB_EMULATOR_HOST"); host != "" {
+		c.endpoint = "http://" + host + "/v1/"
+		c.emulator = true
+	}
+	return c
+}
+
+// wireMessage 為 REST API 的訊息格式，data 需以 base64 編碼
+type wireMessage struct {
+	Data       string            `json:"data"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Publish sends msgs to topic (projects/<p>/topics/<t>) in batches and
+// returns the number of messages accepted before the first error.
+func (c *Client) Publish(ctx context.Context, topic string, msgs []Message) (int, error) {
+	sent := 0
+	for start := 0; start < len(msgs); start += maxPublishBatch {
+		end := min(start+maxPublishBatch, len(msgs))
+		batch := make([]wireMessage, 0, end-start)
+		for _, m := range msgs[start:end] {
+			batch = append(batch, wireMessage{Data: base64.StdEncoding.EncodeToString(m.Data), Attributes: m.Attributes})
+		}
+		if err := c.call(ctx, topic+":publish", map[string]any{"messages": batch}, nil); err != nil {
+			return sent, fmt.Errorf("publish to %s: %w", topic, err)
+		}
+		sent += end - start
+	}
+	return sent, nil
+}
+
+// ReceivedMessage is a message pulled from a subscription.
+type ReceivedMessage struct {
+	AckID      string
+	Data       []byte
+	Attributes map[string]string
+}
+
+// Pull waits for up to max messages on subscription
+// (projects/<p>/subscriptions/<s>). It returns no messages and no error
+// when nothing arrives before ctx or the server wait expires.
+func (c *Client) Pull(ctx context.Context, subscription string, max int) ([]ReceivedMessage, error) {
+	var resp struct {
+		ReceivedMessages []struct {
+			AckID   string `json:"ackId"`
+			Message struct {
+				Data       string            `json:"data"`
+				Attributes map[string]string `json:"attributes"`
+			} `json:"message"`
+		} `json:"receivedMessages"`
+	}
+	if err := c.call(ctx, subscription+":pull", map[string]any{"maxMessages": max}, &resp); err != nil {
+		if ctx.Err() != nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("pull from %s: %w", subscription, err)
+	}
+	msgs := make([]ReceivedMessage, 0, len(resp.ReceivedMessages))
+	for _, m := range resp.ReceivedMessages {
+		data, err := base64.StdEncoding.DecodeString(m.Message.Data)
+		if err != nil {
+			// 無法解碼的訊息仍回傳，讓呼叫端 ack 掉，避免一直重送
+			data = nil
+		}
+		msgs = append(msgs, ReceivedMessage{AckID: m.AckID, Data: data, Attributes: m.Message.Attributes})
+	}
+	return msgs, nil
+}
+
+// Acknowledge marks messages as processed so they are not redelivered.
+func (c *Client) Acknowledge(ctx context.Context, subscription string, ackIDs []string) error {
+	if len(ackIDs) == 0 {
+		return nil
+	}
+	if err := c.call(ctx, subscription+":acknowledge", map[string]any{"ackIds": ackIDs}, nil); err != nil {
+		return fmt.Errorf("acknowledge on %s: %w", subscription, err)
+	}
+	return nil
+}
+
+// call 以 POST 呼叫 Pub/Sub API，out 不為 nil 時解碼回應
+func (c *Client) call(ctx context.Context, method string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+method, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if !c.emulator {
+		token, err := gcpauth.AccessToken(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,203 @@
+package sanitize
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Policy controls which embeds survive HTML sanitization.
+type Policy struct {
+	// EmbedHosts 允許保留的 iframe 來源網域，例如 www.youtube.com
+	EmbedHosts []string
+}
+
+// DefaultEmbedHosts lists the embed providers kept by default.
+var DefaultEmbedHosts = []string{
+	"www.youtube.com",
+	"www.youtube-nocookie.com",
+	"player.vimeo.com",
+	"www.facebook.com",
+	"platform.twitter.com",
+	"www.instagram.com",
+}
+
+// droppedElements 連同內容一起移除的元素
+var droppedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Object:   true,
+	atom.Embed:    true,
+	atom.Applet:   true,
+	atom.Frame:    true,
+	atom.Frameset: true,
+	atom.Form:     true,
+	atom.Textarea: true,
+	atom.Select:   true,
+	atom.Button:   true,
+	atom.Noscript: true,
+	atom.Template: true,
+}
+
+// droppedVoidElements 沒有內容、直接移除的元素
+var droppedVoidElements = map[atom.Atom]bool{
+	atom.Link:  true,
+	atom.Meta:  true,
+	atom.Base:  true,
+	atom.Input: true,
+}
+
+// urlAttributes 需要檢查 scheme 的屬性
+var urlAttributes = map[string]bool{
+	"href":       true,
+	"src":        true,
+	"action":     true,
+	"formaction": true,
+	"poster":     true,
+	"background": true,
+}
+
+// HTML removes scripts, non-allowlisted iframes and other active content from
+// s, and normalizes the attributes of the remaining elements.
+func HTML(s string, p Policy) string {
+	if s == "" {
+		return s
+	}
+
+	var sb strings.Builder
+	z := html.NewTokenizer(strings.NewReader(s))
+	// skipDepth > 0 表示正在略過被移除元素的內容
+	skipDepth := 0
+	var skipAtom atom.Atom
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		tok := z.Token()
+
+		if skipDepth > 0 {
+			switch {
+			case tt == html.StartTagToken && tok.DataAtom == skipAtom:
+				skipDepth++
+			case tt == html.EndTagToken && tok.DataAtom == skipAtom:
+				skipDepth--
+			}
+			continue
+		}
+
+		switch tt {
+		case html.CommentToken, html.DoctypeToken:
+			continue
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedVoidElements[tok.DataAtom] {
+				continue
+			}
+			if droppedElements[tok.DataAtom] || (tok.DataAtom == atom.Iframe && !p.allowsEmbed(tok)) {
+				if tt == html.StartTagToken {
+					skipDepth = 1
+					skipAtom = tok.DataAtom
+				}
+				continue
+			}
+			tok.Attr = normalizeAttrs(tok)
+			sb.WriteString(tok.String())
+		case html.EndTagToken:
+			if droppedElements[tok.DataAtom] || droppedVoidElements[tok.DataAtom] {
+				continue
+			}
+			sb.WriteString(tok.String())
+		default:
+			sb.WriteString(tok.String())
+		}
+	}
+	return sb.String()
+}
+
+// allowsEmbed 檢查 iframe 的 src 是否為允許的 https 網域
+func (p Policy) allowsEmbed(tok html.Token) bool {
+	for _, a := range tok.Attr {
+		if a.Key != "src" {
+			continue
+		}
+		src := strings.TrimSpace(a.Val)
+		if strings.HasPrefix(src, "//") {
+			src = "https:" + src
+		}
+		u, err := url.Parse(src)
+		if err != nil || u.Scheme != "https" {
+			return false
+		}
+		host := strings.ToLower(u.Hostname())
+		for _, allowed := range p.EmbedHosts {
+			if host == strings.ToLower(allowed) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// normalizeAttrs 移除事件屬性與危險 URL，屬性名稱轉為小寫並去除重複，
+// target=_blank 的連結補上 rel="noopener noreferrer"
+func normalizeAttrs(tok html.Token) []html.Attribute {
+	attrs := make([]html.Attribute, 0, len(tok.Attr))
+	seen := map[string]bool{}
+	blankTarget := false
+	for _, a := range tok.Attr {
+		key := strings.ToLower(strings.TrimSpace(a.Key))
+		if key == "" || seen[key] || strings.HasPrefix(key, "on") || key == "srcdoc" {
+			continue
+		}
+		val := strings.TrimSpace(a.Val)
+		if urlAttributes[key] && !isSafeURL(val) {
+			continue
+		}
+		if key == "style" && strings.Contains(strings.ToLower(val), "expression(") {
+			continue
+		}
+		if key == "target" && strings.EqualFold(val, "_blank") {
+			blankTarget = true
+		}
+		seen[key] = true
+		attrs = append(attrs, html.Attribute{Key: key, Val: val})
+	}
+	if tok.DataAtom == atom.A && blankTarget {
+		rel := html.Attribute{Key: "rel", Val: "noopener noreferrer"}
+		replaced := false
+		for i := range attrs {
+			if attrs[i].Key == "rel" {
+				attrs[i] = rel
+				replaced = true
+			}
+		}
+		if !replaced {
+			attrs = append(attrs, rel)
+		}
+	}
+	return attrs
+}
+
+// isSafeURL 只允許 http(s)、mailto、tel 與相對路徑
+func isSafeURL(raw string) bool {
+	// 去除控制字元與空白，避免 "java\tscript:" 之類的繞過
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, raw)
+	u, err := url.Parse(cleaned)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto", "tel":
+		return true
+	}
+	return false
+}
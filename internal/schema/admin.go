@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"context"
+
+	"go-story/internal/data"
+
+	"github.com/graphql-go/graphql"
+)
+
+type adminKey struct{}
+
+// WithAdmin marks ctx as belonging to a request authenticated with the
+// admin token, which the admin mutations require.
+func WithAdmin(ctx context.Context) context.Context {
+	return context.WithValue(ctx, adminKey{}, true)
+}
+
+// IsAdmin reports whether ctx was marked by WithAdmin.
+func IsAdmin(ctx context.Context) bool {
+	admin, _ := ctx.Value(adminKey{}).(bool)
+	return admin
+}
+
+// addAdminMutations 加入 admin 用的 mutation：編輯回報頁面過期時，重新查詢 DB 並覆寫該 entity 的 cache
+func addAdminMutations(fields graphql.Fields, repo *data.Repo, postType, topicType *graphql.Object) {
+	slugArgs := graphql.FieldConfigArgument{
+		"slug": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+	}
+	fields["refreshPost"] = &graphql.Field{
+		Type:        postType,
+		Description: "Re-read a post from the database and rewrite its cache entries (admin only)",
+		Args:        slugArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if !IsAdmin(p.Context) {
+				return nil, inputErrorf("refreshPost requires the admin token")
+			}
+			return repo.RefreshPost(p.Context, p.Args["slug"].(string))
+		},
+	}
+	fields["refreshTopic"] = &graphql.Field{
+		Type:        topicType,
+		Description: "Re-read a topic from the database and rewrite its cache entries (admin only)",
+		Args:        slugArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if !IsAdmin(p.Context) {
+				return nil, inputErrorf("refreshTopic requires the admin token")
+			}
+			return repo.RefreshTopic(p.Context, p.Args["slug"].(string))
+		},
+	}
+}
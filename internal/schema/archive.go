@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"go-story/internal/data"
+
+	"github.com/graphql-go/graphql"
+)
+
+// postsArchiveField 建立 postsArchive 查詢，供依年月瀏覽的彙整頁
+func postsArchiveField(repo *data.Repo, opts Options, postType *graphql.Object) *graphql.Field {
+	return &graphql.Field{
+		Type:        graphql.NewList(postType),
+		Description: "Published posts of a month (or of a whole year without month), newest first; months follow the output time zone",
+		Args: graphql.FieldConfigArgument{
+			"year":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"month":       &graphql.ArgumentConfig{Type: graphql.Int, Description: "1-12; omit for the whole year"},
+			"sectionSlug": &graphql.ArgumentConfig{Type: graphql.String},
+			"take":        &graphql.ArgumentConfig{Type: graphql.Int},
+			"skip":        &graphql.ArgumentConfig{Type: graphql.Int},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			year := asInt(p.Args["year"])
+			if year < 1 || year > 9999 {
+				return nil, inputErrorf("invalid year %d: must be between 1 and 9999", year)
+			}
+			month := 0
+			if raw, ok := p.Args["month"]; ok && raw != nil {
+				month = asInt(raw)
+				if month < 1 || month > 12 {
+					return nil, inputErrorf("invalid month %d: must be between 1 and 12", month)
+				}
+			}
+			take, skip, err := parsePagination(p.Args, opts)
+			if err != nil {
+				return nil, err
+			}
+			sectionSlug, _ := p.Args["sectionSlug"].(string)
+			return repo.QueryPostsArchive(p.Context, year, month, sectionSlug, take, skip)
+		},
+	}
+}
+
+// archiveMonthsField 建立 archiveMonths 查詢，列出有已發布文章的月份
+func archiveMonthsField(repo *data.Repo) *graphql.Field {
+	monthType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ArchiveMonth",
+		Fields: graphql.Fields{
+			"year":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"month": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"count": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Number of published posts"},
+		},
+	})
+	return &graphql.Field{
+		Type:        graphql.NewList(monthType),
+		Description: "Months with published posts, newest first, in the output time zone",
+		Args: graphql.FieldConfigArgument{
+			"sectionSlug": &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			sectionSlug, _ := p.Args["sectionSlug"].(string)
+			return repo.QueryArchiveMonths(p.Context, sectionSlug)
+		},
+	}
+}
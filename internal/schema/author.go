@@ -0,0 +1,81 @@
+package schema
+
+import (
+	"go-story/internal/data"
+
+	"github.com/graphql-go/graphql"
+)
+
+// authorField 建立 author 查詢，作者頁一次取得 Contact、各角色的文章與總數，取代逐一查詢六種角色
+func authorField(repo *data.Repo, opts Options, contactType, postType *graphql.Object) *graphql.Field {
+	whereInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "AuthorWhereUniqueInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":   &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"slug": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	authorType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Author",
+		Fields: graphql.Fields{
+			"contact": &graphql.Field{
+				Type: contactType,
+				// 來源即為 QueryAuthor 回傳的 Contact
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+			"posts": &graphql.Field{
+				Type:        graphql.NewList(postType),
+				Description: "Published posts crediting the contact in any role, newest first",
+				Args: graphql.FieldConfigArgument{
+					"take": &graphql.ArgumentConfig{Type: graphql.Int},
+					"skip": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					contact, ok := p.Source.(*data.Contact)
+					if !ok || contact == nil {
+						return nil, nil
+					}
+					take, skip, err := parsePagination(p.Args, opts)
+					if err != nil {
+						return nil, err
+					}
+					return repo.QueryAuthorPosts(p.Context, contact.ID, take, skip)
+				},
+			},
+			"postsCount": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					contact, ok := p.Source.(*data.Contact)
+					if !ok || contact == nil {
+						return nil, nil
+					}
+					return repo.QueryAuthorPostsCount(p.Context, contact.ID)
+				},
+			},
+		},
+	})
+
+	return &graphql.Field{
+		Type:        authorType,
+		Description: "A contact with the posts crediting them as writer, photographer, camera man, designer, engineer or vocal",
+		Args: graphql.FieldConfigArgument{
+			"where": &graphql.ArgumentConfig{Type: graphql.NewNonNull(whereInput)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			where, err := data.DecodeAuthorWhereUnique(p.Args["where"])
+			if err != nil {
+				return nil, err
+			}
+			if where == nil || (where.ID == nil) == (where.Slug == nil) {
+				return nil, inputErrorf("author where must specify exactly one of id or slug")
+			}
+			contact, err := repo.QueryAuthor(p.Context, where)
+			if err != nil || contact == nil {
+				return nil, err
+			}
+			return contact, nil
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+)
+
+// batchLoader 將同一個請求中、參數相同的巢狀 resolver（例如 post.tags 底下每個 Tag.posts）
+// 合併為一次查詢：resolver 先登記 key 並回傳 thunk，graphql-go 在同一層的欄位都解析完後
+// 才執行 thunk，第一個執行的 thunk 以所有已登記的 key 查詢一次，其餘共用結果
+type batchLoader[V any] struct {
+	mu      sync.Mutex
+	pending map[batchKey]*batch[V]
+}
+
+// batchKey 以請求的 context 與欄位參數區分批次；同一個請求的 resolver 共用同一個 context
+type batchKey struct {
+	ctx  context.Context
+	args string
+}
+
+type batch[V any] struct {
+	once   sync.Once
+	keys   []string
+	seen   map[string]bool
+	result map[string]V
+	err    error
+}
+
+func newBatchLoader[V any]() *batchLoader[V] {
+	return &batchLoader[V]{pending: map[batchKey]*batch[V]{}}
+}
+
+// load 登記 key 並回傳 thunk；fetch 以批次內所有的 key 查詢，同一批次只會呼叫一次
+func (l *batchLoader[V]) load(p graphql.ResolveParams, key string, fetch func(ctx context.Context, keys []string) (map[string]V, error)) func() (interface{}, error) {
+	args, _ := json.Marshal(p.Args)
+	bk := batchKey{ctx: p.Context, args: string(args)}
+	l.mu.Lock()
+	b := l.pending[bk]
+	if b == nil {
+		b = &batch[V]{seen: map[string]bool{}}
+		l.pending[bk] = b
+	}
+	if !b.seen[key] {
+		b.seen[key] = true
+		b.keys = append(b.keys, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		b.once.Do(func() {
+			// 開始查詢後的登記改由新的批次處理
+			l.mu.Lock()
+			if l.pending[bk] == b {
+				delete(l.pending, bk)
+			}
+			keys := b.keys
+			l.mu.Unlock()
+			b.result, b.err = fetch(p.Context, keys)
+		})
+		if b.err != nil {
+			return nil, b.err
+		}
+		return b.result[key], nil
+	}
+}
+
+// thenResolved 對 resolver 的結果套用 fn；結果為 batchLoader 的 thunk 時，於 thunk 執行後才套用，
+// 供包裝 resolver 的 surrogate key、錯誤回報等使用
+func thenResolved(v interface{}, err error, fn func(interface{}, error) (interface{}, error)) (interface{}, error) {
+	if thunk, ok := v.(func() (interface{}, error)); ok && err == nil {
+		return func() (interface{}, error) {
+			return fn(thunk())
+		}, nil
+	}
+	return fn(v, err)
+}
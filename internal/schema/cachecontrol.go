@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"strings"
+
+	"go-story/internal/cachecontrol"
+
+	"github.com/graphql-go/graphql"
+)
+
+// cacheControlHints 為各欄位的 @cacheControl(maxAge, scope)，單位為秒；
+// schema 以程式碼定義，無法在欄位上標註 directive，因此集中於此。
+// 未列出的 root 欄位使用 Options.DefaultMaxAge，其餘欄位沿用上層
+var cacheControlHints = map[string]map[string]cachecontrol.Hint{
+	"Query": {
+		"posts":                   {MaxAge: 60},
+		"postsCount":              {MaxAge: 60},
+		"postsBySlugs":            {MaxAge: 60},
+		"post":                    {MaxAge: 60},
+		"homepage":                {MaxAge: 60},
+		"sectionPage":             {MaxAge: 60},
+		"editorChoices":           {MaxAge: 60},
+		"externals":               {MaxAge: 60},
+		"externalsCount":          {MaxAge: 60},
+		"postsCountBySection":     {MaxAge: 300},
+		"externalsCountByPartner": {MaxAge: 300},
+		"topics":                  {MaxAge: 300},
+		"topicsCount":             {MaxAge: 300},
+		"topic":                   {MaxAge: 300},
+		"categories":              {MaxAge: 300},
+		"navigation":              {MaxAge: 3600},
+		"author":                  {MaxAge: 300},
+		"tag":                     {MaxAge: 300},
+		"partner":                 {MaxAge: 60},
+		"postsArchive":            {MaxAge: 300},
+		"archiveMonths":           {MaxAge: 3600},
+		"audios":                  {MaxAge: 300},
+		"events":                  {MaxAge: 300},
+		"tagSuggest":              {MaxAge: 3600},
+		"contactSearch":           {MaxAge: 3600},
+		// 供同步程式增量讀取，不可快取
+		"changedStories": {MaxAge: 0},
+	},
+	"Post": {
+		"viewsCount": {MaxAge: 10},
+	},
+}
+
+var cacheControlScopeEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "CacheControlScope",
+	Values: graphql.EnumValueConfigMap{
+		"PUBLIC":  &graphql.EnumValueConfig{Value: string(cachecontrol.Public)},
+		"PRIVATE": &graphql.EnumValueConfig{Value: string(cachecontrol.Private)},
+	},
+})
+
+// cacheControlDirective 只用於 introspection 說明欄位的快取語意，查詢中不可使用
+var cacheControlDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:        "cacheControl",
+	Description: "Cache hint of a field. A response is cached for the smallest maxAge of its fields.",
+	Locations:   []string{graphql.DirectiveLocationFieldDefinition, graphql.DirectiveLocationObject},
+	Args: graphql.FieldConfigArgument{
+		"maxAge": &graphql.ArgumentConfig{Type: graphql.Int},
+		"scope":  &graphql.ArgumentConfig{Type: cacheControlScopeEnum},
+	},
+})
+
+// applyCacheControl 包裝有 hint 的欄位，執行時將 hint 寫入 context 中的 cachecontrol.Policy。
+// mutation 的 root 欄位一律視為 maxAge 0，回應不會被快取
+func applyCacheControl(s graphql.Schema, defaultMaxAge int) {
+	roots := map[*graphql.Object]cachecontrol.Hint{s.QueryType(): {MaxAge: defaultMaxAge}}
+	if s.MutationType() != nil {
+		roots[s.MutationType()] = cachecontrol.Hint{MaxAge: 0}
+	}
+	for name, t := range s.TypeMap() {
+		obj, ok := t.(*graphql.Object)
+		if !ok || strings.HasPrefix(name, "__") {
+			continue
+		}
+		rootHint, isRoot := roots[obj]
+		for fieldName, def := range obj.Fields() {
+			hint, ok := cacheControlHints[name][fieldName]
+			if !ok && !isRoot {
+				continue
+			}
+			if !ok || obj == s.MutationType() {
+				hint = rootHint
+			}
+			resolve := def.Resolve
+			if resolve == nil {
+				resolve = graphql.DefaultResolveFn
+			}
+			def.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+				cachecontrol.FromContext(p.Context).Restrict(hint)
+				return resolve(p)
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package schema
+
+import (
+	"time"
+
+	"go-story/internal/data"
+
+	"github.com/graphql-go/graphql"
+)
+
+// changedStoriesField 建立 changedStories 查詢，供下游 cache 與靜態頁產生器增量同步
+func changedStoriesField(repo *data.Repo, opts Options, postType, externalType, topicType *graphql.Object, dateTimeScalar *graphql.Scalar) *graphql.Field {
+	kindEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "StoryChangeKind",
+		Values: graphql.EnumValueConfigMap{
+			data.ChangeKindPost:     &graphql.EnumValueConfig{Value: data.ChangeKindPost},
+			data.ChangeKindExternal: &graphql.EnumValueConfig{Value: data.ChangeKindExternal},
+			data.ChangeKindTopic:    &graphql.EnumValueConfig{Value: data.ChangeKindTopic},
+		},
+	})
+	changeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "StoryChange",
+		Fields: graphql.Fields{
+			"kind":      &graphql.Field{Type: graphql.NewNonNull(kindEnum)},
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"slug":      &graphql.Field{Type: graphql.String},
+			"updatedAt": &graphql.Field{Type: dateTimeScalar},
+			"deleted": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Tombstone: the entity is no longer published and should be removed downstream",
+			},
+			"post":     &graphql.Field{Type: postType},
+			"external": &graphql.Field{Type: externalType},
+			"topic":    &graphql.Field{Type: topicType},
+			"cursor": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "Pass as cursor to continue after this change",
+			},
+		},
+	})
+	resultType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ChangedStories",
+		Fields: graphql.Fields{
+			"changes": &graphql.Field{Type: graphql.NewList(changeType)},
+			"cursor": &graphql.Field{
+				Type:        graphql.String,
+				Description: "Cursor of the last change (or the given cursor when nothing changed); store it for the next sync",
+			},
+			"hasMore": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+
+	return &graphql.Field{
+		Type:        resultType,
+		Description: "Posts, externals and topics updated after a watermark, oldest first, including tombstones for unpublished items",
+		Args: graphql.FieldConfigArgument{
+			"since":  &graphql.ArgumentConfig{Type: dateTimeScalar, Description: "Watermark; required unless cursor is given"},
+			"take":   &graphql.ArgumentConfig{Type: graphql.Int},
+			"cursor": &graphql.ArgumentConfig{Type: graphql.String, Description: "Continue after a previous change; takes precedence over since"},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			take := opts.MaxTake
+			if raw, ok := p.Args["take"]; ok && raw != nil {
+				take = asInt(raw)
+			}
+			if take < 1 || take > opts.MaxTake {
+				return nil, inputErrorf("invalid take %d: must be between 1 and %d", take, opts.MaxTake)
+			}
+
+			var (
+				since  time.Time
+				after  *data.ChangeCursor
+				cursor string
+			)
+			if raw, _ := p.Args["cursor"].(string); raw != "" {
+				c, err := data.ParseChangeCursor(raw)
+				if err != nil {
+					return nil, inputErrorf("%v", err)
+				}
+				after, cursor = &c, raw
+			} else if raw, _ := p.Args["since"].(string); raw != "" {
+				t, err := time.Parse(time.RFC3339Nano, raw)
+				if err != nil {
+					return nil, inputErrorf("invalid since %q", raw)
+				}
+				since = t
+			} else {
+				return nil, inputErrorf("since or cursor is required")
+			}
+
+			// 多取一筆判斷是否還有下一頁
+			changes, err := repo.QueryChangedStories(p.Context, since, after, take+1)
+			if err != nil {
+				return nil, err
+			}
+			hasMore := len(changes) > take
+			if hasMore {
+				changes = changes[:take]
+			}
+			if len(changes) > 0 {
+				cursor = changes[len(changes)-1].Cursor
+			}
+			result := map[string]interface{}{
+				"changes": changes,
+				"hasMore": hasMore,
+			}
+			if cursor != "" {
+				result["cursor"] = cursor
+			}
+			return result, nil
+		},
+	}
+}
@@ -0,0 +1,18 @@
+package schema
+
+import "github.com/graphql-go/graphql"
+
+// deferDirective 讓查詢可以標註 @defer；graphql-go 本身不支援增量回傳，
+// 由 server 拆分查詢後以 multipart/mixed 分段輸出，其他情況忽略此 directive 一次回傳
+var deferDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:        "defer",
+	Description: "Delivers the fragment after the rest of the response when the client accepts multipart/mixed.",
+	Locations:   []string{graphql.DirectiveLocationFragmentSpread, graphql.DirectiveLocationInlineFragment},
+	Args: graphql.FieldConfigArgument{
+		"if": &graphql.ArgumentConfig{
+			Type:         graphql.Boolean,
+			DefaultValue: true,
+		},
+		"label": &graphql.ArgumentConfig{Type: graphql.String},
+	},
+})
@@ -0,0 +1,33 @@
+package schema
+
+import "github.com/graphql-go/graphql"
+
+// deprecatedFields 列出只為了與舊版 Keystone 相容而保留的欄位及其棄用原因，
+// 會出現在 introspection 中，讓 client codegen 提醒使用者改用新欄位
+var deprecatedFields = map[string]map[string]string{
+	"Post": {
+		"sectionsInInputOrder":   "Use `sections`; the order is the same.",
+		"categoriesInInputOrder": "Use `categories`; the order is the same.",
+		"writersInInputOrder":    "Use `writers`; the order is the same.",
+		"relatedsInInputOrder":   "Use `relateds`; the order is the same.",
+	},
+	"Topic": {
+		"slideshow_imagesInInputOrder": "Use `slideshow_images`; the order is the same.",
+	},
+}
+
+// applyDeprecations 將 deprecatedFields 的原因寫入 schema 的欄位定義
+func applyDeprecations(s graphql.Schema) {
+	for typeName, fields := range deprecatedFields {
+		obj, ok := s.Type(typeName).(*graphql.Object)
+		if !ok {
+			continue
+		}
+		defs := obj.Fields()
+		for name, reason := range fields {
+			if def, ok := defs[name]; ok {
+				def.DeprecationReason = reason
+			}
+		}
+	}
+}
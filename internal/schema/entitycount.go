@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EntityCounts counts the distinct entities of each type resolved while
+// executing one request, for the request log.
+type EntityCounts struct {
+	mu  sync.Mutex
+	ids map[string]map[string]bool
+}
+
+type entityCountsKey struct{}
+
+// WithEntityCounts returns a context counting the entities resolved with it
+// into the returned EntityCounts. Entities are only counted when the schema
+// was built with Options.EntityCounts.
+func WithEntityCounts(ctx context.Context) (context.Context, *EntityCounts) {
+	c := &EntityCounts{ids: map[string]map[string]bool{}}
+	return context.WithValue(ctx, entityCountsKey{}, c), c
+}
+
+// entityCountsFrom 回傳 context 中的 EntityCounts，沒有時為 nil
+func entityCountsFrom(ctx context.Context) *EntityCounts {
+	if ctx == nil {
+		return nil
+	}
+	c, _ := ctx.Value(entityCountsKey{}).(*EntityCounts)
+	return c
+}
+
+func (c *EntityCounts) add(typeName, id string) {
+	if c == nil || id == "" {
+		return
+	}
+	typeName = strings.ToLower(typeName)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ids[typeName] == nil {
+		c.ids[typeName] = map[string]bool{}
+	}
+	c.ids[typeName][id] = true
+}
+
+// String returns the counts sorted by type, e.g. "photo=8,post=12".
+func (c *EntityCounts) String() string {
+	if c == nil {
+		return ""
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	parts := make([]string, 0, len(c.ids))
+	for typeName, ids := range c.ids {
+		parts = append(parts, typeName+"="+strconv.Itoa(len(ids)))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
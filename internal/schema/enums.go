@@ -0,0 +1,108 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// enumDef 描述一個對應 Keystone select 欄位的 enum，Values 為 DB 中的值，
+// GraphQL 名稱由 enumName 產生
+type enumDef struct {
+	Name   string
+	Values []string
+	// Default 為 DB 值為空時輸出的值
+	Default string
+}
+
+// Topic 欄位對應 Keystone 的 select 選項
+var (
+	topicStateEnumDef = enumDef{
+		Name:    "TopicStateType",
+		Values:  []string{"draft", "published", "scheduled"},
+		Default: "draft",
+	}
+	topicTypeEnumDef = enumDef{
+		Name:    "TopicTypeType",
+		Values:  []string{"list", "timeline", "group", "portraitWall", "wide"},
+		Default: "list",
+	}
+	topicStyleEnumDef = enumDef{
+		Name:    "TopicStyleType",
+		Values:  []string{"feature", "wide"},
+		Default: "feature",
+	}
+	topicTitleStyleEnumDef = enumDef{
+		Name:    "TopicTitleStyleType",
+		Values:  []string{"feature", "wide"},
+		Default: "feature",
+	}
+)
+
+// Post 欄位對應 Keystone 的 select 選項
+var (
+	postStateEnumDef = enumDef{
+		Name:    "PostStateType",
+		Values:  []string{"published", "draft", "scheduled", "archived", "invisible"},
+		Default: "draft",
+	}
+	postStyleEnumDef = enumDef{
+		Name:    "PostStyleType",
+		Values:  []string{"article", "wide", "projects", "photography", "script", "campaign", "readr"},
+		Default: "article",
+	}
+)
+
+// newEnum 建立 GraphQL enum，value 保持 DB 的字串，方便沿用 StringFilter 解析
+func newEnum(def enumDef) *graphql.Enum {
+	values := graphql.EnumValueConfigMap{}
+	for _, v := range def.Values {
+		values[enumName(v)] = &graphql.EnumValueConfig{Value: v}
+	}
+	return graphql.NewEnum(graphql.EnumConfig{
+		Name:   def.Name,
+		Values: values,
+	})
+}
+
+// newEnumFilter 建立與 StringFilter 相同結構、但以 enum 驗證輸入的 filter
+func newEnumFilter(name string, enum *graphql.Enum) *graphql.InputObject {
+	fields := graphql.InputObjectConfigFieldMap{}
+	filter := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:   name,
+		Fields: fields,
+	})
+	fields["equals"] = &graphql.InputObjectFieldConfig{Type: enum}
+	fields["in"] = &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(enum))}
+	fields["notIn"] = &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(enum))}
+	fields["not"] = &graphql.InputObjectFieldConfig{Type: filter}
+	return filter
+}
+
+// enumField 輸出 enum 欄位，值為空時使用預設值
+func enumField(enum *graphql.Enum, def enumDef, get func(p graphql.ResolveParams) string) *graphql.Field {
+	return &graphql.Field{
+		Type: enum,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			v := get(p)
+			if v == "" {
+				v = def.Default
+			}
+			return v, nil
+		},
+	}
+}
+
+// enumName 將 DB 值轉為合法的 GraphQL enum 名稱（非英數字元改為底線）
+func enumName(v string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, v)
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"go-story/internal/errreport"
+
+	"github.com/graphql-go/graphql"
+)
+
+// inputError 為查詢參數不合法等 client 端錯誤，不送到 Error Reporting
+type inputError struct{ msg string }
+
+func (e *inputError) Error() string { return e.msg }
+
+func inputErrorf(format string, args ...interface{}) error {
+	return &inputError{msg: fmt.Sprintf(format, args...)}
+}
+
+// applyErrorReporting 包裝所有自訂 resolver，將回傳的錯誤（多半來自 repo）與 panic 送到 reporter
+func applyErrorReporting(s graphql.Schema, reporter *errreport.Reporter) {
+	for name, t := range s.TypeMap() {
+		obj, ok := t.(*graphql.Object)
+		if !ok || strings.HasPrefix(name, "__") {
+			continue
+		}
+		for fieldName, def := range obj.Fields() {
+			if def.Resolve == nil {
+				continue
+			}
+			resolve := def.Resolve
+			where := name + "." + fieldName
+			def.Resolve = func(p graphql.ResolveParams) (v interface{}, err error) {
+				defer func() {
+					if rec := recover(); rec != nil {
+						reporter.Report(p.Context, fmt.Errorf("%s: panic: %v", where, rec), debug.Stack())
+						panic(rec)
+					}
+				}()
+				v, err = resolve(p)
+				return thenResolved(v, err, func(v interface{}, err error) (interface{}, error) {
+					var inErr *inputError
+					if err != nil && !errors.As(err, &inErr) {
+						reporter.Report(p.Context, fmt.Errorf("%s: %w", where, err), nil)
+					}
+					return v, err
+				})
+			}
+		}
+	}
+}
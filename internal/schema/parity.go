@@ -0,0 +1,110 @@
+package schema
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// keystoneNullFields 列出舊版 Keystone 在沒有資料時回傳 null 的欄位，
+// 我們的 struct 會填入零值（空字串、空 struct、空 map），parity 模式下改回 null
+var keystoneNullFields = map[string][]string{
+	"Post": {
+		"publishedDate", "updatedAt",
+		"heroVideo", "heroImage", "og_image", "heroAudio", "audio",
+		"relatedsOne", "relatedsTwo", "topics",
+		"brief", "trimmedContent", "content",
+	},
+	"Topic": {
+		"createdAt", "updatedAt",
+		"heroImage", "og_image",
+		"brief", "manualOrderOfSlideshowImages",
+	},
+	"Photo":        {"imageFile", "resized", "resizedWebp"},
+	"Video":        {"heroImage"},
+	"Audio":        {"heroImage", "createdAt", "updatedAt"},
+	"External":     {"publishedDate", "updatedAt", "partner"},
+	"EditorChoice": {"publishedDate", "createdAt", "updatedAt", "choices"},
+	"Event":        {"startDate", "endDate", "publishedDate", "createdAt", "updatedAt", "heroImage"},
+}
+
+// keystoneListTypes 中的 list 欄位在 Keystone 一律回傳 []，不會是 null
+var keystoneListTypes = []string{"Post", "Topic", "Category", "External"}
+
+// applyKeystoneParity 包裝既有 resolver，重現舊版 Keystone 的 null / [] 語意
+func applyKeystoneParity(s graphql.Schema) {
+	for typeName, fields := range keystoneNullFields {
+		obj, ok := s.Type(typeName).(*graphql.Object)
+		if !ok {
+			continue
+		}
+		defs := obj.Fields()
+		for _, name := range fields {
+			if def, ok := defs[name]; ok {
+				def.Resolve = wrapResolve(def.Resolve, func(v interface{}) interface{} {
+					if isEmptyValue(v) {
+						return nil
+					}
+					return v
+				})
+			}
+		}
+	}
+
+	for _, typeName := range keystoneListTypes {
+		obj, ok := s.Type(typeName).(*graphql.Object)
+		if !ok {
+			continue
+		}
+		for _, def := range obj.Fields() {
+			if _, ok := def.Type.(*graphql.List); !ok {
+				continue
+			}
+			def.Resolve = wrapResolve(def.Resolve, func(v interface{}) interface{} {
+				if v == nil {
+					return []interface{}{}
+				}
+				if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+					return []interface{}{}
+				}
+				return v
+			})
+		}
+	}
+}
+
+func wrapResolve(resolve graphql.FieldResolveFn, transform func(interface{}) interface{}) graphql.FieldResolveFn {
+	if resolve == nil {
+		resolve = graphql.DefaultResolveFn
+	}
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v, err := resolve(p)
+		return thenResolved(v, err, func(v interface{}, err error) (interface{}, error) {
+			if err != nil {
+				return v, err
+			}
+			return transform(v), nil
+		})
+	}
+}
+
+// isEmptyValue 判斷值是否為 nil、零值 struct、空字串或空 map
+func isEmptyValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return true
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice:
+		return rv.Len() == 0
+	case reflect.String, reflect.Struct:
+		return rv.IsZero()
+	}
+	return false
+}
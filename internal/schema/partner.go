@@ -0,0 +1,86 @@
+package schema
+
+import (
+	"go-story/internal/data"
+
+	"github.com/graphql-go/graphql"
+)
+
+// partnerField 建立 partner 查詢，合作夥伴頁以 partner(where: { slug }) 一次取得 partner 與其文章
+func partnerField(repo *data.Repo, partnerType *graphql.Object) *graphql.Field {
+	whereInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "PartnerWhereUniqueInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":   &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"slug": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	return &graphql.Field{
+		Type: partnerType,
+		Args: graphql.FieldConfigArgument{
+			"where": &graphql.ArgumentConfig{Type: graphql.NewNonNull(whereInput)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			where, err := data.DecodePartnerWhereUnique(p.Args["where"])
+			if err != nil {
+				return nil, err
+			}
+			if where == nil || (where.ID == nil) == (where.Slug == nil) {
+				return nil, inputErrorf("partner where must specify exactly one of id or slug")
+			}
+			partner, err := repo.QueryPartner(p.Context, where)
+			if err != nil || partner == nil {
+				return nil, err
+			}
+			return partner, nil
+		},
+	}
+}
+
+// addPartnerExternalsFields 在 Partner 加上 externals / externalsCount；partnerType 早於 externalType
+// 建立，因此另外加入。以 partner slug 篩選 QueryExternals，沿用其 cache
+func addPartnerExternalsFields(partnerType *graphql.Object, repo *data.Repo, opts Options, externalType *graphql.Object, externalOrderByInput *graphql.InputObject) {
+	partnerWhere := func(src interface{}) *data.ExternalWhereInput {
+		partner, ok := src.(*data.Partner)
+		if !ok || partner == nil || partner.Slug == "" {
+			return nil
+		}
+		// 明確限定已發布，externals 與 externalsCount 不依賴 Repo 的預設條件也能保持一致
+		slug, published := partner.Slug, "published"
+		return &data.ExternalWhereInput{
+			State:   &data.StringFilter{Equals: &published},
+			Partner: &data.PartnerWhereInput{Slug: &data.StringFilter{Equals: &slug}},
+		}
+	}
+	partnerType.AddFieldConfig("externals", &graphql.Field{
+		Type:        graphql.NewList(externalType),
+		Description: "Published externals of this partner, newest first unless orderBy is given",
+		Args: graphql.FieldConfigArgument{
+			"take":    &graphql.ArgumentConfig{Type: graphql.Int},
+			"skip":    &graphql.ArgumentConfig{Type: graphql.Int},
+			"orderBy": &graphql.ArgumentConfig{Type: graphql.NewList(externalOrderByInput)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			where := partnerWhere(p.Source)
+			if where == nil {
+				return nil, nil
+			}
+			orders := parseOrderRules(p.Args["orderBy"])
+			take, skip, err := parsePagination(p.Args, opts)
+			if err != nil {
+				return nil, err
+			}
+			return repo.QueryExternals(p.Context, where, orders, take, skip)
+		},
+	})
+	partnerType.AddFieldConfig("externalsCount", &graphql.Field{
+		Type: graphql.Int,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			where := partnerWhere(p.Source)
+			if where == nil {
+				return nil, nil
+			}
+			return repo.QueryExternalsCount(p.Context, where)
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package schema
+
+import (
+	"context"
+
+	"go-story/internal/data"
+
+	"github.com/graphql-go/graphql"
+)
+
+// relatedPosts 為 Tag、Section、Category 依 id 批次查詢文章與數量的 repo 方法
+type relatedPosts struct {
+	// description 為 posts 欄位的說明
+	description string
+	// idOf 取得來源物件的 id，來源不符時回傳空字串
+	idOf  func(src interface{}) string
+	posts func(ctx context.Context, ids []string, where *data.PostWhereInput, orders []data.OrderRule, take, skip int) (map[string][]data.Post, error)
+	count func(ctx context.Context, ids []string, where *data.PostWhereInput) (map[string]int, error)
+}
+
+// addRelatedPostsFields 在 obj 加上 posts / postsCount；這些型別早於 postType 建立，因此另外加入。
+// 同一層列表中的多個物件（例如 post.tags、categories）以 batchLoader 合併為一次查詢
+func addRelatedPostsFields(obj *graphql.Object, rel relatedPosts, opts Options, postType *graphql.Object, postWhereInputType *graphql.InputObject, postOrderByInput *graphql.InputObject) {
+	postsLoader := newBatchLoader[[]data.Post]()
+	countLoader := newBatchLoader[int]()
+
+	obj.AddFieldConfig("posts", &graphql.Field{
+		Type:        graphql.NewList(postType),
+		Description: rel.description,
+		Args: graphql.FieldConfigArgument{
+			"where":   &graphql.ArgumentConfig{Type: postWhereInputType},
+			"orderBy": &graphql.ArgumentConfig{Type: graphql.NewList(postOrderByInput)},
+			"take":    &graphql.ArgumentConfig{Type: graphql.Int},
+			"skip":    &graphql.ArgumentConfig{Type: graphql.Int},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			id := rel.idOf(p.Source)
+			if id == "" {
+				return nil, nil
+			}
+			where, err := data.DecodePostWhere(p.Args["where"])
+			if err != nil {
+				return nil, err
+			}
+			orders := parseOrderRules(p.Args["orderBy"])
+			take, skip, err := parsePagination(p.Args, opts)
+			if err != nil {
+				return nil, err
+			}
+			return postsLoader.load(p, id, func(ctx context.Context, ids []string) (map[string][]data.Post, error) {
+				return rel.posts(ctx, ids, where, orders, take, skip)
+			}), nil
+		},
+	})
+	obj.AddFieldConfig("postsCount", &graphql.Field{
+		Type: graphql.Int,
+		Args: graphql.FieldConfigArgument{
+			"where": &graphql.ArgumentConfig{Type: postWhereInputType},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			id := rel.idOf(p.Source)
+			if id == "" {
+				return nil, nil
+			}
+			where, err := data.DecodePostWhere(p.Args["where"])
+			if err != nil {
+				return nil, err
+			}
+			return countLoader.load(p, id, func(ctx context.Context, ids []string) (map[string]int, error) {
+				return rel.count(ctx, ids, where)
+			}), nil
+		},
+	})
+}
+
+func tagID(src interface{}) string {
+	if t := normalizeTag(src); t != nil {
+		return t.ID
+	}
+	return ""
+}
+
+func sectionID(src interface{}) string {
+	switch s := src.(type) {
+	case data.Section:
+		return s.ID
+	case *data.Section:
+		if s != nil {
+			return s.ID
+		}
+	}
+	return ""
+}
+
+func categoryID(src interface{}) string {
+	switch c := src.(type) {
+	case data.Category:
+		return c.ID
+	case *data.Category:
+		if c != nil {
+			return c.ID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+package schema
+
+import (
+	"strings"
+	"time"
+
+	"go-story/internal/metrics"
+
+	"github.com/graphql-go/graphql"
+)
+
+var resolverDuration = metrics.NewHistogram(
+	"go_story_graphql_resolver_duration_seconds",
+	"Time spent in GraphQL field resolvers, by parent type and field.",
+	nil,
+	"parent_type", "field",
+)
+
+// applyResolverMetrics 記錄每個自訂 resolver 的耗時（例如 Query.posts、Topic.posts、Post.heroImage），
+// 用來區分延遲是在 root 查詢還是巢狀的關聯組裝。使用預設 resolver 的純欄位不計時
+func applyResolverMetrics(s graphql.Schema) {
+	for name, t := range s.TypeMap() {
+		obj, ok := t.(*graphql.Object)
+		if !ok || strings.HasPrefix(name, "__") {
+			continue
+		}
+		for fieldName, def := range obj.Fields() {
+			if def.Resolve == nil {
+				continue
+			}
+			resolve := def.Resolve
+			typeName, field := name, fieldName
+			def.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+				start := time.Now()
+				defer func() {
+					resolverDuration.Observe(time.Since(start).Seconds(), typeName, field)
+				}()
+				return resolve(p)
+			}
+		}
+	}
+}
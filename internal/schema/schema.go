@@ -1,17 +1,75 @@
 package schema
 
 import (
+	"encoding/json"
 	"fmt"
+	"go-story/internal/apidata"
+	"go-story/internal/cachecontrol"
 	"go-story/internal/data"
+	"go-story/internal/errreport"
+	"go-story/internal/sanitize"
 	"strconv"
+	"time"
 
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/mitchellh/mapstructure"
 )
 
+// Options tunes schema behaviour; zero values use the defaults.
+type Options struct {
+	// MaxTake 單次查詢 take 上限，未指定 take 或 take=-1 時也以此為上限，預設 100
+	MaxTake int
+	// MaxSkip skip 上限，預設 10000
+	MaxSkip int
+	// KeystoneParity 開啟後重現舊版 Keystone 的 null / [] 回傳語意
+	KeystoneParity bool
+	// SanitizeExternalHTML 開啟後 External.content 會移除 script、未允許的 iframe 等內容
+	SanitizeExternalHTML bool
+	// EmbedHosts 過濾時保留的 iframe 網域，未設定時使用 sanitize.DefaultEmbedHosts
+	EmbedHosts []string
+	// OgImageFallback 開啟後 og_image 為 null 時改回傳 heroImage
+	OgImageFallback bool
+	// Reporter 設定後會將 resolver 的錯誤與 panic 送到 Error Reporting
+	Reporter *errreport.Reporter
+	// HomepageSections homepage 查詢的 section slug，依此順序輸出
+	HomepageSections []string
+	// HomepagePostsPerSection homepage 每個 section 的文章數，預設 6
+	HomepagePostsPerSection int
+	// SectionPartners sectionPage 併入外稿的 partner slug，以 section slug 為 key
+	SectionPartners map[string][]string
+	// ResolverMetrics 開啟後記錄每個 resolver 的耗時 histogram
+	ResolverMetrics bool
+	// SurrogateKeys 開啟後將回傳的 entity id 記錄到 context 中的 surrogate.Keys
+	SurrogateKeys bool
+	// EntityCounts 開啟後將回傳的 entity 數量記錄到 context 中的 EntityCounts，供請求日誌使用
+	EntityCounts bool
+	// AdminMutations 開啟後提供 refreshPost / refreshTopic mutation，只有 WithAdmin 標記的請求可以執行
+	AdminMutations bool
+	// ViewCounts 開啟後提供 recordPostView mutation 與 Post.viewsCount，需要 PostViews table
+	ViewCounts bool
+	// CacheControl 開啟後依 cacheControlHints 計算每個回應的 cachecontrol.Policy
+	CacheControl bool
+	// DefaultMaxAge 未設定 hint 的 root 欄位的 maxAge（秒），預設 0 代表不快取
+	DefaultMaxAge int
+}
+
 // Build constructs the GraphQL schema using provided repo.
-func Build(repo *data.Repo) (graphql.Schema, error) {
+func Build(repo *data.Repo, opts Options) (graphql.Schema, error) {
+	if opts.MaxTake <= 0 {
+		opts.MaxTake = 100
+	}
+	if opts.MaxSkip <= 0 {
+		opts.MaxSkip = 10000
+	}
+	if opts.HomepagePostsPerSection <= 0 {
+		opts.HomepagePostsPerSection = 6
+	}
+	if len(opts.EmbedHosts) == 0 {
+		opts.EmbedHosts = sanitize.DefaultEmbedHosts
+	}
+	htmlPolicy := sanitize.Policy{EmbedHosts: opts.EmbedHosts}
+
 	jsonScalar := newJSONScalar()
 	dateTimeScalar := newDateTimeScalar()
 
@@ -23,6 +81,7 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 	})
 	stringFilterFields["equals"] = &graphql.InputObjectFieldConfig{Type: graphql.String}
 	stringFilterFields["in"] = &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.String)}
+	stringFilterFields["notIn"] = &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.String)}
 	stringFilterFields["not"] = &graphql.InputObjectFieldConfig{Type: stringFilterInput}
 
 	booleanFilterFields := graphql.InputObjectConfigFieldMap{}
@@ -39,10 +98,15 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 	})
 	dateTimeNullableFilterFields["equals"] = &graphql.InputObjectFieldConfig{Type: dateTimeScalar}
 	dateTimeNullableFilterFields["not"] = &graphql.InputObjectFieldConfig{Type: dateTimeNullableFilter}
+	dateTimeNullableFilterFields["gt"] = &graphql.InputObjectFieldConfig{Type: dateTimeScalar}
+	dateTimeNullableFilterFields["gte"] = &graphql.InputObjectFieldConfig{Type: dateTimeScalar}
+	dateTimeNullableFilterFields["lt"] = &graphql.InputObjectFieldConfig{Type: dateTimeScalar}
+	dateTimeNullableFilterFields["lte"] = &graphql.InputObjectFieldConfig{Type: dateTimeScalar}
 
 	sectionWhereInputType := graphql.NewInputObject(graphql.InputObjectConfig{
 		Name: "SectionWhereInput",
 		Fields: graphql.InputObjectConfigFieldMap{
+			"name":  &graphql.InputObjectFieldConfig{Type: stringFilterInput},
 			"slug":  &graphql.InputObjectFieldConfig{Type: stringFilterInput},
 			"state": &graphql.InputObjectFieldConfig{Type: stringFilterInput},
 		},
@@ -57,6 +121,7 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 	categoryWhereInputType := graphql.NewInputObject(graphql.InputObjectConfig{
 		Name: "CategoryWhereInput",
 		Fields: graphql.InputObjectConfigFieldMap{
+			"name":         &graphql.InputObjectFieldConfig{Type: stringFilterInput},
 			"slug":         &graphql.InputObjectFieldConfig{Type: stringFilterInput},
 			"state":        &graphql.InputObjectFieldConfig{Type: stringFilterInput},
 			"isMemberOnly": &graphql.InputObjectFieldConfig{Type: booleanFilterInput},
@@ -69,6 +134,21 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 		},
 	})
 
+	tagWhereInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TagWhereInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"slug": &graphql.InputObjectFieldConfig{Type: stringFilterInput},
+			"name": &graphql.InputObjectFieldConfig{Type: stringFilterInput},
+		},
+	})
+
+	tagManyRelationFilterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TagManyRelationFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"some": &graphql.InputObjectFieldConfig{Type: tagWhereInputType},
+		},
+	})
+
 	partnerWhereInputType := graphql.NewInputObject(graphql.InputObjectConfig{
 		Name: "PartnerWhereInput",
 		Fields: graphql.InputObjectConfigFieldMap{
@@ -76,25 +156,37 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 		},
 	})
 
+	idFilterInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "IDFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"equals": &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"in":     &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+			"notIn":  &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+		},
+	})
+
+	postStateEnum := newEnum(postStateEnumDef)
+	postStyleEnum := newEnum(postStyleEnumDef)
+
 	postWhereInputType := graphql.NewInputObject(graphql.InputObjectConfig{
 		Name: "PostWhereInput",
 		Fields: graphql.InputObjectConfigFieldMap{
+			"id":         &graphql.InputObjectFieldConfig{Type: idFilterInput},
 			"slug":       &graphql.InputObjectFieldConfig{Type: stringFilterInput},
 			"sections":   &graphql.InputObjectFieldConfig{Type: sectionManyRelationFilterType},
 			"categories": &graphql.InputObjectFieldConfig{Type: categoryManyRelationFilterType},
-			"state":      &graphql.InputObjectFieldConfig{Type: stringFilterInput},
+			"state":      &graphql.InputObjectFieldConfig{Type: newEnumFilter("PostStateTypeNullableFilter", postStateEnum)},
+			"style":      &graphql.InputObjectFieldConfig{Type: newEnumFilter("PostStyleTypeNullableFilter", postStyleEnum)},
 			"isAdult":    &graphql.InputObjectFieldConfig{Type: booleanFilterInput},
 			"isMember":   &graphql.InputObjectFieldConfig{Type: booleanFilterInput},
 			"isFeatured": &graphql.InputObjectFieldConfig{Type: booleanFilterInput},
+			"tags_algo":  &graphql.InputObjectFieldConfig{Type: tagManyRelationFilterType},
+			"hasVideo":   &graphql.InputObjectFieldConfig{Type: booleanFilterInput},
 			"topics": &graphql.InputObjectFieldConfig{Type: graphql.NewInputObject(graphql.InputObjectConfig{
 				Name: "PostTopicsWhereInput",
 				Fields: graphql.InputObjectConfigFieldMap{
-					"id": &graphql.InputObjectFieldConfig{Type: graphql.NewInputObject(graphql.InputObjectConfig{
-						Name: "IDFilter",
-						Fields: graphql.InputObjectConfigFieldMap{
-							"equals": &graphql.InputObjectFieldConfig{Type: graphql.ID},
-						},
-					})},
+					"id":   &graphql.InputObjectFieldConfig{Type: idFilterInput},
+					"slug": &graphql.InputObjectFieldConfig{Type: stringFilterInput},
 				},
 			})},
 		},
@@ -115,6 +207,7 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 			"state":         &graphql.InputObjectFieldConfig{Type: stringFilterInput},
 			"partner":       &graphql.InputObjectFieldConfig{Type: partnerWhereInputType},
 			"publishedDate": &graphql.InputObjectFieldConfig{Type: dateTimeNullableFilter},
+			"tags":          &graphql.InputObjectFieldConfig{Type: tagManyRelationFilterType},
 		},
 	})
 
@@ -140,18 +233,27 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 		Fields: graphql.InputObjectConfigFieldMap{
 			"publishedDate": &graphql.InputObjectFieldConfig{Type: orderDirectionEnum},
 			"updatedAt":     &graphql.InputObjectFieldConfig{Type: orderDirectionEnum},
+			"createdAt":     &graphql.InputObjectFieldConfig{Type: orderDirectionEnum},
+			"title":         &graphql.InputObjectFieldConfig{Type: orderDirectionEnum},
+			"partnerName":   &graphql.InputObjectFieldConfig{Type: orderDirectionEnum, Description: "Name of the partner; externals without one sort last"},
 		},
 	})
 
+	topicStateEnum := newEnum(topicStateEnumDef)
+	topicTypeEnum := newEnum(topicTypeEnumDef)
+	topicStyleEnum := newEnum(topicStyleEnumDef)
+	topicTitleStyleEnum := newEnum(topicTitleStyleEnumDef)
+
 	topicWhereInputType := graphql.NewInputObject(graphql.InputObjectConfig{
 		Name: "TopicWhereInput",
 		Fields: graphql.InputObjectConfigFieldMap{
+			"id":         &graphql.InputObjectFieldConfig{Type: idFilterInput},
 			"slug":       &graphql.InputObjectFieldConfig{Type: stringFilterInput},
 			"name":       &graphql.InputObjectFieldConfig{Type: stringFilterInput},
-			"state":      &graphql.InputObjectFieldConfig{Type: stringFilterInput},
+			"state":      &graphql.InputObjectFieldConfig{Type: newEnumFilter("TopicStateTypeNullableFilter", topicStateEnum)},
 			"isFeatured": &graphql.InputObjectFieldConfig{Type: booleanFilterInput},
-			"type":       &graphql.InputObjectFieldConfig{Type: stringFilterInput},
-			"style":      &graphql.InputObjectFieldConfig{Type: stringFilterInput},
+			"type":       &graphql.InputObjectFieldConfig{Type: newEnumFilter("TopicTypeTypeNullableFilter", topicTypeEnum)},
+			"style":      &graphql.InputObjectFieldConfig{Type: newEnumFilter("TopicStyleTypeNullableFilter", topicStyleEnum)},
 		},
 	})
 
@@ -175,14 +277,6 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 		},
 	})
 
-	tagWhereInputType := graphql.NewInputObject(graphql.InputObjectConfig{
-		Name: "TagWhereInput",
-		Fields: graphql.InputObjectConfigFieldMap{
-			"slug": &graphql.InputObjectFieldConfig{Type: stringFilterInput},
-			"name": &graphql.InputObjectFieldConfig{Type: stringFilterInput},
-		},
-	})
-
 	photoWhereInputType := graphql.NewInputObject(graphql.InputObjectConfig{
 		Name: "PhotoWhereInput",
 		Fields: graphql.InputObjectConfigFieldMap{
@@ -240,8 +334,16 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 					"where": &graphql.ArgumentConfig{Type: sectionWhereInputType},
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					c, ok := p.Source.(data.Category)
-					if !ok {
+					var c data.Category
+					switch v := p.Source.(type) {
+					case data.Category:
+						c = v
+					case *data.Category:
+						if v == nil {
+							return nil, nil
+						}
+						c = *v
+					default:
 						return nil, nil
 					}
 					where, err := decodeSectionWhere(p.Args["where"])
@@ -304,6 +406,7 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 			"imageFile":   &graphql.Field{Type: imageFileType},
 			"resized":     &graphql.Field{Type: resizedType},
 			"resizedWebp": &graphql.Field{Type: resizedType},
+			"resizedAvif": &graphql.Field{Type: resizedType, Description: "null unless AVIF variants are generated (IMAGE_AVIF)"},
 		},
 	})
 
@@ -311,13 +414,47 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 		Name: "Video",
 		Fields: graphql.Fields{
 			"id":       &graphql.Field{Type: graphql.ID},
+			"name":     &graphql.Field{Type: graphql.String},
+			"state":    &graphql.Field{Type: graphql.String},
 			"videoSrc": &graphql.Field{Type: graphql.String},
+			"mp4Src":   &graphql.Field{Type: graphql.String},
+			"hlsSrc":   &graphql.Field{Type: graphql.String},
+			"duration": &graphql.Field{Type: graphql.Int, Description: "length in seconds"},
 			"heroImage": &graphql.Field{
 				Type: photoType,
 			},
 		},
 	})
 
+	audioType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Audio",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.ID},
+			"name": &graphql.Field{Type: graphql.String},
+			"file": &graphql.Field{
+				Type: graphql.NewObject(graphql.ObjectConfig{
+					Name: "AudioFile",
+					Fields: graphql.Fields{
+						"filename": &graphql.Field{Type: graphql.String},
+						"filesize": &graphql.Field{Type: graphql.Int},
+						"url":      &graphql.Field{Type: graphql.String},
+					},
+				}),
+			},
+			"heroImage": &graphql.Field{Type: photoType},
+			"createdAt": &graphql.Field{Type: dateTimeScalar},
+			"updatedAt": &graphql.Field{Type: dateTimeScalar},
+		},
+	})
+
+	audioWhereInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "AudioWhereInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":   &graphql.InputObjectFieldConfig{Type: idFilterInput},
+			"name": &graphql.InputObjectFieldConfig{Type: stringFilterInput},
+		},
+	})
+
 	partnerType := graphql.NewObject(graphql.ObjectConfig{
 		Name: "Partner",
 		Fields: graphql.Fields{
@@ -340,8 +477,10 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 				"name":      &graphql.Field{Type: graphql.String},
 				"slug":      &graphql.Field{Type: graphql.String},
 				"sortOrder": &graphql.Field{Type: graphql.Int},
-				"state":     &graphql.Field{Type: graphql.String},
-				"brief":     &graphql.Field{Type: jsonScalar},
+				"state": enumField(topicStateEnum, topicStateEnumDef, func(p graphql.ResolveParams) string {
+					return normalizeTopic(p.Source).State
+				}),
+				"brief": &graphql.Field{Type: jsonScalar},
 				"heroImage": &graphql.Field{
 					Type: photoType,
 					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
@@ -355,18 +494,28 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 				"og_image": &graphql.Field{
 					Type: photoType,
 					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-						return normalizeTopic(p.Source).OgImage, nil
+						current := normalizeTopic(p.Source)
+						if current.OgImage == nil && opts.OgImageFallback {
+							return current.HeroImage, nil
+						}
+						return current.OgImage, nil
 					},
 				},
-				"isFeatured":  &graphql.Field{Type: graphql.Boolean},
-				"title_style": &graphql.Field{Type: graphql.String},
-				"type":        &graphql.Field{Type: graphql.String},
-				"style":       &graphql.Field{Type: graphql.String},
-				"javascript":  &graphql.Field{Type: graphql.String},
-				"dfp":         &graphql.Field{Type: graphql.String},
-				"mobile_dfp":  &graphql.Field{Type: graphql.String},
-				"createdAt":   &graphql.Field{Type: dateTimeScalar},
-				"updatedAt":   &graphql.Field{Type: dateTimeScalar},
+				"isFeatured": &graphql.Field{Type: graphql.Boolean},
+				"title_style": enumField(topicTitleStyleEnum, topicTitleStyleEnumDef, func(p graphql.ResolveParams) string {
+					return normalizeTopic(p.Source).TitleStyle
+				}),
+				"type": enumField(topicTypeEnum, topicTypeEnumDef, func(p graphql.ResolveParams) string {
+					return normalizeTopic(p.Source).Type
+				}),
+				"style": enumField(topicStyleEnum, topicStyleEnumDef, func(p graphql.ResolveParams) string {
+					return normalizeTopic(p.Source).Style
+				}),
+				"javascript": &graphql.Field{Type: graphql.String},
+				"dfp":        &graphql.Field{Type: graphql.String},
+				"mobile_dfp": &graphql.Field{Type: graphql.String},
+				"createdAt":  &graphql.Field{Type: dateTimeScalar},
+				"updatedAt":  &graphql.Field{Type: dateTimeScalar},
 				"tags": &graphql.Field{
 					Type: graphql.NewList(tagType),
 					Args: graphql.FieldConfigArgument{
@@ -416,6 +565,20 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 					},
 				},
 				"manualOrderOfSlideshowImages": &graphql.Field{Type: jsonScalar},
+				"parentTopic": &graphql.Field{
+					Type:        topicType,
+					Description: "The published group topic this topic belongs to; its own relations are not loaded",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return normalizeTopic(p.Source).ParentTopic, nil
+					},
+				},
+				"subtopics": &graphql.Field{
+					Type:        graphql.NewList(topicType),
+					Description: "Published child topics of a group topic, ordered by sortOrder; their own relations are not loaded",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return normalizeTopic(p.Source).Subtopics, nil
+					},
+				},
 				"posts": &graphql.Field{
 					Type: graphql.NewList(postType),
 					Args: graphql.FieldConfigArgument{
@@ -431,7 +594,10 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 							return nil, err
 						}
 						orders := parseOrderRules(p.Args["orderBy"])
-						take, skip := parsePagination(p.Args)
+						take, skip, err := parsePagination(p.Args, opts)
+						if err != nil {
+							return nil, err
+						}
 						return filterAndPaginatePosts(current.Posts, where, orders, take, skip), nil
 					},
 				},
@@ -507,13 +673,17 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 	postType = graphql.NewObject(graphql.ObjectConfig{
 		Name: "Post",
 		Fields: graphql.FieldsThunk(func() graphql.Fields {
-			return graphql.Fields{
-				"id":            &graphql.Field{Type: graphql.ID},
-				"slug":          &graphql.Field{Type: graphql.String},
-				"title":         &graphql.Field{Type: graphql.String},
-				"subtitle":      &graphql.Field{Type: graphql.String},
-				"state":         &graphql.Field{Type: graphql.String},
-				"style":         &graphql.Field{Type: graphql.String},
+			fields := graphql.Fields{
+				"id":       &graphql.Field{Type: graphql.ID},
+				"slug":     &graphql.Field{Type: graphql.String},
+				"title":    &graphql.Field{Type: graphql.String},
+				"subtitle": &graphql.Field{Type: graphql.String},
+				"state": enumField(postStateEnum, postStateEnumDef, func(p graphql.ResolveParams) string {
+					return normalizePost(p.Source).State
+				}),
+				"style": enumField(postStyleEnum, postStyleEnumDef, func(p graphql.ResolveParams) string {
+					return normalizePost(p.Source).Style
+				}),
 				"publishedDate": &graphql.Field{Type: dateTimeScalar},
 				"updatedAt":     &graphql.Field{Type: dateTimeScalar},
 				"isMember":      &graphql.Field{Type: graphql.Boolean},
@@ -630,8 +800,15 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 				},
 				"tags_algo": &graphql.Field{
 					Type: graphql.NewList(tagType),
+					Args: graphql.FieldConfigArgument{
+						"where": &graphql.ArgumentConfig{Type: tagWhereInputType},
+					},
 					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-						return normalizePost(p.Source).TagsAlgo, nil
+						where, err := data.DecodeTagWhere(p.Args["where"])
+						if err != nil {
+							return nil, err
+						}
+						return filterTags(normalizePost(p.Source).TagsAlgo, where), nil
 					},
 				},
 				"heroVideo": &graphql.Field{
@@ -646,6 +823,18 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 						return normalizePost(p.Source).HeroImage, nil
 					},
 				},
+				"heroAudio": &graphql.Field{
+					Type: audioType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return normalizePost(p.Source).HeroAudio, nil
+					},
+				},
+				"audio": &graphql.Field{
+					Type: audioType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return normalizePost(p.Source).Audio, nil
+					},
+				},
 				"heroCaption": &graphql.Field{Type: graphql.String},
 				"brief":       &graphql.Field{Type: jsonScalar},
 				"trimmedContent": &graphql.Field{
@@ -660,16 +849,51 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 						return normalizePost(p.Source).Content, nil
 					},
 				},
+				"readingTime": &graphql.Field{
+					Type:        graphql.Int,
+					Description: "estimated reading time of content in minutes",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return normalizePost(p.Source).ReadingTime, nil
+					},
+				},
+				"wordCount": &graphql.Field{
+					Type:        graphql.Int,
+					Description: "CJK characters plus words of other languages in content",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return normalizePost(p.Source).WordCount, nil
+					},
+				},
+				"apiData": &graphql.Field{
+					Type:        jsonScalar,
+					Description: "content converted from draft-js into apiData blocks",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return apidata.FromDraft(normalizePost(p.Source).Content), nil
+					},
+				},
 				"relateds": &graphql.Field{
 					Type: graphql.NewList(postType),
+					Args: graphql.FieldConfigArgument{
+						"where": &graphql.ArgumentConfig{Type: postWhereInputType},
+					},
 					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-						return normalizePost(p.Source).Relateds, nil
+						where, err := data.DecodePostWhere(p.Args["where"])
+						if err != nil {
+							return nil, err
+						}
+						return filterRelateds(normalizePost(p.Source).Relateds, where), nil
 					},
 				},
 				"relatedsInInputOrder": &graphql.Field{
 					Type: graphql.NewList(postType),
+					Args: graphql.FieldConfigArgument{
+						"where": &graphql.ArgumentConfig{Type: postWhereInputType},
+					},
 					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-						return normalizePost(p.Source).RelatedsInInputOrder, nil
+						where, err := data.DecodePostWhere(p.Args["where"])
+						if err != nil {
+							return nil, err
+						}
+						return filterRelateds(normalizePost(p.Source).RelatedsInInputOrder, where), nil
 					},
 				},
 				"relatedsOne": &graphql.Field{
@@ -699,7 +923,11 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 				"og_image": &graphql.Field{
 					Type: photoType,
 					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-						return normalizePost(p.Source).OgImage, nil
+						current := normalizePost(p.Source)
+						if current.OgImage == nil && opts.OgImageFallback {
+							return current.HeroImage, nil
+						}
+						return current.OgImage, nil
 					},
 				},
 				"og_description": &graphql.Field{
@@ -732,27 +960,196 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 						return normalizePost(p.Source).Topics, nil
 					},
 				},
+				// 舊版 schema 的部分前端 fragment 把 post→topics 當成 list，遷移期間以此欄位相容
+				"topicsList": &graphql.Field{
+					Type:        graphql.NewList(topicType),
+					Description: "topics as a list ([] or a single topic), for fragments written against the legacy list relation",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if t := normalizePost(p.Source).Topics; t != nil {
+							return []data.Topic{*t}, nil
+						}
+						return []data.Topic{}, nil
+					},
+				},
+			}
+			if opts.ViewCounts {
+				fields["viewsCount"] = &graphql.Field{
+					Type:        graphql.Int,
+					Description: "views recorded by recordPostView, updated when buffered views are flushed",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return repo.PostViews(p.Context, normalizePost(p.Source).ID)
+					},
+				}
 			}
+			return fields
 		}),
 	})
 
 	externalType := graphql.NewObject(graphql.ObjectConfig{
 		Name: "External",
 		Fields: graphql.Fields{
-			"id":            &graphql.Field{Type: graphql.ID},
-			"slug":          &graphql.Field{Type: graphql.String},
-			"title":         &graphql.Field{Type: graphql.String},
-			"thumb":         &graphql.Field{Type: graphql.String},
-			"brief":         &graphql.Field{Type: graphql.String},
-			"content":       &graphql.Field{Type: graphql.String},
+			"id":    &graphql.Field{Type: graphql.ID},
+			"slug":  &graphql.Field{Type: graphql.String},
+			"title": &graphql.Field{Type: graphql.String},
+			"thumb": &graphql.Field{Type: graphql.String},
+			"brief": &graphql.Field{Type: graphql.String},
+			"content": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"raw": &graphql.ArgumentConfig{
+						Type:         graphql.Boolean,
+						DefaultValue: false,
+						Description:  "Return the unsanitized partner HTML (admin only)",
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var content string
+					switch v := p.Source.(type) {
+					case data.External:
+						content = v.Content
+					case *data.External:
+						if v == nil {
+							return nil, nil
+						}
+						content = v.Content
+					default:
+						return nil, nil
+					}
+					raw, _ := p.Args["raw"].(bool)
+					if raw {
+						// 原始 HTML 只提供給帶 admin token 的內部服務，且不讓共用快取保存
+						if !IsAdmin(p.Context) {
+							return nil, inputErrorf("content(raw: true) requires the admin token")
+						}
+						cachecontrol.FromContext(p.Context).Restrict(cachecontrol.Hint{Scope: cachecontrol.Private})
+						return content, nil
+					}
+					if !opts.SanitizeExternalHTML {
+						return content, nil
+					}
+					return sanitize.HTML(content, htmlPolicy), nil
+				},
+			},
 			"publishedDate": &graphql.Field{Type: dateTimeScalar},
 			"extend_byline": &graphql.Field{Type: graphql.String},
 			"thumbCaption":  &graphql.Field{Type: graphql.String},
 			"partner":       &graphql.Field{Type: partnerType},
 			"updatedAt":     &graphql.Field{Type: dateTimeScalar},
+			"relateds":      &graphql.Field{Type: graphql.NewList(postType)},
+		},
+	})
+
+	sectionPostCountType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SectionPostCount",
+		Fields: graphql.Fields{
+			"section": &graphql.Field{Type: sectionType},
+			"count":   &graphql.Field{Type: graphql.Int},
 		},
 	})
 
+	partnerExternalCountType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PartnerExternalCount",
+		Fields: graphql.Fields{
+			"partner": &graphql.Field{Type: partnerType},
+			"count":   &graphql.Field{Type: graphql.Int},
+		},
+	})
+
+	homepageSectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "HomepageSection",
+		Fields: graphql.Fields{
+			"section": &graphql.Field{Type: sectionType},
+			"posts":   &graphql.Field{Type: graphql.NewList(postType)},
+		},
+	})
+	navigationSectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "NavigationSection",
+		Fields: graphql.Fields{
+			"section":    &graphql.Field{Type: sectionType},
+			"categories": &graphql.Field{Type: graphql.NewList(categoryType)},
+		},
+	})
+	homepageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Homepage",
+		Fields: graphql.Fields{
+			"sections":  &graphql.Field{Type: graphql.NewList(homepageSectionType)},
+			"topics":    &graphql.Field{Type: graphql.NewList(topicType)},
+			"externals": &graphql.Field{Type: graphql.NewList(externalType)},
+		},
+	})
+
+	editorChoiceWhereInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "EditorChoiceWhereInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"state": &graphql.InputObjectFieldConfig{Type: stringFilterInput},
+		},
+	})
+	editorChoiceType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "EditorChoice",
+		Fields: graphql.Fields{
+			"id":            &graphql.Field{Type: graphql.ID},
+			"name":          &graphql.Field{Type: graphql.String},
+			"sortOrder":     &graphql.Field{Type: graphql.Int},
+			"state":         &graphql.Field{Type: graphql.String},
+			"publishedDate": &graphql.Field{Type: dateTimeScalar},
+			"choices":       &graphql.Field{Type: postType},
+			"createdAt":     &graphql.Field{Type: dateTimeScalar},
+			"updatedAt":     &graphql.Field{Type: dateTimeScalar},
+		},
+	})
+
+	eventWhereInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "EventWhereInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"slug":      &graphql.InputObjectFieldConfig{Type: stringFilterInput},
+			"state":     &graphql.InputObjectFieldConfig{Type: stringFilterInput},
+			"eventType": &graphql.InputObjectFieldConfig{Type: stringFilterInput},
+			"isActive": &graphql.InputObjectFieldConfig{
+				Type:        graphql.Boolean,
+				Description: "true: started and not yet ended; false: not started or already ended",
+			},
+		},
+	})
+	eventType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Event",
+		Fields: graphql.Fields{
+			"id":            &graphql.Field{Type: graphql.ID},
+			"name":          &graphql.Field{Type: graphql.String},
+			"slug":          &graphql.Field{Type: graphql.String},
+			"state":         &graphql.Field{Type: graphql.String},
+			"eventType":     &graphql.Field{Type: graphql.String},
+			"link":          &graphql.Field{Type: graphql.String},
+			"embedCode":     &graphql.Field{Type: graphql.String},
+			"startDate":     &graphql.Field{Type: dateTimeScalar},
+			"endDate":       &graphql.Field{Type: dateTimeScalar},
+			"publishedDate": &graphql.Field{Type: dateTimeScalar},
+			"heroImage":     &graphql.Field{Type: photoType},
+			"createdAt":     &graphql.Field{Type: dateTimeScalar},
+			"updatedAt":     &graphql.Field{Type: dateTimeScalar},
+		},
+	})
+
+	addRelatedPostsFields(tagType, relatedPosts{
+		description: "Posts tagged with this tag, newest first unless orderBy is given",
+		idOf:        tagID,
+		posts:       repo.QueryTagPosts,
+		count:       repo.QueryTagPostsCount,
+	}, opts, postType, postWhereInputType, postOrderByInput)
+	addRelatedPostsFields(sectionType, relatedPosts{
+		description: "Posts in this section, newest first unless orderBy is given",
+		idOf:        sectionID,
+		posts:       repo.QuerySectionPosts,
+		count:       repo.QuerySectionPostsCount,
+	}, opts, postType, postWhereInputType, postOrderByInput)
+	addRelatedPostsFields(categoryType, relatedPosts{
+		description: "Posts in this category, newest first unless orderBy is given",
+		idOf:        categoryID,
+		posts:       repo.QueryCategoryPosts,
+		count:       repo.QueryCategoryPostsCount,
+	}, opts, postType, postWhereInputType, postOrderByInput)
+
+	addPartnerExternalsFields(partnerType, repo, opts, externalType, externalOrderByInput)
+
 	rootQuery := graphql.NewObject(graphql.ObjectConfig{
 		Name: "Query",
 		Fields: graphql.Fields{
@@ -770,7 +1167,10 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 						return nil, err
 					}
 					orders := parseOrderRules(p.Args["orderBy"])
-					take, skip := parsePagination(p.Args)
+					take, skip, err := parsePagination(p.Args, opts)
+					if err != nil {
+						return nil, err
+					}
 					return repo.QueryPosts(p.Context, where, orders, take, skip)
 				},
 			},
@@ -787,6 +1187,74 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 					return repo.QueryPostsCount(p.Context, where)
 				},
 			},
+			"homepage": &graphql.Field{
+				Type:        homepageType,
+				Description: "Latest posts of each main section, featured topics and latest externals in one query",
+				Args: graphql.FieldConfigArgument{
+					"postsPerSection": &graphql.ArgumentConfig{Type: graphql.Int},
+					"topicsTake":      &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 5},
+					"externalsTake":   &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					homepageOpts := data.HomepageOptions{
+						SectionSlugs:    opts.HomepageSections,
+						PostsPerSection: opts.HomepagePostsPerSection,
+						TopicsTake:      asInt(p.Args["topicsTake"]),
+						ExternalsTake:   asInt(p.Args["externalsTake"]),
+					}
+					if raw, ok := p.Args["postsPerSection"]; ok && raw != nil {
+						homepageOpts.PostsPerSection = asInt(raw)
+					}
+					limits := []struct {
+						name  string
+						value int
+					}{
+						{"postsPerSection", homepageOpts.PostsPerSection},
+						{"topicsTake", homepageOpts.TopicsTake},
+						{"externalsTake", homepageOpts.ExternalsTake},
+					}
+					for _, l := range limits {
+						if l.value < 0 || l.value > opts.MaxTake {
+							return nil, inputErrorf("invalid %s %d: must be between 0 and %d", l.name, l.value, opts.MaxTake)
+						}
+					}
+					return repo.QueryHomepage(p.Context, homepageOpts)
+				},
+			},
+			"postsCountBySection": &graphql.Field{
+				Type:        graphql.NewList(sectionPostCountType),
+				Description: "Published post counts grouped by section",
+				Args: graphql.FieldConfigArgument{
+					"where": &graphql.ArgumentConfig{Type: postWhereInputType},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					where, err := data.DecodePostWhere(p.Args["where"])
+					if err != nil {
+						return nil, err
+					}
+					return repo.QueryPostsCountBySection(p.Context, where)
+				},
+			},
+			"postsBySlugs": &graphql.Field{
+				Type:        graphql.NewList(postType),
+				Description: "Published posts matching slugs, in the order the slugs were given",
+				Args: graphql.FieldConfigArgument{
+					"slugs": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					rawSlugs, _ := p.Args["slugs"].([]interface{})
+					if len(rawSlugs) > opts.MaxTake {
+						return nil, inputErrorf("invalid slugs: at most %d slugs are allowed", opts.MaxTake)
+					}
+					slugs := make([]string, 0, len(rawSlugs))
+					for _, v := range rawSlugs {
+						if slug, ok := v.(string); ok {
+							slugs = append(slugs, slug)
+						}
+					}
+					return repo.QueryPostsBySlugs(p.Context, slugs)
+				},
+			},
 			"post": &graphql.Field{
 				Type: postType,
 				Args: graphql.FieldConfigArgument{
@@ -814,7 +1282,10 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 						return nil, err
 					}
 					orders := parseOrderRules(p.Args["orderBy"])
-					take, skip := parsePagination(p.Args)
+					take, skip, err := parsePagination(p.Args, opts)
+					if err != nil {
+						return nil, err
+					}
 					return repo.QueryTopics(p.Context, where, orders, take, skip)
 				},
 			},
@@ -844,6 +1315,63 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 					return repo.QueryTopicByUnique(p.Context, where)
 				},
 			},
+			"editorChoices": &graphql.Field{
+				Type: graphql.NewList(editorChoiceType),
+				Args: graphql.FieldConfigArgument{
+					"take":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"skip":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"where": &graphql.ArgumentConfig{Type: editorChoiceWhereInputType},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					where, err := data.DecodeEditorChoiceWhere(p.Args["where"])
+					if err != nil {
+						return nil, err
+					}
+					take, skip, err := parsePagination(p.Args, opts)
+					if err != nil {
+						return nil, err
+					}
+					return repo.QueryEditorChoices(p.Context, where, take, skip)
+				},
+			},
+			"audios": &graphql.Field{
+				Type: graphql.NewList(audioType),
+				Args: graphql.FieldConfigArgument{
+					"take":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"skip":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"where": &graphql.ArgumentConfig{Type: audioWhereInputType},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					where, err := data.DecodeAudioWhere(p.Args["where"])
+					if err != nil {
+						return nil, err
+					}
+					take, skip, err := parsePagination(p.Args, opts)
+					if err != nil {
+						return nil, err
+					}
+					return repo.QueryAudios(p.Context, where, take, skip)
+				},
+			},
+			"events": &graphql.Field{
+				Type: graphql.NewList(eventType),
+				Args: graphql.FieldConfigArgument{
+					"take":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"skip":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"where": &graphql.ArgumentConfig{Type: eventWhereInputType},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					where, err := data.DecodeEventWhere(p.Args["where"])
+					if err != nil {
+						return nil, err
+					}
+					take, skip, err := parsePagination(p.Args, opts)
+					if err != nil {
+						return nil, err
+					}
+					return repo.QueryEvents(p.Context, where, take, skip)
+				},
+			},
 			"externals": &graphql.Field{
 				Type: graphql.NewList(externalType),
 				Args: graphql.FieldConfigArgument{
@@ -858,10 +1386,63 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 						return nil, err
 					}
 					orders := parseOrderRules(p.Args["orderBy"])
-					take, skip := parsePagination(p.Args)
+					take, skip, err := parsePagination(p.Args, opts)
+					if err != nil {
+						return nil, err
+					}
 					return repo.QueryExternals(p.Context, where, orders, take, skip)
 				},
 			},
+			"navigation": &graphql.Field{
+				Type:        graphql.NewList(navigationSectionType),
+				Description: "Active sections ordered by id, each with its active categories ordered by id",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return repo.QueryNavigation(p.Context)
+				},
+			},
+			"categories": &graphql.Field{
+				Type:        graphql.NewList(categoryType),
+				Description: "Categories ordered by id, with their sections",
+				Args: graphql.FieldConfigArgument{
+					"take":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"skip":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"where": &graphql.ArgumentConfig{Type: categoryWhereInputType},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					where, err := decodeCategoryWhere(p.Args["where"])
+					if err != nil {
+						return nil, err
+					}
+					take, skip, err := parsePagination(p.Args, opts)
+					if err != nil {
+						return nil, err
+					}
+					return repo.QueryCategories(p.Context, where, take, skip)
+				},
+			},
+			"tagSuggest":     tagSuggestField(repo, opts, tagType),
+			"contactSearch":  contactSearchField(repo, opts, contactType),
+			"author":         authorField(repo, opts, contactType, postType),
+			"tag":            tagField(repo, tagType),
+			"partner":        partnerField(repo, partnerType),
+			"sectionPage":    sectionPageField(repo, opts, sectionType, categoryType, postType, externalType),
+			"postsArchive":   postsArchiveField(repo, opts, postType),
+			"archiveMonths":  archiveMonthsField(repo),
+			"changedStories": changedStoriesField(repo, opts, postType, externalType, topicType, dateTimeScalar),
+			"externalsCountByPartner": &graphql.Field{
+				Type:        graphql.NewList(partnerExternalCountType),
+				Description: "Published external counts grouped by partner",
+				Args: graphql.FieldConfigArgument{
+					"where": &graphql.ArgumentConfig{Type: externalWhereInputType},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					where, err := data.DecodeExternalWhere(p.Args["where"])
+					if err != nil {
+						return nil, err
+					}
+					return repo.QueryExternalsCountByPartner(p.Context, where)
+				},
+			},
 			"externalsCount": &graphql.Field{
 				Type: graphql.Int,
 				Args: graphql.FieldConfigArgument{
@@ -878,9 +1459,42 @@ func Build(repo *data.Repo) (graphql.Schema, error) {
 		},
 	})
 
-	return graphql.NewSchema(graphql.SchemaConfig{
-		Query: rootQuery,
-	})
+	schemaConfig := graphql.SchemaConfig{Query: rootQuery}
+	mutationFields := graphql.Fields{}
+	if opts.AdminMutations {
+		addAdminMutations(mutationFields, repo, postType, topicType)
+	}
+	if opts.ViewCounts {
+		mutationFields["recordPostView"] = recordPostViewField(repo)
+	}
+	if len(mutationFields) > 0 {
+		schemaConfig.Mutation = graphql.NewObject(graphql.ObjectConfig{Name: "Mutation", Fields: mutationFields})
+	}
+	schemaConfig.Directives = append(append([]*graphql.Directive{}, graphql.SpecifiedDirectives...), deferDirective)
+	if opts.CacheControl {
+		schemaConfig.Directives = append(schemaConfig.Directives, cacheControlDirective)
+	}
+	gqlSchema, err := graphql.NewSchema(schemaConfig)
+	if err != nil {
+		return gqlSchema, err
+	}
+	applyDeprecations(gqlSchema)
+	if opts.SurrogateKeys || opts.EntityCounts {
+		applyEntityTracking(gqlSchema, opts.SurrogateKeys)
+	}
+	if opts.CacheControl {
+		applyCacheControl(gqlSchema, opts.DefaultMaxAge)
+	}
+	if opts.ResolverMetrics {
+		applyResolverMetrics(gqlSchema)
+	}
+	if opts.Reporter != nil {
+		applyErrorReporting(gqlSchema, opts.Reporter)
+	}
+	if opts.KeystoneParity {
+		applyKeystoneParity(gqlSchema)
+	}
+	return gqlSchema, nil
 }
 
 // Scalars
@@ -888,9 +1502,7 @@ func newJSONScalar() *graphql.Scalar {
 	return graphql.NewScalar(graphql.ScalarConfig{
 		Name:        "JSON",
 		Description: "Arbitrary JSON value",
-		Serialize: func(value interface{}) interface{} {
-			return value
-		},
+		Serialize:   serializeJSON,
 		ParseValue: func(value interface{}) interface{} {
 			return value
 		},
@@ -900,19 +1512,74 @@ func newJSONScalar() *graphql.Scalar {
 	})
 }
 
+// serializeJSON 輸出 JSON 欄位：原始 JSON bytes 先解析為巢狀的 map / slice，
+// 避免被當成字串或 base64 輸出；typed nil 視為 null
+func serializeJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case json.RawMessage:
+		return decodeRawJSON(v)
+	case []byte:
+		return decodeRawJSON(v)
+	case map[string]any:
+		if v == nil {
+			return nil
+		}
+		return v
+	case []any:
+		if v == nil {
+			return nil
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func decodeRawJSON(raw []byte) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil
+	}
+	return v
+}
+
+// dateTimeLayout 為 DateTime 輸入正規化後的格式（UTC、毫秒）
+const dateTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
 func newDateTimeScalar() *graphql.Scalar {
 	return graphql.NewScalar(graphql.ScalarConfig{
-		Name: "DateTime",
+		Name:        "DateTime",
+		Description: "RFC3339 timestamp with millisecond precision, e.g. 2024-01-02T03:04:05.000Z",
 		Serialize: func(value interface{}) interface{} {
-			return value
+			// repo 已依 OUTPUT_TIME_LAYOUT 格式化為字串，直接輸出以維持既有格式
+			switch v := value.(type) {
+			case time.Time:
+				return v.Format(dateTimeLayout)
+			case *time.Time:
+				if v == nil {
+					return nil
+				}
+				return v.Format(dateTimeLayout)
+			default:
+				return value
+			}
 		},
 		ParseValue: func(value interface{}) interface{} {
-			return value
+			s, ok := value.(string)
+			if !ok {
+				return nil
+			}
+			return parseDateTime(s)
 		},
 		ParseLiteral: func(valueAST ast.Value) interface{} {
 			switch v := valueAST.(type) {
 			case *ast.StringValue:
-				return v.Value
+				return parseDateTime(v.Value)
 			default:
 				return nil
 			}
@@ -920,6 +1587,16 @@ func newDateTimeScalar() *graphql.Scalar {
 	})
 }
 
+// parseDateTime 解析 RFC3339 字串並轉為 UTC 毫秒格式；格式錯誤回傳 nil，
+// graphql-go 會將其視為不合法的輸入並回傳錯誤
+func parseDateTime(s string) interface{} {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil
+	}
+	return t.UTC().Format(dateTimeLayout)
+}
+
 // Helpers
 func parseOrderRules(input interface{}) []data.OrderRule {
 	rules := []data.OrderRule{}
@@ -942,17 +1619,31 @@ func parseOrderRules(input interface{}) []data.OrderRule {
 	return rules
 }
 
-func parsePagination(args map[string]interface{}) (take int, skip int) {
-	if raw, ok := args["take"]; ok {
+// parsePagination 驗證 take/skip：take 未指定或為 -1 代表「全部」，但仍受 MaxTake 限制
+func parsePagination(args map[string]interface{}, opts Options) (take int, skip int, err error) {
+	take = -1
+	if raw, ok := args["take"]; ok && raw != nil {
 		take = asInt(raw)
 	}
-	if raw, ok := args["skip"]; ok {
+	if raw, ok := args["skip"]; ok && raw != nil {
 		skip = asInt(raw)
 	}
+	if take < -1 {
+		return 0, 0, inputErrorf("invalid take %d: must be -1 (all) or between 0 and %d", take, opts.MaxTake)
+	}
+	if take > opts.MaxTake {
+		return 0, 0, inputErrorf("invalid take %d: must not exceed %d", take, opts.MaxTake)
+	}
+	if take == -1 {
+		take = opts.MaxTake
+	}
 	if skip < 0 {
-		skip = 0
+		return 0, 0, inputErrorf("invalid skip %d: must not be negative", skip)
+	}
+	if skip > opts.MaxSkip {
+		return 0, 0, inputErrorf("invalid skip %d: must not exceed %d", skip, opts.MaxSkip)
 	}
-	return
+	return take, skip, nil
 }
 
 func asInt(val interface{}) int {
@@ -973,8 +1664,14 @@ func parseASTValue(value ast.Value) interface{} {
 	case *ast.StringValue:
 		return v.Value
 	case *ast.IntValue:
+		if n, err := strconv.ParseInt(v.Value, 10, 64); err == nil {
+			return n
+		}
 		return v.Value
 	case *ast.FloatValue:
+		if f, err := strconv.ParseFloat(v.Value, 64); err == nil {
+			return f
+		}
 		return v.Value
 	case *ast.BooleanValue:
 		return v.Value
@@ -1087,9 +1784,20 @@ func filterPosts(items []data.Post, where *data.PostWhereInput) []data.Post {
 	}
 	result := []data.Post{}
 	for _, item := range items {
+		if !matchesIDFilter(item.ID, where.ID) {
+			continue
+		}
 		if !matchesStringFilter(item.State, where.State) {
 			continue
 		}
+		// style 為空時視為預設值，與 SQL filter 一致
+		style := item.Style
+		if style == "" {
+			style = postStyleEnumDef.Default
+		}
+		if !matchesStringFilter(style, where.Style) {
+			continue
+		}
 		if !matchesBooleanFilter(item.IsFeatured, where.IsFeatured) {
 			continue
 		}
@@ -1099,18 +1807,36 @@ func filterPosts(items []data.Post, where *data.PostWhereInput) []data.Post {
 		if !matchesBooleanFilter(item.IsAdult, where.IsAdult) {
 			continue
 		}
+		if !matchesBooleanFilter(item.HeroVideo != nil, where.HasVideo) {
+			continue
+		}
 		result = append(result, item)
 	}
 	return result
 }
 
+// filterRelateds 預設只保留 published 的相關文章，where 帶 state 時改用指定條件。
+// 相關文章的來源都會帶 State，State 為空（例如舊 cache 資料）視為未發布
+func filterRelateds(items []data.Post, where *data.PostWhereInput) []data.Post {
+	if where != nil && where.State != nil {
+		return filterPosts(items, where)
+	}
+	result := []data.Post{}
+	for _, item := range filterPosts(items, where) {
+		if item.State == "published" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 func filterAndPaginatePosts(items []data.Post, where *data.PostWhereInput, orders []data.OrderRule, take, skip int) []data.Post {
 	filtered := filterPosts(items, where)
 	// TODO: 實作排序和分頁
 	if skip > 0 && skip < len(filtered) {
 		filtered = filtered[skip:]
 	}
-	if take > 0 && take < len(filtered) {
+	if take >= 0 && take < len(filtered) {
 		filtered = filtered[:take]
 	}
 	return filtered
@@ -1120,6 +1846,9 @@ func matchesSectionWhere(s *data.Section, where *data.SectionWhereInput) bool {
 	if where == nil {
 		return true
 	}
+	if !matchesStringFilter(s.Name, where.Name) {
+		return false
+	}
 	if !matchesStringFilter(s.Slug, where.Slug) {
 		return false
 	}
@@ -1133,6 +1862,9 @@ func matchesCategoryWhere(c *data.Category, where *data.CategoryWhereInput) bool
 	if where == nil {
 		return true
 	}
+	if !matchesStringFilter(c.Name, where.Name) {
+		return false
+	}
 	if !matchesStringFilter(c.Slug, where.Slug) {
 		return false
 	}
@@ -1164,12 +1896,40 @@ func matchesStringFilter(value string, filter *data.StringFilter) bool {
 			return false
 		}
 	}
+	for _, item := range filter.NotIn {
+		if value == item {
+			return false
+		}
+	}
 	if filter.Not != nil && matchesStringFilter(value, filter.Not) {
 		return false
 	}
 	return true
 }
 
+func matchesIDFilter(value string, filter *data.IDFilter) bool {
+	if filter == nil {
+		return true
+	}
+	if filter.Equals != nil && value != *filter.Equals {
+		return false
+	}
+	for _, id := range filter.NotIn {
+		if value == id {
+			return false
+		}
+	}
+	if len(filter.In) > 0 {
+		for _, id := range filter.In {
+			if value == id {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
 func matchesBooleanFilter(value bool, filter *data.BooleanFilter) bool {
 	if filter == nil {
 		return true
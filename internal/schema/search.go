@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"go-story/internal/data"
+
+	"github.com/graphql-go/graphql"
+)
+
+// defaultSuggestTake 建議清單預設筆數
+const defaultSuggestTake = 10
+
+// suggestTake 讀取 take 參數，範圍為 1 到 MaxTake
+func suggestTake(args map[string]interface{}, opts Options) (int, error) {
+	take := defaultSuggestTake
+	if raw, ok := args["take"]; ok && raw != nil {
+		take = asInt(raw)
+	}
+	if take < 1 || take > opts.MaxTake {
+		return 0, inputErrorf("invalid take %d: must be between 1 and %d", take, opts.MaxTake)
+	}
+	return take, nil
+}
+
+// tagSuggestField 建立 tagSuggest 查詢，供搜尋列的 tag 自動完成
+func tagSuggestField(repo *data.Repo, opts Options, tagType *graphql.Object) *graphql.Field {
+	return &graphql.Field{
+		Type:        graphql.NewList(tagType),
+		Description: "Tags whose name contains prefix, names starting with it first",
+		Args: graphql.FieldConfigArgument{
+			"prefix": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"take":   &graphql.ArgumentConfig{Type: graphql.Int, Description: "Defaults to 10"},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			take, err := suggestTake(p.Args, opts)
+			if err != nil {
+				return nil, err
+			}
+			prefix, _ := p.Args["prefix"].(string)
+			return repo.QueryTagSuggestions(p.Context, prefix, take)
+		},
+	}
+}
+
+// contactSearchField 建立 contactSearch 查詢，供內部的作者連結工具以姓名查詢 Contact
+func contactSearchField(repo *data.Repo, opts Options, contactType *graphql.Object) *graphql.Field {
+	return &graphql.Field{
+		Type:        graphql.NewList(contactType),
+		Description: "Contacts whose name contains q, ignoring case and spaces; exact and prefix matches first",
+		Args: graphql.FieldConfigArgument{
+			"q":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"take": &graphql.ArgumentConfig{Type: graphql.Int, Description: "Defaults to 10"},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			take, err := suggestTake(p.Args, opts)
+			if err != nil {
+				return nil, err
+			}
+			q, _ := p.Args["q"].(string)
+			return repo.QueryContacts(p.Context, q, take)
+		},
+	}
+}
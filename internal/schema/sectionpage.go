@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"go-story/internal/data"
+
+	"github.com/graphql-go/graphql"
+)
+
+// sectionPageField 建立 sectionPage 查詢，一次取得 section、其 category 與文章、外稿合併的列表，重現舊版 section 頁
+func sectionPageField(repo *data.Repo, opts Options, sectionType, categoryType, postType, externalType *graphql.Object) *graphql.Field {
+	kindEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "SectionPageItemKind",
+		Values: graphql.EnumValueConfigMap{
+			data.ChangeKindPost:     &graphql.EnumValueConfig{Value: data.ChangeKindPost},
+			data.ChangeKindExternal: &graphql.EnumValueConfig{Value: data.ChangeKindExternal},
+		},
+	})
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SectionPageItem",
+		Fields: graphql.Fields{
+			"kind":          &graphql.Field{Type: graphql.NewNonNull(kindEnum)},
+			"publishedDate": &graphql.Field{Type: graphql.String},
+			"post":          &graphql.Field{Type: postType},
+			"external":      &graphql.Field{Type: externalType},
+		},
+	})
+	pageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SectionPage",
+		Fields: graphql.Fields{
+			"section":    &graphql.Field{Type: sectionType},
+			"categories": &graphql.Field{Type: graphql.NewList(categoryType)},
+			"items": &graphql.Field{
+				Type:        graphql.NewList(itemType),
+				Description: "Published posts of the section merged with the externals of its partners, newest first",
+			},
+		},
+	})
+
+	return &graphql.Field{
+		Type:        pageType,
+		Description: "An active section with its categories and a merged, date-sorted stream of posts and partner externals",
+		Args: graphql.FieldConfigArgument{
+			"slug": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"take": &graphql.ArgumentConfig{Type: graphql.Int},
+			"skip": &graphql.ArgumentConfig{Type: graphql.Int},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			take, skip, err := parsePagination(p.Args, opts)
+			if err != nil {
+				return nil, err
+			}
+			slug, _ := p.Args["slug"].(string)
+			page, err := repo.QuerySectionPage(p.Context, slug, opts.SectionPartners[slug], take, skip)
+			if err != nil || page == nil {
+				return nil, err
+			}
+			return page, nil
+		},
+	}
+}
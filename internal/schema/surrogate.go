@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+
+	"go-story/internal/surrogate"
+
+	"github.com/graphql-go/graphql"
+)
+
+// applyEntityTracking 包裝回傳物件的欄位，surrogateKeys 時將結果中的 entity id 記錄為
+// surrogate key（例如 post-123），root 查詢回傳 list 時另外記錄 post-list 等 key；
+// context 中有 EntityCounts 時同時計算各型別的 entity 數量
+func applyEntityTracking(s graphql.Schema, surrogateKeys bool) {
+	queryType := s.QueryType()
+	for name, t := range s.TypeMap() {
+		obj, ok := t.(*graphql.Object)
+		if !ok || strings.HasPrefix(name, "__") {
+			continue
+		}
+		for _, def := range obj.Fields() {
+			target, isList := unwrapObject(def.Type)
+			if target == nil {
+				continue
+			}
+			resolve := def.Resolve
+			if resolve == nil {
+				resolve = graphql.DefaultResolveFn
+			}
+			typeName := target.Name()
+			rootList := obj == queryType && isList
+			def.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+				v, err := resolve(p)
+				return thenResolved(v, err, func(v interface{}, err error) (interface{}, error) {
+					if err != nil {
+						return v, err
+					}
+					var keys *surrogate.Keys
+					if surrogateKeys {
+						keys = surrogate.FromContext(p.Context)
+					}
+					counts := entityCountsFrom(p.Context)
+					if keys != nil && rootList {
+						keys.AddList(typeName)
+					}
+					if keys != nil || counts != nil {
+						walkEntityIDs(v, func(id string) {
+							keys.AddEntity(typeName, id)
+							counts.add(typeName, id)
+						})
+					}
+					return v, err
+				})
+			}
+		}
+	}
+}
+
+// unwrapObject 去掉 NonNull / List，回傳欄位的物件型別與是否為 list
+func unwrapObject(t graphql.Output) (*graphql.Object, bool) {
+	isList := false
+	for {
+		switch v := t.(type) {
+		case *graphql.NonNull:
+			t = v.OfType
+		case *graphql.List:
+			isList = true
+			t = v.OfType
+		case *graphql.Object:
+			return v, isList
+		default:
+			return nil, false
+		}
+	}
+}
+
+// walkEntityIDs 以 reflection 讀取結果（struct、指標或 slice）的 ID 欄位
+func walkEntityIDs(v interface{}, fn func(id string)) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			walkEntityIDs(rv.Index(i).Interface(), fn)
+		}
+	case reflect.Struct:
+		if f := rv.FieldByName("ID"); f.IsValid() && f.Kind() == reflect.String {
+			fn(f.String())
+		}
+	}
+}
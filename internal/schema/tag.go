@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"go-story/internal/data"
+
+	"github.com/graphql-go/graphql"
+)
+
+// tagField 建立 tag 查詢，標籤頁以 tag(where: { slug }) 一次取得 tag 與其文章
+func tagField(repo *data.Repo, tagType *graphql.Object) *graphql.Field {
+	whereInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TagWhereUniqueInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":   &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"slug": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	return &graphql.Field{
+		Type: tagType,
+		Args: graphql.FieldConfigArgument{
+			"where": &graphql.ArgumentConfig{Type: graphql.NewNonNull(whereInput)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			where, err := data.DecodeTagWhereUnique(p.Args["where"])
+			if err != nil {
+				return nil, err
+			}
+			if where == nil || (where.ID == nil) == (where.Slug == nil) {
+				return nil, inputErrorf("tag where must specify exactly one of id or slug")
+			}
+			tag, err := repo.QueryTag(p.Context, where)
+			if err != nil || tag == nil {
+				return nil, err
+			}
+			return tag, nil
+		},
+	}
+}
+
+// normalizeTag 接受 Tag 或 *Tag（post.tags 為值、tag 查詢為指標）
+func normalizeTag(src interface{}) *data.Tag {
+	switch t := src.(type) {
+	case *data.Tag:
+		return t
+	case data.Tag:
+		return &t
+	default:
+		return nil
+	}
+}
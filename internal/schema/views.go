@@ -0,0 +1,31 @@
+package schema
+
+import (
+	"strconv"
+
+	"go-story/internal/data"
+
+	"github.com/graphql-go/graphql"
+)
+
+// recordPostViewField 建立 recordPostView mutation，前端在文章頁載入時呼叫一次
+func recordPostViewField(repo *data.Repo) *graphql.Field {
+	return &graphql.Field{
+		Type:        graphql.Boolean,
+		Description: "Count one view of a published post and return whether it was counted; views are buffered and flushed to the database periodically",
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			id, _ := p.Args["id"].(string)
+			if n, err := strconv.Atoi(id); err != nil || n <= 0 {
+				return nil, inputErrorf("invalid post id %q", id)
+			}
+			counted, err := repo.RecordPostView(p.Context, id)
+			if err != nil {
+				return nil, err
+			}
+			return counted, nil
+		},
+	}
+}
@@ -0,0 +1,134 @@
+-- 本機開發用的示範資料：涵蓋首頁、文章頁、專題、合作夥伴文章與各種關聯，
+-- 以固定的 id 寫入，重複執行時已存在的資料會被略過
+INSERT INTO "Image" (id, name, "imageFile_id", "imageFile_extension", "imageFile_width", "imageFile_height", "topicKeywords") VALUES
+	(1, '颱風過境 台北街景', 'seed-typhoon', 'jpg', 1600, 900, ''),
+	(2, '金曲獎紅毯', 'seed-golden-melody', 'jpg', 1600, 1067, ''),
+	(3, '夜市小吃', 'seed-night-market', 'jpg', 1200, 800, ''),
+	(4, '專題主視覺 台灣山林', 'seed-topic-hero', 'jpg', 1920, 1080, '登山,林道'),
+	(5, '玉山主峰', 'seed-yushan', 'jpg', 1600, 1067, '玉山'),
+	(6, '雪山圈谷', 'seed-xueshan', 'jpg', 1600, 1067, '雪山'),
+	(7, '音樂節舞台', 'seed-music-festival', 'jpg', 1600, 900, '')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO "Video" (id, name, state, "urlOriginal", file_filename, duration, "heroImage") VALUES
+	(1, '颱風現場直擊', 'published', '', 'seed-typhoon.mp4', 95, 1)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO "Audio" (id, name, file_filename, file_filesize, "heroImage", "createdAt", "updatedAt") VALUES
+	(1, '鏡週刊 Podcast：本週焦點', 'seed-podcast-01.mp3', 5242880, 7, now() - interval '2 days', now() - interval '2 days')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO "Section" (id, name, slug, state) VALUES
+	(1, '時事', 'news', 'active'),
+	(2, '娛樂', 'entertainment', 'active'),
+	(3, '生活', 'life', 'active'),
+	(4, '會員專區', 'member', 'active')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO "Category" (id, name, slug, state, "isMemberOnly") VALUES
+	(1, '焦點', 'political', 'active', false),
+	(2, '娛樂頭條', 'celebrity', 'active', false),
+	(3, '美食', 'food', 'active', false),
+	(4, '深度報導', 'report', 'active', true)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO "Contact" (id, name, slug) VALUES
+	(1, '王小明', 'wang-xiaoming'),
+	(2, '陳美玲', 'chen-meiling'),
+	(3, '林志強', 'lin-zhiqiang')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO "Tag" (id, name, slug) VALUES
+	(1, '颱風', 'typhoon'),
+	(2, '金曲獎', 'golden-melody'),
+	(3, '夜市', 'night-market'),
+	(4, '登山', 'hiking'),
+	(5, '天氣', 'weather')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO "Partner" (id, slug, name, "showOnIndex", "showThumb", "showBrief") VALUES
+	(1, 'ebc', '東森新聞', true, true, false),
+	(2, 'healthnews', '健康醫療網', false, true, true)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO "Topic" (id, name, slug, "sortOrder", state, brief, "heroImage", leading, og_title, og_description, og_image, "isFeatured", type, style, "manualOrderOfSlideshowImages", "parentTopic", "createdAt", "updatedAt") VALUES
+	(2, '台灣戶外指南', 'taiwan-outdoors', 2, 'published',
+		'{"blocks":[{"key":"t2b1","text":"登山、露營與單車，整理台灣的戶外專題。","type":"unstyled","depth":0,"inlineStyleRanges":[],"entityRanges":[],"data":{}}],"entityMap":{}}',
+		6, '', '台灣戶外指南', '登山、露營與單車，整理台灣的戶外專題。', 6, false, 'group', '',
+		NULL, NULL, now() - interval '40 days', now() - interval '2 days'),
+	(1, '走進台灣山林', 'taiwan-mountains', 1, 'published',
+		'{"blocks":[{"key":"t1b1","text":"百岳、林道與山屋，帶你認識台灣的高山。","type":"unstyled","depth":0,"inlineStyleRanges":[],"entityRanges":[],"data":{}}],"entityMap":{}}',
+		4, 'slideshow', '走進台灣山林', '百岳、林道與山屋，帶你認識台灣的高山。', 4, true, 'list', '',
+		'[{"id":"6"},{"id":"5"}]', 2, now() - interval '30 days', now() - interval '1 day')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO "Post" (id, slug, title, subtitle, state, style, "isMember", "publishedDate", "heroCaption", "heroImage", "heroVideo", brief, content, og_title, og_description, "isFeatured", topics, "heroAudio", "createdAt", "updatedAt") VALUES
+	(1, '20261001-typhoon', '颱風逼近 北部今晚起防強風豪雨', '氣象署發布海上陸上颱風警報', 'published', 'article', false, now() - interval '6 hours', '颱風外圍環流影響，台北街頭風雨交加。', 1, 1,
+		'{"blocks":[{"key":"p1b1","text":"颱風持續逼近，氣象署提醒北部及東北部民眾嚴防強風豪雨。","type":"unstyled","depth":0,"inlineStyleRanges":[],"entityRanges":[],"data":{}}],"entityMap":{}}',
+		'{"blocks":[{"key":"p1c1","text":"氣象署表示，颱風中心今晚將通過北部海面。","type":"unstyled","depth":0,"inlineStyleRanges":[],"entityRanges":[],"data":{}},{"key":"p1c2","text":"防颱準備","type":"header-two","depth":0,"inlineStyleRanges":[],"entityRanges":[],"data":{}},{"key":"p1c3","text":"請民眾減少外出，並注意最新警報。","type":"unstyled","depth":0,"inlineStyleRanges":[{"offset":0,"length":6,"style":"BOLD"}],"entityRanges":[],"data":{}}],"entityMap":{}}',
+		'颱風逼近 北部今晚起防強風豪雨', '氣象署發布海上陸上颱風警報', true, NULL, NULL, now() - interval '7 hours', now() - interval '6 hours'),
+	(2, '20261002-golden-melody', '金曲獎紅毯 星光熠熠', '', 'published', 'article', false, now() - interval '1 day', '入圍者陸續走上紅毯。', 2, NULL,
+		'{"blocks":[{"key":"p2b1","text":"金曲獎今晚登場，入圍者盛裝出席。","type":"unstyled","depth":0,"inlineStyleRanges":[],"entityRanges":[],"data":{}}],"entityMap":{}}',
+		'{"blocks":[{"key":"p2c1","text":"今年金曲獎共有多位新人入圍，競爭激烈。","type":"unstyled","depth":0,"inlineStyleRanges":[],"entityRanges":[],"data":{}}],"entityMap":{}}',
+		'', '', false, NULL, NULL, now() - interval '1 day', now() - interval '1 day'),
+	(3, '20261003-night-market', '在地人推薦的夜市小吃', '', 'published', 'wide', false, now() - interval '2 days', '', 3, NULL,
+		'{"blocks":[{"key":"p3b1","text":"從蚵仔煎到胡椒餅，一次吃遍夜市經典。","type":"unstyled","depth":0,"inlineStyleRanges":[],"entityRanges":[],"data":{}}],"entityMap":{}}',
+		'{"blocks":[{"key":"p3c1","text":"夜市是台灣飲食文化的縮影。","type":"unstyled","depth":0,"inlineStyleRanges":[],"entityRanges":[],"data":{}}],"entityMap":{}}',
+		'', '', false, NULL, 1, now() - interval '2 days', now() - interval '2 days'),
+	(4, '20261004-yushan', '玉山攻頂全紀錄', '第一次挑戰百岳就上手', 'published', 'article', false, now() - interval '3 days', '', 5, NULL,
+		'{"blocks":[{"key":"p4b1","text":"從排雲山莊出發，凌晨摸黑攻頂看日出。","type":"unstyled","depth":0,"inlineStyleRanges":[],"entityRanges":[],"data":{}}],"entityMap":{}}',
+		'{"blocks":[{"key":"p4c1","text":"申請入園與山屋床位是第一步。","type":"unstyled","depth":0,"inlineStyleRanges":[],"entityRanges":[],"data":{}}],"entityMap":{}}',
+		'', '', false, 1, NULL, now() - interval '3 days', now() - interval '3 days'),
+	(5, '20261005-member-report', '會員獨享：山屋經濟調查', '', 'published', 'article', true, now() - interval '4 days', '', 6, NULL,
+		'{"blocks":[{"key":"p5b1","text":"山屋一位難求，背後的供需問題。","type":"unstyled","depth":0,"inlineStyleRanges":[],"entityRanges":[],"data":{}}],"entityMap":{}}',
+		'{"blocks":[{"key":"p5c1","text":"本文為會員專屬內容。","type":"unstyled","depth":0,"inlineStyleRanges":[],"entityRanges":[],"data":{}},{"key":"p5c2","text":"調查發現，熱門山屋的抽籤中籤率不到兩成。","type":"unstyled","depth":0,"inlineStyleRanges":[],"entityRanges":[],"data":{}}],"entityMap":{}}',
+		'', '', false, 1, NULL, now() - interval '4 days', now() - interval '4 days'),
+	(6, '20261006-draft', '尚未發布的草稿', '', 'draft', 'article', false, NULL, '', NULL, NULL,
+		'{"blocks":[],"entityMap":{}}', '{"blocks":[],"entityMap":{}}',
+		'', '', false, NULL, NULL, now() - interval '1 hour', now() - interval '1 hour')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO "_Post_sections" ("A", "B") VALUES (1, 1), (2, 2), (3, 3), (4, 3), (5, 4), (6, 1) ON CONFLICT DO NOTHING;
+INSERT INTO "_Category_sections" ("A", "B") VALUES (1, 1), (2, 2), (3, 3), (4, 4) ON CONFLICT DO NOTHING;
+INSERT INTO "_Category_posts" ("A", "B") VALUES (1, 1), (2, 2), (3, 3), (4, 5), (1, 6) ON CONFLICT DO NOTHING;
+INSERT INTO "_Post_tags" ("A", "B") VALUES (1, 1), (1, 5), (2, 2), (3, 3), (4, 4), (5, 4) ON CONFLICT DO NOTHING;
+INSERT INTO "_Post_tags_algo" ("A", "B") VALUES (1, 5), (4, 4) ON CONFLICT DO NOTHING;
+INSERT INTO "_Post_relateds" ("A", "B") VALUES (1, 4), (4, 5), (5, 4) ON CONFLICT DO NOTHING;
+INSERT INTO "_Post_writers" ("A", "B") VALUES (1, 1), (2, 2), (1, 3), (3, 4), (3, 5) ON CONFLICT DO NOTHING;
+INSERT INTO "_Post_photographers" ("A", "B") VALUES (2, 1), (3, 4) ON CONFLICT DO NOTHING;
+INSERT INTO "Tag_topics" ("A", "B") VALUES (1, 4) ON CONFLICT DO NOTHING;
+INSERT INTO "Topic_slideshow_images" ("A", "B") VALUES (1, 5), (1, 6) ON CONFLICT DO NOTHING;
+
+INSERT INTO "External" (id, slug, title, state, "publishedDate", extend_byline, thumb, "thumbCaption", brief, content, partner, "createdAt", "updatedAt") VALUES
+	(1, 'ebc-20261001-traffic', '颱風影響 國道車流管制', 'published', now() - interval '5 hours', '東森新聞', 'https://example.com/seed/ebc-traffic.jpg', '', '<p>國道部分路段實施車流管制。</p>', '<p>高公局表示，颱風期間國道部分路段將實施管制，請用路人留意。</p>', 1, now() - interval '5 hours', now() - interval '5 hours'),
+	(2, 'healthnews-20261002-sleep', '睡不好？醫師教你改善失眠', 'published', now() - interval '1 day', '健康醫療網', 'https://example.com/seed/healthnews-sleep.jpg', '', '<p>規律作息是改善睡眠的第一步。</p>', '<p>醫師建議睡前一小時避免使用手機。</p>', 2, now() - interval '1 day', now() - interval '1 day')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO "_External_tags" ("A", "B") VALUES (1, 1) ON CONFLICT DO NOTHING;
+INSERT INTO "_External_relateds" ("A", "B") VALUES (1, 1) ON CONFLICT DO NOTHING;
+
+INSERT INTO "EditorChoice" (id, name, "sortOrder", state, "publishedDate", choices) VALUES
+	(1, '颱風逼近', 1, 'published', now() - interval '6 hours', 1),
+	(2, '玉山攻頂', 2, 'published', now() - interval '3 days', 4)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO "Event" (id, name, slug, state, "eventType", link, "startDate", "endDate", "publishedDate", "heroImage") VALUES
+	(1, '秋季音樂節', 'autumn-music-festival', 'published', 'mod', 'https://example.com/seed/festival', now() - interval '1 day', now() + interval '2 days', now() - interval '3 days', 7)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO "PostViews" (post, views) VALUES (1, 1280), (2, 640), (3, 320), (4, 160) ON CONFLICT DO NOTHING;
+
+-- 以固定 id 寫入後，將 serial 調整到目前最大值，之後新增的資料才不會撞號
+SELECT setval(pg_get_serial_sequence('"Image"', 'id'), (SELECT COALESCE(MAX(id), 0) + 1 FROM "Image"), false);
+SELECT setval(pg_get_serial_sequence('"Video"', 'id'), (SELECT COALESCE(MAX(id), 0) + 1 FROM "Video"), false);
+SELECT setval(pg_get_serial_sequence('"Audio"', 'id'), (SELECT COALESCE(MAX(id), 0) + 1 FROM "Audio"), false);
+SELECT setval(pg_get_serial_sequence('"Section"', 'id'), (SELECT COALESCE(MAX(id), 0) + 1 FROM "Section"), false);
+SELECT setval(pg_get_serial_sequence('"Category"', 'id'), (SELECT COALESCE(MAX(id), 0) + 1 FROM "Category"), false);
+SELECT setval(pg_get_serial_sequence('"Contact"', 'id'), (SELECT COALESCE(MAX(id), 0) + 1 FROM "Contact"), false);
+SELECT setval(pg_get_serial_sequence('"Tag"', 'id'), (SELECT COALESCE(MAX(id), 0) + 1 FROM "Tag"), false);
+SELECT setval(pg_get_serial_sequence('"Partner"', 'id'), (SELECT COALESCE(MAX(id), 0) + 1 FROM "Partner"), false);
+SELECT setval(pg_get_serial_sequence('"Topic"', 'id'), (SELECT COALESCE(MAX(id), 0) + 1 FROM "Topic"), false);
+SELECT setval(pg_get_serial_sequence('"Post"', 'id'), (SELECT COALESCE(MAX(id), 0) + 1 FROM "Post"), false);
+SELECT setval(pg_get_serial_sequence('"External"', 'id'), (SELECT COALESCE(MAX(id), 0) + 1 FROM "External"), false);
+SELECT setval(pg_get_serial_sequence('"EditorChoice"', 'id'), (SELECT COALESCE(MAX(id), 0) + 1 FROM "EditorChoice"), false);
+SELECT setval(pg_get_serial_sequence('"Event"', 'id'), (SELECT COALESCE(MAX(id), 0) + 1 FROM "Event"), false);
@@ -0,0 +1,197 @@
+-- 本機開發用的最小 schema：只包含 go-story 會查詢的資料表與欄位，
+-- 欄位名稱與 Keystone 產生的 CMS schema 相同；已存在的資料表不會被修改
+CREATE TABLE IF NOT EXISTS "Image" (
+	id serial PRIMARY KEY,
+	name text NOT NULL DEFAULT '',
+	"imageFile_id" text,
+	"imageFile_extension" text,
+	"imageFile_width" integer,
+	"imageFile_height" integer,
+	"topicKeywords" text NOT NULL DEFAULT '',
+	"createdAt" timestamptz DEFAULT now(),
+	"updatedAt" timestamptz DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS "Video" (
+	id serial PRIMARY KEY,
+	name text NOT NULL DEFAULT '',
+	state text NOT NULL DEFAULT 'draft',
+	"urlOriginal" text NOT NULL DEFAULT '',
+	file_filename text,
+	duration integer NOT NULL DEFAULT 0,
+	"heroImage" integer REFERENCES "Image"(id) ON DELETE SET NULL,
+	"createdAt" timestamptz DEFAULT now(),
+	"updatedAt" timestamptz DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS "Audio" (
+	id serial PRIMARY KEY,
+	name text NOT NULL DEFAULT '',
+	file_filename text,
+	file_filesize integer,
+	"heroImage" integer REFERENCES "Image"(id) ON DELETE SET NULL,
+	"createdAt" timestamptz DEFAULT now(),
+	"updatedAt" timestamptz DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS "Section" (
+	id serial PRIMARY KEY,
+	name text NOT NULL DEFAULT '',
+	slug text NOT NULL DEFAULT '' UNIQUE,
+	state text NOT NULL DEFAULT 'active'
+);
+
+CREATE TABLE IF NOT EXISTS "Category" (
+	id serial PRIMARY KEY,
+	name text NOT NULL DEFAULT '',
+	slug text NOT NULL DEFAULT '' UNIQUE,
+	state text NOT NULL DEFAULT 'active',
+	"isMemberOnly" boolean NOT NULL DEFAULT false
+);
+
+CREATE TABLE IF NOT EXISTS "Contact" (
+	id serial PRIMARY KEY,
+	name text NOT NULL DEFAULT '',
+	slug text NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS "Tag" (
+	id serial PRIMARY KEY,
+	name text NOT NULL DEFAULT '',
+	slug text NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS "Partner" (
+	id serial PRIMARY KEY,
+	slug text NOT NULL DEFAULT '' UNIQUE,
+	name text NOT NULL DEFAULT '',
+	"showOnIndex" boolean NOT NULL DEFAULT false,
+	"showThumb" boolean NOT NULL DEFAULT true,
+	"showBrief" boolean NOT NULL DEFAULT false
+);
+
+CREATE TABLE IF NOT EXISTS "Topic" (
+	id serial PRIMARY KEY,
+	name text NOT NULL DEFAULT '',
+	slug text NOT NULL DEFAULT '' UNIQUE,
+	"sortOrder" integer,
+	state text NOT NULL DEFAULT 'draft',
+	brief jsonb,
+	"heroImage" integer REFERENCES "Image"(id) ON DELETE SET NULL,
+	"heroUrl" text NOT NULL DEFAULT '',
+	leading text NOT NULL DEFAULT '',
+	og_title text NOT NULL DEFAULT '',
+	og_description text NOT NULL DEFAULT '',
+	og_image integer REFERENCES "Image"(id) ON DELETE SET NULL,
+	"isFeatured" boolean NOT NULL DEFAULT false,
+	title_style text NOT NULL DEFAULT '',
+	type text NOT NULL DEFAULT '',
+	style text NOT NULL DEFAULT '',
+	javascript text NOT NULL DEFAULT '',
+	dfp text NOT NULL DEFAULT '',
+	mobile_dfp text NOT NULL DEFAULT '',
+	"manualOrderOfSlideshowImages" jsonb,
+	"parentTopic" integer REFERENCES "Topic"(id) ON DELETE SET NULL,
+	"createdAt" timestamptz DEFAULT now(),
+	"updatedAt" timestamptz DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS "Post" (
+	id serial PRIMARY KEY,
+	slug text NOT NULL DEFAULT '' UNIQUE,
+	title text NOT NULL DEFAULT '',
+	subtitle text NOT NULL DEFAULT '',
+	state text NOT NULL DEFAULT 'draft',
+	style text NOT NULL DEFAULT 'article',
+	"isMember" boolean NOT NULL DEFAULT false,
+	"isAdult" boolean NOT NULL DEFAULT false,
+	"publishedDate" timestamptz,
+	"heroCaption" text NOT NULL DEFAULT '',
+	extend_byline text NOT NULL DEFAULT '',
+	"heroImage" integer REFERENCES "Image"(id) ON DELETE SET NULL,
+	"heroVideo" integer REFERENCES "Video"(id) ON DELETE SET NULL,
+	brief jsonb,
+	content jsonb,
+	redirect text NOT NULL DEFAULT '',
+	og_title text NOT NULL DEFAULT '',
+	og_description text NOT NULL DEFAULT '',
+	"hiddenAdvertised" boolean NOT NULL DEFAULT false,
+	"isAdvertised" boolean NOT NULL DEFAULT false,
+	"isFeatured" boolean NOT NULL DEFAULT false,
+	topics integer REFERENCES "Topic"(id) ON DELETE SET NULL,
+	og_image integer REFERENCES "Image"(id) ON DELETE SET NULL,
+	"relatedsOne" integer REFERENCES "Post"(id) ON DELETE SET NULL,
+	"relatedsTwo" integer REFERENCES "Post"(id) ON DELETE SET NULL,
+	"heroAudio" integer REFERENCES "Audio"(id) ON DELETE SET NULL,
+	audio integer REFERENCES "Audio"(id) ON DELETE SET NULL,
+	"manualOrderOfRelateds" jsonb,
+	"createdAt" timestamptz DEFAULT now(),
+	"updatedAt" timestamptz DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS "External" (
+	id serial PRIMARY KEY,
+	slug text NOT NULL DEFAULT '' UNIQUE,
+	title text NOT NULL DEFAULT '',
+	state text NOT NULL DEFAULT 'draft',
+	"publishedDate" timestamptz,
+	extend_byline text NOT NULL DEFAULT '',
+	thumb text NOT NULL DEFAULT '',
+	"thumbCaption" text NOT NULL DEFAULT '',
+	brief text NOT NULL DEFAULT '',
+	content text NOT NULL DEFAULT '',
+	partner integer REFERENCES "Partner"(id) ON DELETE SET NULL,
+	"createdAt" timestamptz DEFAULT now(),
+	"updatedAt" timestamptz DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS "EditorChoice" (
+	id serial PRIMARY KEY,
+	name text NOT NULL DEFAULT '',
+	"sortOrder" integer,
+	state text NOT NULL DEFAULT 'draft',
+	"publishedDate" timestamptz,
+	choices integer REFERENCES "Post"(id) ON DELETE SET NULL,
+	"createdAt" timestamptz DEFAULT now(),
+	"updatedAt" timestamptz DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS "Event" (
+	id serial PRIMARY KEY,
+	name text NOT NULL DEFAULT '',
+	slug text NOT NULL DEFAULT '',
+	state text NOT NULL DEFAULT 'draft',
+	"eventType" text NOT NULL DEFAULT '',
+	link text NOT NULL DEFAULT '',
+	"embedCode" text NOT NULL DEFAULT '',
+	"startDate" timestamptz,
+	"endDate" timestamptz,
+	"publishedDate" timestamptz,
+	"heroImage" integer REFERENCES "Image"(id) ON DELETE SET NULL,
+	"createdAt" timestamptz DEFAULT now(),
+	"updatedAt" timestamptz DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS "PostViews" (
+	post integer PRIMARY KEY,
+	views bigint NOT NULL DEFAULT 0,
+	"updatedAt" timestamptz NOT NULL DEFAULT now()
+);
+
+-- 多對多關聯表，"A"/"B" 依 Keystone 的慣例以 list 名稱的字母順序決定
+CREATE TABLE IF NOT EXISTS "_Post_sections" ("A" integer NOT NULL REFERENCES "Post"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Section"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "_Category_sections" ("A" integer NOT NULL REFERENCES "Category"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Section"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "_Category_posts" ("A" integer NOT NULL REFERENCES "Category"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Post"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "_Post_tags" ("A" integer NOT NULL REFERENCES "Post"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Tag"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "_Post_tags_algo" ("A" integer NOT NULL REFERENCES "Post"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Tag"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "_Post_relateds" ("A" integer NOT NULL REFERENCES "Post"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Post"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "_Post_writers" ("A" integer NOT NULL REFERENCES "Contact"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Post"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "_Post_photographers" ("A" integer NOT NULL REFERENCES "Contact"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Post"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "_Post_camera_man" ("A" integer NOT NULL REFERENCES "Contact"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Post"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "_Post_designers" ("A" integer NOT NULL REFERENCES "Contact"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Post"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "_Post_engineers" ("A" integer NOT NULL REFERENCES "Contact"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Post"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "_Post_vocals" ("A" integer NOT NULL REFERENCES "Contact"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Post"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "_External_tags" ("A" integer NOT NULL REFERENCES "External"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Tag"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "_External_relateds" ("A" integer NOT NULL REFERENCES "External"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Post"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "Tag_topics" ("A" integer NOT NULL REFERENCES "Topic"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Tag"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
+CREATE TABLE IF NOT EXISTS "Topic_slideshow_images" ("A" integer NOT NULL REFERENCES "Topic"(id) ON DELETE CASCADE, "B" integer NOT NULL REFERENCES "Image"(id) ON DELETE CASCADE, UNIQUE ("A", "B"));
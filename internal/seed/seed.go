@@ -0,0 +1,67 @@
+// Package seed creates the tables go-story reads and loads a small fixture
+// dataset, so the service can run against an empty local Postgres.
+package seed
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"strings"
+)
+
+//go:embed schema.sql
+var schemaSQL string
+
+//go:embed fixtures.sql
+var fixturesSQL string
+
+// tables 為 schema.sql 建立的資料表，reset 時一併清空
+var tables = []string{
+	"_Post_sections", "_Category_posts", "_Category_sections", "_Post_tags", "_Post_tags_algo", "_Post_relateds",
+	"_Post_writers", "_Post_photographers", "_Post_camera_man", "_Post_designers", "_Post_engineers", "_Post_vocals",
+	"_External_tags", "_External_relateds", "Tag_topics", "Topic_slideshow_images",
+	"PostViews", "EditorChoice", "Event", "External", "Post", "Topic",
+	"Partner", "Tag", "Contact", "Category", "Section", "Audio", "Video", "Image",
+}
+
+// Options controls what Run does.
+type Options struct {
+	// Reset 載入前清空所有相關資料表，會刪除既有資料
+	Reset bool
+	// SchemaOnly 只建立資料表，不寫入示範資料
+	SchemaOnly bool
+}
+
+// Run creates the missing tables and loads the fixtures in one transaction.
+// Existing rows are left alone unless opts.Reset is set.
+func Run(ctx context.Context, db *sql.DB, opts Options) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 沒有參數時以 simple protocol 執行，可一次送出多個 statement
+	if _, err := tx.ExecContext(ctx, schemaSQL); err != nil {
+		return fmt.Errorf("create tables: %w", err)
+	}
+	if opts.Reset {
+		quoted := make([]string, len(tables))
+		for i, t := range tables {
+			quoted[i] = `"` + t + `"`
+		}
+		if _, err := tx.ExecContext(ctx, "TRUNCATE "+strings.Join(quoted, ", ")+" RESTART IDENTITY CASCADE"); err != nil {
+			return fmt.Errorf("reset tables: %w", err)
+		}
+	}
+	if !opts.SchemaOnly {
+		if _, err := tx.ExecContext(ctx, fixturesSQL); err != nil {
+			return fmt.Errorf("load fixtures: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
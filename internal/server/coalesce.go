@@ -0,0 +1,50 @@
+package server
+
+import (
+	"go-story/internal/cachecontrol"
+	"go-story/internal/metrics"
+
+	"golang.org/x/sync/singleflight"
+)
+
+var coalescedCounter = metrics.NewCounter(
+	"go_story_graphql_coalesced_total",
+	"Number of GraphQL requests answered with the result of an identical in-flight request.",
+)
+
+// Coalescer executes identical anonymous queries that arrive at the same
+// time only once and shares the response among them, e.g. when the
+// homepage cache expires. A nil Coalescer executes every request.
+type Coalescer struct {
+	group singleflight.Group
+}
+
+// executedResponse 為執行後的回應，合併的請求共用同一份
+type executedResponse struct {
+	body []byte
+	// keys 為輸出在 header 的 surrogate key；allKeys 不受 header 長度限制，作為回應快取的 entity 索引
+	keys      []string
+	allKeys   []string
+	hasErrors bool
+	// hint 為回應的 @cacheControl，hinted 為 false 時沒有任何欄位帶 hint
+	hint   cachecontrol.Hint
+	hinted bool
+	// cacheStatus、cachePrefixes 為執行時 Redis cache 的使用情形，見 writeCacheHeaders
+	cacheStatus   string
+	cachePrefixes string
+	// entities 為回應中各型別的 entity 數量，見 schema.EntityCounts
+	entities string
+}
+
+// do 以 key 合併同時進行的 exec，回傳結果與是否為共用的結果
+func (c *Coalescer) do(key string, exec func() (executedResponse, error)) (executedResponse, bool, error) {
+	if c == nil {
+		resp, err := exec()
+		return resp, false, err
+	}
+	v, err, shared := c.group.Do(key, func() (interface{}, error) {
+		return exec()
+	})
+	resp, _ := v.(executedResponse)
+	return resp, shared, err
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"strings"
+	"time"
+
+	"go-story/internal/data"
+	"go-story/internal/schema"
+)
+
+// RequireAdmin only lets requests carrying "Authorization: Bearer <token>"
+// through to next. With an empty token the endpoint is disabled and
+// responds 404, so debug endpoints are never exposed by accident.
+func RequireAdmin(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			http.NotFound(w, r)
+			return
+		}
+		if !hasBearer(r, token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// MarkAdmin marks requests carrying "Authorization: Bearer <token>" with
+// schema.WithAdmin so they can run the admin mutations; other requests are
+// passed through unchanged.
+func MarkAdmin(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" && hasBearer(r, token) {
+			r = r.WithContext(schema.WithAdmin(r.Context()))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hasBearer 以固定時間比對 Authorization header 的 Bearer token
+func hasBearer(r *http.Request, token string) bool {
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
+
+// DBStatsHandler serves the connection pool stats of db and the query load
+// seen by the repo as JSON. A high waitCount with few in-flight queries
+// points to pool exhaustion; a high latency with idle connections points to
+// slow queries.
+func DBStatsHandler(db *sql.DB, load func() data.DBLoad) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stats := db.Stats()
+		current := load()
+		writeJSON(w, map[string]any{
+			"maxOpenConnections": stats.MaxOpenConnections,
+			"openConnections":    stats.OpenConnections,
+			"inUse":              stats.InUse,
+			"idle":               stats.Idle,
+			"waitCount":          stats.WaitCount,
+			"waitDurationMs":     stats.WaitDuration.Milliseconds(),
+			"maxIdleClosed":      stats.MaxIdleClosed,
+			"maxIdleTimeClosed":  stats.MaxIdleTimeClosed,
+			"maxLifetimeClosed":  stats.MaxLifetimeClosed,
+			"inFlightQueries":    current.InFlight,
+			"recentLatencyMs":    float64(current.Latency.Microseconds()) / 1000,
+		})
+	})
+}
+
+// CacheStatsHandler serves the cache hit/miss counters per key prefix and
+// the Redis memory usage as JSON.
+func CacheStatsHandler(cache *data.Cache) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+		defer cancel()
+		writeJSON(w, cache.Stats(ctx))
+	})
+}
+
+// PprofHandler serves the net/http/pprof profiles under /debug/pprof/. It is
+// only mounted on the admin listener.
+func PprofHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,288 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// deferContentType 與 Apollo Client 相同的 @defer 增量回傳格式
+const deferContentType = `multipart/mixed; boundary="-"; deferSpec=20220824`
+
+// wantsDeferred 判斷是否以 multipart/mixed 分段回傳；client 不接受時 @defer 被忽略，整份一次回傳
+func wantsDeferred(r *http.Request, query string) bool {
+	return strings.Contains(r.Header.Get("Accept"), "multipart/mixed") && strings.Contains(query, "@defer")
+}
+
+// deferredPart 為一個延後回傳的 fragment；steps 為從 root 到 fragment 位置經過的 field 與 inline fragment
+type deferredPart struct {
+	label    string
+	steps    []ast.Selection
+	fragment ast.Selection
+}
+
+// incrementalItem 為後續分段中的一筆資料，path 為 fragment 在回應中的位置
+type incrementalItem struct {
+	Data   map[string]interface{}     `json:"data"`
+	Path   []interface{}              `json:"path"`
+	Label  string                     `json:"label,omitempty"`
+	Errors []gqlerrors.FormattedError `json:"errors,omitempty"`
+}
+
+// serveDeferred 先執行移除 @defer fragment 的查詢並回傳，再逐一執行各 fragment，
+// 以 incremental 分段回傳；只有 query 會分段，mutation 忽略 @defer。fragment 所在路徑上的 resolver 會再執行一次（通常命中 cache）；
+// 只處理 operation 中的 @defer，named fragment 定義內的 @defer 會被忽略
+func serveDeferred(w http.ResponseWriter, ctx context.Context, gqlSchema graphql.Schema, payload graphqlPayload, hideHints bool) {
+	doc, err := parser.Parse(parser.ParseParams{Source: payload.Query})
+	if err != nil {
+		writeJSONResult(w, &graphql.Result{Errors: requestErrors(gqlerrors.FormatErrors(err), true, hideHints)})
+		return
+	}
+	if result := graphql.ValidateDocument(&gqlSchema, doc, nil); !result.IsValid {
+		writeJSONResult(w, &graphql.Result{Errors: requestErrors(result.Errors, false, hideHints)})
+		return
+	}
+	op := selectedOperation(doc, payload.OperationName)
+	if op == nil {
+		// 交給 graphql.Execute 回報找不到 operation 的錯誤
+		writeJSONResult(w, graphql.Execute(graphql.ExecuteParams{Schema: gqlSchema, AST: doc, OperationName: payload.OperationName, Args: payload.Variables, Context: ctx}))
+		return
+	}
+
+	execute := func(op *ast.OperationDefinition) *graphql.Result {
+		return graphql.Execute(graphql.ExecuteParams{
+			Schema:  gqlSchema,
+			AST:     withOperation(doc, op),
+			Args:    payload.Variables,
+			Context: ctx,
+		})
+	}
+	// 每個延後的 fragment 都會重新執行一次 operation，mutation 不可重複執行，忽略 @defer 一次回傳
+	if op.Operation != ast.OperationTypeQuery {
+		writeJSONResult(w, execute(op))
+		return
+	}
+
+	var parts []deferredPart
+	initial := *op
+	initial.SelectionSet = stripDeferred(op.SelectionSet, nil, payload.Variables, &parts)
+	result := execute(&initial)
+	if len(parts) == 0 {
+		writeJSONResult(w, result)
+		return
+	}
+
+	w.Header().Set("Content-Type", deferContentType)
+	flusher, _ := w.(http.Flusher)
+	writePart := func(v interface{}) {
+		body, err := json.Marshal(v)
+		if err != nil {
+			return
+		}
+		_, _ = w.Write([]byte("\r\n---\r\nContent-Type: application/json; charset=utf-8\r\n\r\n"))
+		_, _ = w.Write(body)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	first := map[string]interface{}{"data": result.Data, "hasNext": true}
+	if len(result.Errors) > 0 {
+		first["errors"] = result.Errors
+	}
+	writePart(first)
+
+	for i, part := range parts {
+		if ctx.Err() != nil {
+			return
+		}
+		partResult := execute(part.operation(op))
+		var items []incrementalItem
+		collectIncremental(partResult.Data, part.keys(), nil, &items)
+		if len(partResult.Errors) > 0 {
+			if len(items) == 0 {
+				items = append(items, incrementalItem{Path: pathOf(part.keys())})
+			}
+			items[0].Errors = partResult.Errors
+		}
+		for j := range items {
+			items[j].Label = part.label
+		}
+		writePart(map[string]interface{}{"incremental": items, "hasNext": i < len(parts)-1})
+	}
+	_, _ = w.Write([]byte("\r\n-----\r\n"))
+}
+
+// stripDeferred 複製 selection set 並移除 @defer fragment，移除的部分記錄到 parts
+func stripDeferred(set *ast.SelectionSet, steps []ast.Selection, vars map[string]interface{}, parts *[]deferredPart) *ast.SelectionSet {
+	if set == nil {
+		return nil
+	}
+	out := &ast.SelectionSet{Kind: set.Kind, Loc: set.Loc}
+	for _, sel := range set.Selections {
+		switch s := sel.(type) {
+		case *ast.Field:
+			f := *s
+			f.SelectionSet = stripDeferred(s.SelectionSet, appendStep(steps, &f), vars, parts)
+			out.Selections = append(out.Selections, &f)
+		case *ast.InlineFragment:
+			frag := *s
+			if directives, label, ok := removeDefer(s.Directives, vars); ok {
+				frag.Directives = directives
+				*parts = append(*parts, deferredPart{label: label, steps: steps, fragment: &frag})
+				continue
+			}
+			frag.SelectionSet = stripDeferred(s.SelectionSet, appendStep(steps, &frag), vars, parts)
+			out.Selections = append(out.Selections, &frag)
+		case *ast.FragmentSpread:
+			if directives, label, ok := removeDefer(s.Directives, vars); ok {
+				spread := *s
+				spread.Directives = directives
+				*parts = append(*parts, deferredPart{label: label, steps: steps, fragment: &spread})
+				continue
+			}
+			out.Selections = append(out.Selections, s)
+		default:
+			out.Selections = append(out.Selections, sel)
+		}
+	}
+	// 所有欄位都被延後時保留 __typename，避免空的 selection set
+	if len(out.Selections) == 0 {
+		out.Selections = []ast.Selection{&ast.Field{Kind: kinds.Field, Name: &ast.Name{Kind: kinds.Name, Value: "__typename"}}}
+	}
+	return out
+}
+
+// appendStep 複製 steps 再加上 sel，避免不同分支共用同一個底層陣列
+func appendStep(steps []ast.Selection, sel ast.Selection) []ast.Selection {
+	return append(steps[:len(steps):len(steps)], sel)
+}
+
+// removeDefer 回傳移除 @defer 後的 directives 與 label；沒有 @defer 或 if 為 false 時回傳 false
+func removeDefer(directives []*ast.Directive, vars map[string]interface{}) ([]*ast.Directive, string, bool) {
+	for i, d := range directives {
+		if d.Name == nil || d.Name.Value != "defer" {
+			continue
+		}
+		label := ""
+		for _, arg := range d.Arguments {
+			v := argumentValue(arg.Value, vars)
+			switch arg.Name.Value {
+			case "if":
+				if b, ok := v.(bool); ok && !b {
+					return directives, "", false
+				}
+			case "label":
+				label, _ = v.(string)
+			}
+		}
+		rest := append(append([]*ast.Directive{}, directives[:i]...), directives[i+1:]...)
+		return rest, label, true
+	}
+	return directives, "", false
+}
+
+// argumentValue 取出 directive 參數的值，變數以 variables 代入
+func argumentValue(v ast.Value, vars map[string]interface{}) interface{} {
+	switch val := v.(type) {
+	case *ast.Variable:
+		if val.Name == nil {
+			return nil
+		}
+		return vars[val.Name.Value]
+	case *ast.BooleanValue:
+		return val.Value
+	case *ast.StringValue:
+		return val.Value
+	}
+	return nil
+}
+
+// operation 組出只查詢此 fragment 的 operation：沿著 steps 保留 field 與參數，最內層只放 fragment
+func (p deferredPart) operation(op *ast.OperationDefinition) *ast.OperationDefinition {
+	sel := p.fragment
+	for i := len(p.steps) - 1; i >= 0; i-- {
+		set := &ast.SelectionSet{Kind: kinds.SelectionSet, Selections: []ast.Selection{sel}}
+		switch s := p.steps[i].(type) {
+		case *ast.Field:
+			f := *s
+			f.SelectionSet = set
+			sel = &f
+		case *ast.InlineFragment:
+			frag := *s
+			frag.SelectionSet = set
+			sel = &frag
+		}
+	}
+	out := *op
+	out.SelectionSet = &ast.SelectionSet{Kind: kinds.SelectionSet, Selections: []ast.Selection{sel}}
+	return &out
+}
+
+// keys 回傳 fragment 所在位置的回應 key（alias 優先）
+func (p deferredPart) keys() []string {
+	var keys []string
+	for _, step := range p.steps {
+		f, ok := step.(*ast.Field)
+		if !ok {
+			continue
+		}
+		if f.Alias != nil && f.Alias.Value != "" {
+			keys = append(keys, f.Alias.Value)
+		} else {
+			keys = append(keys, f.Name.Value)
+		}
+	}
+	return keys
+}
+
+// withOperation 以 op 取代文件中的 operation，保留 fragment 定義
+func withOperation(doc *ast.Document, op *ast.OperationDefinition) *ast.Document {
+	out := &ast.Document{Kind: doc.Kind, Loc: doc.Loc, Definitions: []ast.Node{op}}
+	for _, def := range doc.Definitions {
+		if _, ok := def.(*ast.FragmentDefinition); ok {
+			out.Definitions = append(out.Definitions, def)
+		}
+	}
+	return out
+}
+
+// collectIncremental 依 keys 找出 fragment 的資料；路徑上的 list 每個元素各自成為一筆，path 帶 index
+func collectIncremental(v interface{}, keys []string, path []interface{}, items *[]incrementalItem) {
+	switch val := v.(type) {
+	case []interface{}:
+		for i, elem := range val {
+			collectIncremental(elem, keys, append(path[:len(path):len(path)], i), items)
+		}
+	case map[string]interface{}:
+		if len(keys) == 0 {
+			*items = append(*items, incrementalItem{Data: val, Path: append([]interface{}{}, path...)})
+			return
+		}
+		collectIncremental(val[keys[0]], keys[1:], append(path[:len(path):len(path)], keys[0]), items)
+	}
+}
+
+func pathOf(keys []string) []interface{} {
+	path := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		path = append(path, k)
+	}
+	return path
+}
+
+// writeJSONResult 以一般的 JSON 回應輸出 result
+func writeJSONResult(w http.ResponseWriter, result *graphql.Result) {
+	body, err := json.Marshal(result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeGraphQLResponse(w, body, nil)
+}
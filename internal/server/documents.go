@@ -0,0 +1,79 @@
+package server
+
+import (
+	"crypto/sha256"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// defaultMaxDocuments DocumentCache 未設定 MaxEntries 時的上限
+const defaultMaxDocuments = 1000
+
+// DocumentCache keeps parsed and validated query documents keyed by the
+// hash of the query string, so repeated operations skip lexing, parsing and
+// validation. A nil DocumentCache parses every request.
+type DocumentCache struct {
+	// MaxEntries 快取的查詢數上限，達到上限時清空重來；0 表示 1000
+	MaxEntries int
+
+	mu   sync.RWMutex
+	docs map[[sha256.Size]byte]*ast.Document
+}
+
+// execute 與 graphql.Do 相同，但重複的查詢直接使用快取的 document。
+// 解析或驗證失敗的查詢不快取，錯誤經 requestErrors 整理，hideHints 為 false 時
+// 格式與 graphql.Do 一致
+func (c *DocumentCache) execute(p graphql.Params, hideHints bool) *graphql.Result {
+	key := sha256.Sum256([]byte(p.RequestString))
+	var doc *ast.Document
+	if c != nil {
+		c.mu.RLock()
+		doc = c.docs[key]
+		c.mu.RUnlock()
+	}
+
+	if doc == nil {
+		parsed, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+			Body: []byte(p.RequestString),
+			Name: "GraphQL request",
+		})})
+		if err != nil {
+			return &graphql.Result{Errors: requestErrors(gqlerrors.FormatErrors(err), true, hideHints)}
+		}
+		if result := graphql.ValidateDocument(&p.Schema, parsed, nil); !result.IsValid {
+			return &graphql.Result{Errors: requestErrors(result.Errors, false, hideHints)}
+		}
+		if c != nil {
+			c.store(key, parsed)
+		}
+		doc = parsed
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        p.Schema,
+		Root:          p.RootObject,
+		AST:           doc,
+		OperationName: p.OperationName,
+		Args:          p.VariableValues,
+		Context:       p.Context,
+	})
+}
+
+// store 寫入 document；查詢多半來自固定的前端程式碼，達到上限時直接清空即可
+func (c *DocumentCache) store(key [sha256.Size]byte, doc *ast.Document) {
+	limit := c.MaxEntries
+	if limit <= 0 {
+		limit = defaultMaxDocuments
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.docs == nil || len(c.docs) >= limit {
+		c.docs = make(map[[sha256.Size]byte]*ast.Document)
+	}
+	c.docs[key] = doc
+}
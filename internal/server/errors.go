@@ -0,0 +1,45 @@
+package server
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+var (
+	// suggestionPattern 為 graphql-go 驗證錯誤結尾的欄位、參數或型別建議
+	suggestionPattern = regexp.MustCompile(` Did you mean .*\?$`)
+	// syntaxPrefixPattern 為語法錯誤開頭的 source 名稱與位置，位置已在 locations 中
+	syntaxPrefixPattern = regexp.MustCompile(`^Syntax Error .*? \(\d+:\d+\) `)
+)
+
+// requestErrors 整理查詢解析或驗證失敗的錯誤。hideHints（production）時移除
+// "Did you mean ..." 建議以免透露 schema 內容，語法錯誤只留說明、不附查詢原文，
+// 並以 extensions.code 區分兩種錯誤；其他環境維持 graphql-go 原本的訊息
+func requestErrors(errs []gqlerrors.FormattedError, syntax, hideHints bool) []gqlerrors.FormattedError {
+	if !hideHints {
+		return errs
+	}
+	code := "GRAPHQL_VALIDATION_FAILED"
+	if syntax {
+		code = "GRAPHQL_PARSE_FAILED"
+	}
+	out := make([]gqlerrors.FormattedError, len(errs))
+	for i, e := range errs {
+		message := e.Message
+		if syntax {
+			message, _, _ = strings.Cut(message, "\n")
+			message = "Syntax Error: " + syntaxPrefixPattern.ReplaceAllString(message, "")
+		} else {
+			message = suggestionPattern.ReplaceAllString(message, "")
+		}
+		out[i] = gqlerrors.FormattedError{
+			Message:    message,
+			Locations:  e.Locations,
+			Path:       e.Path,
+			Extensions: map[string]interface{}{"code": code},
+		}
+	}
+	return out
+}
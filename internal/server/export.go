@@ -0,0 +1,128 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-story/internal/data"
+)
+
+const (
+	exportDefaultBatch = 200
+	exportMaxBatch     = 1000
+	// exportWriteTimeout 每批寫出的期限，client 停止讀取時不會無限占用連線
+	exportWriteTimeout = time.Minute
+)
+
+// exportLine 為 NDJSON 的一行；cursor 指向這一篇，中斷後以 ?cursor= 從下一篇繼續
+type exportLine struct {
+	Cursor string     `json:"cursor,omitempty"`
+	Post   *data.Post `json:"post,omitempty"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// ExportPostsHandler streams published posts updated after ?since= as
+// NDJSON, one {"cursor": ..., "post": {...}} object per line, ordered by
+// updatedAt. Posts are read in batches and each batch is flushed before the
+// next query, so a slow reader throttles the export instead of buffering it.
+// ?cursor= resumes after a given line, ?limit= caps the number of posts and
+// ?batch= sets the batch size (default 200, max 1000).
+func ExportPostsHandler(repo *data.Repo) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "only GET", http.StatusMethodNotAllowed)
+			return
+		}
+		q := r.URL.Query()
+
+		var since time.Time
+		if raw := q.Get("since"); raw != "" {
+			t, err := parseSince(raw)
+			if err != nil {
+				writeRESTError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			since = t
+		}
+		var cursor *data.ExportCursor
+		if raw := q.Get("cursor"); raw != "" {
+			c, err := data.ParseExportCursor(raw)
+			if err != nil {
+				writeRESTError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			cursor = &c
+		}
+		limit, err := queryInt(r, "limit", 0, 0, 1<<31-1)
+		if err != nil {
+			writeRESTError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		batch, err := queryInt(r, "batch", exportDefaultBatch, 1, exportMaxBatch)
+		if err != nil {
+			writeRESTError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		requestID := requestIDFrom(r)
+		ctx := data.WithRequestID(r.Context(), requestID)
+		rc := http.NewResponseController(w)
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("X-Request-Id", requestID)
+		w.WriteHeader(http.StatusOK)
+
+		enc := json.NewEncoder(w)
+		written := 0
+		for limit == 0 || written < limit {
+			take := batch
+			if limit > 0 && limit-written < take {
+				take = limit - written
+			}
+			posts, err := repo.QueryPostsForExport(ctx, since, cursor, take)
+			if err != nil {
+				// header 已送出，只能在最後一行回報錯誤；client 可用最後的 cursor 重試
+				log.Printf("[export] request %s failed after %d posts: %v", requestID, written, err)
+				_ = enc.Encode(exportLine{Error: "export failed, retry with the last cursor"})
+				return
+			}
+			if len(posts) == 0 {
+				return
+			}
+
+			_ = rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+			for i := range posts {
+				c := data.CursorOf(posts[i])
+				if err := enc.Encode(exportLine{Cursor: c.String(), Post: &posts[i]}); err != nil {
+					// client 已斷線
+					return
+				}
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+			written += len(posts)
+			last := data.CursorOf(posts[len(posts)-1])
+			cursor = &last
+			if len(posts) < take {
+				return
+			}
+		}
+	})
+}
+
+// parseSince 接受 RFC 3339 時間或 unix 秒數
+func parseSince(raw string) (time.Time, error) {
+	if sec, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Unix(sec, 0).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid since %q: must be RFC 3339 or unix seconds", raw)
+	}
+	return t, nil
+}
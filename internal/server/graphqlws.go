@@ -0,0 +1,347 @@
+package server
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go-story/internal/data"
+	"go-story/internal/errreport"
+	"go-story/internal/persisted"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"golang.org/x/net/websocket"
+)
+
+// graphqlWSProtocol 為 graphql-ws 函式庫使用的 WebSocket 子協定
+const graphqlWSProtocol = "graphql-transport-ws"
+
+// graphql-ws 定義的關閉代碼
+const (
+	wsCloseBadRequest   = 4400
+	wsCloseUnauthorized = 4401
+	wsCloseInitTimeout  = 4408
+	wsCloseDuplicateID  = 4409
+	wsCloseTooManyInits = 4429
+)
+
+const (
+	// wsMaxMessageBytes 單一訊息大小上限
+	wsMaxMessageBytes     = 1 << 20
+	wsDefaultKeepAlive    = 15 * time.Second
+	wsDefaultInitTimeout  = 10 * time.Second
+	wsDefaultMaxOperation = 20
+)
+
+// GraphQLWSOptions limits each graphql-ws connection; zero values keep the
+// defaults (20 concurrent operations, 15s keepalive, 10s init timeout).
+type GraphQLWSOptions struct {
+	// MaxOperations 單一連線同時執行中的 operation 上限
+	MaxOperations int
+	// KeepAlive 伺服器送出 ping 的間隔，超過兩個間隔沒收到任何訊息即關閉連線
+	KeepAlive time.Duration
+	// InitTimeout 連線後必須在此時間內送出 connection_init
+	InitTimeout time.Duration
+	// Allowlist 設定後只執行 persisted query allowlist 中的 operation
+	Allowlist *persisted.Allowlist
+	// Documents 設定後重複的查詢使用快取的 document，可與 /api/graphql 共用
+	Documents *DocumentCache
+	// HideHints 移除驗證與語法錯誤中的欄位建議與查詢片段（production 使用）
+	HideHints bool
+	// Shedder 與 /api/graphql 相同，DB 飽和時以 error 訊息拒絕未驗證的列表查詢
+	Shedder *LoadShedder
+	// Quota 設定後，帶 X-API-Key 的連線每個 operation 計入該 client 的額度（連線本身由 RequireQuota 計一次）
+	Quota *ClientQuota
+}
+
+// wsMessage 為 graphql-ws 的訊息格式
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// NewGraphQLWSHandler serves GraphQL over WebSocket using the graphql-ws
+// protocol (graphql-transport-ws). Queries run like POST /api/graphql;
+// subscriptions are rejected until the schema defines any.
+func NewGraphQLWSHandler(schema graphql.Schema, reporter *errreport.Reporter, opts GraphQLWSOptions) http.Handler {
+	if opts.MaxOperations <= 0 {
+		opts.MaxOperations = wsDefaultMaxOperation
+	}
+	if opts.KeepAlive <= 0 {
+		opts.KeepAlive = wsDefaultKeepAlive
+	}
+	if opts.InitTimeout <= 0 {
+		opts.InitTimeout = wsDefaultInitTimeout
+	}
+	return websocket.Server{
+		// 只接受 graphql-transport-ws 子協定；不檢查 Origin，與 POST 端點一致
+		Handshake: func(cfg *websocket.Config, r *http.Request) error {
+			for _, p := range cfg.Protocol {
+				if p == graphqlWSProtocol {
+					cfg.Protocol = []string{graphqlWSProtocol}
+					return nil
+				}
+			}
+			return fmt.Errorf("unsupported subprotocol, expected %s", graphqlWSProtocol)
+		},
+		Handler: func(ws *websocket.Conn) {
+			ws.MaxPayloadBytes = wsMaxMessageBytes
+			c := &wsConn{
+				ws:       ws,
+				schema:   schema,
+				reporter: reporter,
+				opts:     opts,
+				connID:   requestIDFrom(ws.Request()),
+				ops:      map[string]*wsOperation{},
+			}
+			c.serve()
+		},
+	}
+}
+
+// wsConn 為單一 graphql-ws 連線的狀態
+type wsConn struct {
+	ws       *websocket.Conn
+	schema   graphql.Schema
+	reporter *errreport.Reporter
+	opts     GraphQLWSOptions
+	connID   string
+
+	// acked 只在 serve 的 goroutine 讀寫
+	acked bool
+
+	mu     sync.Mutex
+	ops    map[string]*wsOperation
+	closed bool
+
+	// writeMu 讓 operation、keepAlive 與 close 的寫入依序進行；close 會修改 ws.PayloadType，不可與 Send 同時進行
+	writeMu sync.Mutex
+}
+
+// wsOperation 執行中的 operation；以指標比對，避免 client 重複使用 id 時誤刪新的 operation
+type wsOperation struct {
+	stop context.CancelFunc
+}
+
+func (c *wsConn) serve() {
+	ctx, cancel := context.WithCancel(c.ws.Request().Context())
+	defer cancel()
+	defer c.ws.Close()
+
+	_ = c.ws.SetReadDeadline(time.Now().Add(c.opts.InitTimeout))
+	for {
+		var raw []byte
+		if err := websocket.Message.Receive(c.ws, &raw); err != nil {
+			var netErr interface{ Timeout() bool }
+			if !c.acked && errors.As(err, &netErr) && netErr.Timeout() {
+				c.close(wsCloseInitTimeout, "Connection initialisation timeout")
+			}
+			return
+		}
+		if c.acked {
+			_ = c.ws.SetReadDeadline(time.Now().Add(2 * c.opts.KeepAlive))
+		}
+
+		var msg wsMessage
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			c.close(wsCloseBadRequest, "Invalid message received")
+			return
+		}
+		switch msg.Type {
+		case "connection_init":
+			if c.acked {
+				c.close(wsCloseTooManyInits, "Too many initialisation requests")
+				return
+			}
+			c.acked = true
+			_ = c.ws.SetReadDeadline(time.Now().Add(2 * c.opts.KeepAlive))
+			c.send(wsMessage{Type: "connection_ack"})
+			go c.keepAlive(ctx)
+		case "ping":
+			c.send(wsMessage{Type: "pong"})
+		case "pong":
+		case "subscribe":
+			if !c.acked {
+				c.close(wsCloseUnauthorized, "Unauthorized")
+				return
+			}
+			if msg.ID == "" {
+				c.close(wsCloseBadRequest, "Invalid message received")
+				return
+			}
+			if !c.subscribe(ctx, msg) {
+				return
+			}
+		case "complete":
+			c.mu.Lock()
+			if op, ok := c.ops[msg.ID]; ok {
+				op.stop()
+				delete(c.ops, msg.ID)
+			}
+			c.mu.Unlock()
+		default:
+			c.close(wsCloseBadRequest, "Invalid message received")
+			return
+		}
+	}
+}
+
+// subscribe 開始執行一個 operation，連線需關閉時回傳 false
+func (c *wsConn) subscribe(ctx context.Context, msg wsMessage) bool {
+	var payload graphqlPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		c.close(wsCloseBadRequest, "Invalid message received")
+		return false
+	}
+	query, err := c.opts.Allowlist.Resolve(payload.Query, payload.persistedQueryHash())
+	if err != nil {
+		c.sendErrors(msg.ID, gqlerrors.FormatErrors(err))
+		return true
+	}
+	payload.Query = query
+
+	if c.opts.Shedder.rejects(c.ws.Request(), c.schema, payload.Query, payload.OperationName) {
+		c.sendErrors(msg.ID, gqlerrors.FormatErrors(errors.New("service overloaded, please retry later")))
+		return true
+	}
+
+	// 同一條連線可執行任意數量的 operation，因此每個 operation 都要計入額度
+	if client := ClientFromContext(c.ws.Request().Context()); client != "" {
+		now := time.Now()
+		if usage := c.opts.Quota.consume(ctx, client, now); !usage.retryAt.IsZero() {
+			c.sendErrors(msg.ID, gqlerrors.FormatErrors(fmt.Errorf("API quota exceeded, please retry in %d seconds", secondsUntil(usage.retryAt, now))))
+			return true
+		}
+	}
+
+	c.mu.Lock()
+	if _, exists := c.ops[msg.ID]; exists {
+		c.mu.Unlock()
+		c.close(wsCloseDuplicateID, fmt.Sprintf("Subscriber for %s already exists", msg.ID))
+		return false
+	}
+	if len(c.ops) >= c.opts.MaxOperations {
+		c.mu.Unlock()
+		c.sendErrors(msg.ID, gqlerrors.FormatErrors(fmt.Errorf("too many concurrent operations on this connection (max %d)", c.opts.MaxOperations)))
+		return true
+	}
+	opCtx, stop := context.WithCancel(ctx)
+	op := &wsOperation{stop: stop}
+	c.ops[msg.ID] = op
+	c.mu.Unlock()
+
+	requestID := c.connID + "/" + msg.ID
+	opCtx = errreport.WithRequest(opCtx, errreport.RequestInfo{
+		RequestID:     requestID,
+		OperationName: payload.OperationName,
+		Method:        http.MethodGet,
+		URL:           c.ws.Request().URL.String(),
+		UserAgent:     c.ws.Request().UserAgent(),
+	})
+	opCtx = data.WithRequestID(opCtx, requestID)
+
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			if c.ops[msg.ID] == op {
+				delete(c.ops, msg.ID)
+			}
+			c.mu.Unlock()
+			stop()
+		}()
+		defer func() {
+			if rec := recover(); rec != nil {
+				c.reporter.Report(opCtx, fmt.Errorf("panic: %v", rec), debug.Stack())
+				c.sendErrors(msg.ID, gqlerrors.FormatErrors(errors.New("internal server error")))
+			}
+		}()
+
+		result := c.opts.Documents.execute(graphql.Params{
+			Schema:         c.schema,
+			RequestString:  payload.Query,
+			VariableValues: payload.Variables,
+			OperationName:  payload.OperationName,
+			Context:        opCtx,
+		}, c.opts.HideHints)
+		// client 已送出 complete 時不再回傳結果
+		if opCtx.Err() != nil {
+			return
+		}
+		// 沒有 data 代表查詢在執行前就驗證失敗，依協定以 error 訊息回覆
+		if result.Data == nil && result.HasErrors() {
+			c.sendErrors(msg.ID, result.Errors)
+			return
+		}
+		body, err := json.Marshal(result)
+		if err != nil {
+			c.sendErrors(msg.ID, gqlerrors.FormatErrors(err))
+			return
+		}
+		c.send(wsMessage{ID: msg.ID, Type: "next", Payload: body})
+		c.send(wsMessage{ID: msg.ID, Type: "complete"})
+	}()
+	return true
+}
+
+// keepAlive 定期送出 ping，讓中間的 proxy 不會因閒置而切斷連線
+func (c *wsConn) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(c.opts.KeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.send(wsMessage{Type: "ping"})
+		}
+	}
+}
+
+func (c *wsConn) send(msg wsMessage) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.mu.Lock()
+	closed := c.closed
+	c.mu.Unlock()
+	if closed {
+		return
+	}
+	_ = websocket.JSON.Send(c.ws, msg)
+}
+
+func (c *wsConn) sendErrors(id string, errs []gqlerrors.FormattedError) {
+	body, err := json.Marshal(errs)
+	if err != nil {
+		return
+	}
+	c.send(wsMessage{ID: id, Type: "error", Payload: body})
+}
+
+// close 以 graphql-ws 的關閉代碼送出 close frame；x/net/websocket 沒有提供自訂代碼的 API，
+// 因此直接寫入 CloseFrame
+func (c *wsConn) close(code int, reason string) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.closed = true
+	c.mu.Unlock()
+
+	frame := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(frame, uint16(code))
+	frame = append(frame, reason...)
+	c.ws.PayloadType = websocket.CloseFrame
+	_, _ = c.ws.Write(frame)
+	_ = c.ws.Close()
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// HostRouter dispatches requests to the handler registered for their Host
+// header (case-insensitive, port ignored) and to Default otherwise.
+type HostRouter struct {
+	Default http.Handler
+	Hosts   map[string]http.Handler
+}
+
+// ServeHTTP implements http.Handler.
+func (h *HostRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	host := r.Host
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	if next, ok := h.Hosts[strings.ToLower(host)]; ok {
+		next.ServeHTTP(w, r)
+		return
+	}
+	h.Default.ServeHTTP(w, r)
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-story/internal/data"
+	"go-story/internal/errreport"
+)
+
+// imageRedirectMaxAge 重新導向的 CDN / 瀏覽器快取時間（秒）
+const imageRedirectMaxAge = 86400
+
+// ImageRedirectHandler serves /images/{id}: it redirects (302) to the
+// resized variant of the photo that best fits the w query parameter, in the
+// best format the Accept header allows (avif, then webp, then the original).
+// It is meant for emails and legacy templates that cannot pick from the
+// resized URLs themselves.
+func ImageRedirectHandler(repo *data.Repo, reporter *errreport.Reporter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		width := 0
+		if raw := r.URL.Query().Get("w"); raw != "" {
+			v, err := strconv.Atoi(raw)
+			if err != nil || v <= 0 {
+				http.Error(w, "invalid w: must be a positive integer", http.StatusBadRequest)
+				return
+			}
+			width = v
+		}
+
+		photo, err := repo.QueryPhoto(r.Context(), r.PathValue("id"))
+		if err != nil {
+			reporter.Report(r.Context(), err, nil)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if photo == nil || photo.Resized.Original == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(imageRedirectMaxAge))
+		w.Header().Set("Vary", "Accept")
+		http.Redirect(w, r, pickResized(negotiateFormat(photo, r.Header.Get("Accept")), width), http.StatusFound)
+	})
+}
+
+// negotiateFormat 依 Accept 選擇格式：avif（有產生時）> webp > 原始格式
+func negotiateFormat(photo *data.Photo, accept string) data.Resized {
+	if photo.ResizedAvif != nil && acceptsImage(accept, "image/avif") {
+		return *photo.ResizedAvif
+	}
+	if acceptsImage(accept, "image/webp") {
+		return photo.ResizedWebp
+	}
+	return photo.Resized
+}
+
+// acceptsImage 檢查 Accept 是否明確列出 mediaType 且 q 不為 0；image/* 不算，
+// 因為舊的瀏覽器也會送出 image/* 卻無法顯示 webp / avif
+func acceptsImage(accept, mediaType string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(name), mediaType) {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			if k, v, ok := strings.Cut(strings.TrimSpace(param), "="); ok && k == "q" {
+				if q, err := strconv.ParseFloat(v, 64); err == nil && q == 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// pickResized 選擇寬度不小於 width 的最小尺寸，超過最大尺寸時使用 w2400；width 為 0 時回傳原圖
+func pickResized(resized data.Resized, width int) string {
+	if width == 0 {
+		return resized.Original
+	}
+	sizes := []struct {
+		width int
+		url   string
+	}{
+		{480, resized.W480},
+		{800, resized.W800},
+		{1200, resized.W1200},
+		{1600, resized.W1600},
+		{2400, resized.W2400},
+	}
+	for _, s := range sizes {
+		if width <= s.width {
+			return s.url
+		}
+	}
+	return resized.W2400
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// IPAllowlist restricts endpoints to clients whose IP falls in Allowed.
+// When the connection comes from one of TrustedProxies, the client IP is
+// taken from X-Forwarded-For instead.
+type IPAllowlist struct {
+	Allowed        []netip.Prefix
+	TrustedProxies []netip.Prefix
+}
+
+// RequireIP only lets requests from allowed client IPs through to next and
+// responds 403 to the rest. A nil or empty allowlist lets every request
+// through.
+func RequireIP(list *IPAllowlist, next http.Handler) http.Handler {
+	if list == nil || len(list.Allowed) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, ok := list.ClientIP(r)
+		if !ok || !containsAddr(list.Allowed, ip) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ClientIP returns the IP of the client that sent r. X-Forwarded-For is read
+// from the right, skipping trusted proxies, so entries added by the client
+// itself are never used.
+func (l *IPAllowlist) ClientIP(r *http.Request) (netip.Addr, bool) {
+	ip, ok := parseAddr(r.RemoteAddr)
+	// Unix domain socket 的連線沒有來源 IP，對端必然是本機的反向代理
+	if !ok && !strings.Contains(r.RemoteAddr, ":") {
+		ip, ok = netip.Addr{}, true
+	} else if !ok {
+		return netip.Addr{}, false
+	}
+	if ip.IsValid() && !containsAddr(l.TrustedProxies, ip) {
+		return ip, true
+	}
+
+	forwarded := r.Header.Values("X-Forwarded-For")
+	if len(forwarded) == 0 {
+		return ip, ip.IsValid()
+	}
+	hops := strings.Split(strings.Join(forwarded, ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, ok := parseAddr(strings.TrimSpace(hops[i]))
+		if !ok {
+			return netip.Addr{}, false
+		}
+		ip = hop
+		if !containsAddr(l.TrustedProxies, hop) {
+			break
+		}
+	}
+	return ip, ip.IsValid()
+}
+
+// parseAddr 解析 host:port 或單純的 IP，IPv4-mapped IPv6 轉回 IPv4
+func parseAddr(s string) (netip.Addr, bool) {
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap().WithZone(""), true
+}
+
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, p := range prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
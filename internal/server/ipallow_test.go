@@ -0,0 +1,152 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func prefixes(t *testing.T, list ...string) []netip.Prefix {
+	t.Helper()
+	result := make([]netip.Prefix, 0, len(list))
+	for _, s := range list {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			t.Fatalf("invalid prefix %q: %v", s, err)
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+func TestClientIP(t *testing.T) {
+	list := &IPAllowlist{TrustedProxies: prefixes(t, "10.0.0.0/8", "fd00::/8")}
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded []string
+		want      string
+		ok        bool
+	}{
+		{name: "direct client", remote: "203.0.113.7:5000", want: "203.0.113.7", ok: true},
+		{name: "direct IPv6 client", remote: "[2001:db8::1]:5000", want: "2001:db8::1", ok: true},
+		{name: "IPv4-mapped IPv6", remote: "[::ffff:203.0.113.7]:5000", want: "203.0.113.7", ok: true},
+		{name: "untrusted client cannot spoof", remote: "203.0.113.7:5000", forwarded: []string{"10.1.1.1"}, want: "203.0.113.7", ok: true},
+		{name: "trusted proxy without header", remote: "10.0.0.1:5000", want: "10.0.0.1", ok: true},
+		{name: "trusted proxy", remote: "10.0.0.1:5000", forwarded: []string{"198.51.100.2"}, want: "198.51.100.2", ok: true},
+		{
+			name:      "walks right to left past trusted hops",
+			remote:    "10.0.0.1:5000",
+			forwarded: []string{"192.0.2.1, 198.51.100.2, 10.0.0.2"},
+			want:      "198.51.100.2", ok: true,
+		},
+		{
+			name:      "client-supplied entries are ignored",
+			remote:    "10.0.0.1:5000",
+			forwarded: []string{"10.9.9.9, 198.51.100.2"},
+			want:      "198.51.100.2", ok: true,
+		},
+		{
+			name:      "multiple header lines are joined",
+			remote:    "10.0.0.1:5000",
+			forwarded: []string{"192.0.2.1", "198.51.100.2, 10.0.0.3"},
+			want:      "198.51.100.2", ok: true,
+		},
+		{
+			name:      "every hop trusted uses the leftmost",
+			remote:    "10.0.0.1:5000",
+			forwarded: []string{"10.0.0.5, 10.0.0.6"},
+			want:      "10.0.0.5", ok: true,
+		},
+		{
+			name:      "hop with port",
+			remote:    "10.0.0.1:5000",
+			forwarded: []string{"198.51.100.2:443"},
+			want:      "198.51.100.2", ok: true,
+		},
+		{name: "malformed hop", remote: "10.0.0.1:5000", forwarded: []string{"198.51.100.2, unknown"}, ok: false},
+		{name: "malformed remote address", remote: "not-an-ip:5000", ok: false},
+		{name: "unix socket", remote: "@", forwarded: []string{"198.51.100.2"}, want: "198.51.100.2", ok: true},
+		{name: "unix socket path", remote: "/run/go-story.sock", forwarded: []string{"198.51.100.2, 10.0.0.2"}, want: "198.51.100.2", ok: true},
+		{name: "unix socket without header", remote: "", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/probe", nil)
+			r.RemoteAddr = tt.remote
+			for _, v := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			got, ok := list.ClientIP(r)
+			if ok != tt.ok {
+				t.Fatalf("ClientIP() ok = %v, want %v (ip %v)", ok, tt.ok, got)
+			}
+			if tt.ok && got.String() != tt.want {
+				t.Errorf("ClientIP() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireIP(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	tests := []struct {
+		name      string
+		list      *IPAllowlist
+		remote    string
+		forwarded string
+		want      int
+	}{
+		{name: "nil allowlist", list: nil, remote: "203.0.113.7:5000", want: http.StatusNoContent},
+		{name: "empty allowlist", list: &IPAllowlist{}, remote: "203.0.113.7:5000", want: http.StatusNoContent},
+		{name: "allowed", list: &IPAllowlist{Allowed: prefixes(t, "203.0.113.0/24")}, remote: "203.0.113.7:5000", want: http.StatusNoContent},
+		{name: "forbidden", list: &IPAllowlist{Allowed: prefixes(t, "203.0.113.0/24")}, remote: "198.51.100.2:5000", want: http.StatusForbidden},
+		{
+			name:   "spoofed header from untrusted client",
+			list:   &IPAllowlist{Allowed: prefixes(t, "203.0.113.0/24")},
+			remote: "198.51.100.2:5000", forwarded: "203.0.113.7",
+			want: http.StatusForbidden,
+		},
+		{
+			name:   "allowed behind trusted proxy",
+			list:   &IPAllowlist{Allowed: prefixes(t, "203.0.113.0/24"), TrustedProxies: prefixes(t, "10.0.0.0/8")},
+			remote: "10.0.0.1:5000", forwarded: "203.0.113.7",
+			want: http.StatusNoContent,
+		},
+		{
+			name:   "proxy address itself is not allowed",
+			list:   &IPAllowlist{Allowed: prefixes(t, "10.0.0.0/8"), TrustedProxies: prefixes(t, "10.0.0.0/8")},
+			remote: "10.0.0.1:5000", forwarded: "198.51.100.2",
+			want: http.StatusForbidden,
+		},
+		{
+			name:   "unix socket without header",
+			list:   &IPAllowlist{Allowed: prefixes(t, "0.0.0.0/0")},
+			remote: "@",
+			want:   http.StatusForbidden,
+		},
+		{
+			name:   "unix socket with allowed client",
+			list:   &IPAllowlist{Allowed: prefixes(t, "203.0.113.0/24")},
+			remote: "@", forwarded: "203.0.113.7",
+			want: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			r.RemoteAddr = tt.remote
+			if tt.forwarded != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			w := httptest.NewRecorder()
+			RequireIP(tt.list, next).ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"reflect"
+	"strings"
+)
+
+// openAPISpec 由 REST 路由表產生 OpenAPI 3.0 文件；回應型別以 reflection 依 json tag 轉為 schema
+func openAPISpec(routes []restRoute, opts RESTOptions) map[string]any {
+	schemas := map[string]any{
+		"Error": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"error": map[string]any{"type": "string"}},
+			"required":   []string{"error"},
+		},
+	}
+	errorResponse := func(description string) map[string]any {
+		return map[string]any{
+			"description": description,
+			"content":     map[string]any{"application/json": map[string]any{"schema": schemaRef("Error")}},
+		}
+	}
+
+	paths := map[string]any{}
+	for _, route := range routes {
+		params := make([]any, 0, len(route.params))
+		for _, p := range route.params {
+			schema := map[string]any{"type": p.kind}
+			switch p.name {
+			case "take":
+				schema["minimum"], schema["maximum"] = 0, opts.MaxTake
+			case "skip":
+				schema["minimum"], schema["maximum"] = 0, opts.MaxSkip
+			}
+			params = append(params, map[string]any{
+				"name":        p.name,
+				"in":          p.in,
+				"required":    p.in == "path",
+				"description": p.description,
+				"schema":      schema,
+			})
+		}
+
+		result := typeSchema(route.result, schemas)
+		if route.list {
+			result = map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"items": map[string]any{"type": "array", "items": result}},
+				"required":   []string{"items"},
+			}
+		}
+		responses := map[string]any{
+			"200": map[string]any{
+				"description": "OK",
+				"content":     map[string]any{"application/json": map[string]any{"schema": result}},
+			},
+			"500": errorResponse("Internal server error"),
+		}
+		if route.list {
+			responses["400"] = errorResponse("Invalid parameter")
+		}
+		if !route.list {
+			responses["404"] = errorResponse("Not found")
+		}
+
+		item, _ := paths[route.path].(map[string]any)
+		if item == nil {
+			item = map[string]any{}
+			paths[route.path] = item
+		}
+		item[strings.ToLower(route.method)] = map[string]any{
+			"operationId": route.operationID,
+			"summary":     route.summary,
+			"parameters":  params,
+			"responses":   responses,
+		}
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "go-story read API",
+			"version":     "1.0.0",
+			"description": "Read-only access to published posts, topics and externals. Times are formatted like the GraphQL API.",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+func schemaRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+// typeSchema 回傳 t 的 schema；具名 struct 放到 components 並以 $ref 參照，避免 Post.relateds 等遞迴
+func typeSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeSchema(t.Elem(), schemas)
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": true}
+	case reflect.Struct:
+		name := t.Name()
+		if _, ok := schemas[name]; !ok {
+			// 先佔位，遞迴參照自己時直接回傳 $ref
+			schemas[name] = nil
+			schemas[name] = structSchema(t, schemas)
+		}
+		return schemaRef(name)
+	}
+	// interface 等任意 JSON 值
+	return map[string]any{}
+}
+
+func structSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	props := map[string]any{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		schema := typeSchema(f.Type, schemas)
+		// 指標、slice 與 map 可能為 null；$ref 不能加 nullable，以 allOf 包一層
+		if k := f.Type.Kind(); k == reflect.Pointer || k == reflect.Slice || k == reflect.Map {
+			if _, isRef := schema["$ref"]; isRef {
+				schema = map[string]any{"allOf": []any{schema}, "nullable": true}
+			} else {
+				schema["nullable"] = true
+			}
+		}
+		props[name] = schema
+	}
+	return map[string]any{"type": "object", "properties": props}
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-story/internal/data"
+	"go-story/internal/metrics"
+)
+
+var quotaRejectedCounter = metrics.NewCounter(
+	"go_story_api_quota_rejected_total",
+	"Number of API requests rejected with 429 because the client exceeded its quota.",
+	"client",
+)
+
+// ClientQuota identifies API clients by the X-API-Key header and limits the
+// requests each client may make per hour and per day. Counters are kept in
+// Redis so the limits hold across instances. Requests without an API key
+// (the newsroom frontends) are not limited. A nil ClientQuota lets every
+// request through.
+type ClientQuota struct {
+	Cache *data.Cache
+	// Clients 為 client 名稱對應的 API key
+	Clients map[string]string
+	// Hourly、Daily 為每個 client 每小時、每天（UTC）的請求上限，0 表示不限制
+	Hourly int
+	Daily  int
+}
+
+type clientContextKey struct{}
+
+// ClientFromContext returns the name of the API client identified by
+// RequireQuota, or "" for requests without an API key.
+func ClientFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(clientContextKey{}).(string)
+	return name
+}
+
+// quotaWindow 為一個計數區間的上限
+type quotaWindow struct {
+	limit  int
+	length time.Duration
+}
+
+// quotaUsage 為一次計數後最接近上限的區間；retryAt 不為零時已超過上限，需等到該時間
+type quotaUsage struct {
+	limit, remaining int
+	reset, retryAt   time.Time
+	reported         bool
+}
+
+// windows 回傳有設定上限的區間
+func (q *ClientQuota) windows() []quotaWindow {
+	var windows []quotaWindow
+	for _, w := range []quotaWindow{{q.Hourly, time.Hour}, {q.Daily, 24 * time.Hour}} {
+		if w.limit > 0 {
+			windows = append(windows, w)
+		}
+	}
+	return windows
+}
+
+// consume 將 client 每個區間的計數加一，回報剩餘次數最少的區間；超過任一區間的上限即設定 retryAt，
+// 直到該區間結束。HTTP 請求與 WebSocket 上的每個 operation 都各算一次
+func (q *ClientQuota) consume(ctx context.Context, client string, now time.Time) quotaUsage {
+	var u quotaUsage
+	if q == nil || client == "" {
+		return u
+	}
+	for _, win := range q.windows() {
+		count, windowReset, ok := q.Cache.IncrQuota(ctx, client, win.length, now)
+		if !ok {
+			continue
+		}
+		left := max(win.limit-int(count), 0)
+		if !u.reported || left < u.remaining {
+			u.limit, u.remaining, u.reset, u.reported = win.limit, left, windowReset, true
+		}
+		if int(count) > win.limit && windowReset.After(u.retryAt) {
+			u.retryAt = windowReset
+		}
+	}
+	if !u.retryAt.IsZero() {
+		quotaRejectedCounter.Inc(client)
+	}
+	return u
+}
+
+// RequireQuota identifies the client of each request and rejects requests
+// over its quota with 429. Responses of identified clients carry the
+// RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers of the
+// window closest to its limit. An unknown API key is rejected with 401.
+// Operations sent over /api/graphql/ws are counted by the WebSocket handler
+// through GraphQLWSOptions.Quota.
+func RequireQuota(q *ClientQuota, next http.Handler) http.Handler {
+	if q == nil || len(q.Clients) == 0 {
+		return next
+	}
+	var policy []string
+	for _, w := range q.windows() {
+		policy = append(policy, fmt.Sprintf("%d;w=%d", w.limit, int(w.length/time.Second)))
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("X-API-Key")
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		client, ok := q.identify(key)
+		if !ok {
+			http.Error(w, "invalid API key", http.StatusUnauthorized)
+			return
+		}
+		r = r.WithContext(context.WithValue(r.Context(), clientContextKey{}, client))
+
+		now := time.Now()
+		usage := q.consume(r.Context(), client, now)
+		if usage.reported {
+			w.Header().Set("RateLimit-Policy", strings.Join(policy, ", "))
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(usage.limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(usage.remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(secondsUntil(usage.reset, now)))
+		}
+		if !usage.retryAt.IsZero() {
+			w.Header().Set("Retry-After", strconv.Itoa(secondsUntil(usage.retryAt, now)))
+			http.Error(w, "API quota exceeded, please retry later", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// identify 以固定時間比對 API key，回傳對應的 client 名稱
+func (q *ClientQuota) identify(key string) (string, bool) {
+	for name, clientKey := range q.Clients {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(clientKey)) == 1 {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// secondsUntil 回傳距離 t 的秒數（無條件進位）
+func secondsUntil(t, now time.Time) int {
+	return int((t.Sub(now) + time.Second - 1) / time.Second)
+}
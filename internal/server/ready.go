@@ -0,0 +1,25 @@
+package server
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// Readiness reports 503 until SetReady is called, so load balancers and
+// startup probes only route traffic after warm-up has finished.
+type Readiness struct {
+	ready atomic.Bool
+}
+
+// SetReady marks the service as ready.
+func (r *Readiness) SetReady() {
+	r.ready.Store(true)
+}
+
+func (r *Readiness) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	if !r.ready.Load() {
+		http.Error(w, "warming up", http.StatusServiceUnavailable)
+		return
+	}
+	_, _ = w.Write([]byte("ok"))
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"go-story/internal/logging"
+)
+
+// maxLoggedVariables 記錄的 variables JSON 長度上限
+const maxLoggedVariables = 1000
+
+// redactedVariableNames 名稱包含這些字的 variable 不記錄原值（會員 token、密碼等）
+var redactedVariableNames = []string{"token", "password", "secret", "auth", "cookie", "jwt"}
+
+// RequestLog logs one line per GraphQL request with its operationName,
+// query hash, resolved entity counts and duration, so the query shapes that
+// dominate load can be picked out. A nil RequestLog logs nothing.
+type RequestLog struct {
+	// VariablesPercent 同時記錄 variables 的請求比例（0–100）
+	VariablesPercent int
+}
+
+// requestLogEntry 為一個請求的日誌內容
+type requestLogEntry struct {
+	request   *http.Request
+	requestID string
+	traceID   string
+	payload   graphqlPayload
+	entities  string
+	cache     string
+	duration  time.Duration
+}
+
+// log 輸出一行請求日誌，LOG_FORMAT=json 時另附 httpRequest 與 trace 欄位；
+// variables 依比例抽樣，敏感欄位遮蔽後才輸出
+func (l *RequestLog) log(e requestLogEntry) {
+	if l == nil {
+		return
+	}
+	operation := e.payload.OperationName
+	if operation == "" {
+		operation = "-"
+	}
+	line := fmt.Sprintf("[GraphQL] request=%s op=%s hash=%s duration=%s", e.requestID, operation, queryHash(e.payload), e.duration.Round(time.Millisecond))
+	if e.traceID != "" {
+		line += " trace=" + e.traceID
+	}
+	if e.cache != "" {
+		line += " cache=" + e.cache
+	}
+	if e.entities != "" {
+		line += " entities=" + e.entities
+	}
+	if len(e.payload.Variables) > 0 && l.VariablesPercent > 0 && rand.Intn(100) < l.VariablesPercent {
+		if raw, err := json.Marshal(redactVariables(e.payload.Variables)); err == nil {
+			if len(raw) > maxLoggedVariables {
+				raw = append(raw[:maxLoggedVariables], "..."...)
+			}
+			line += " variables=" + string(raw)
+		}
+	}
+	remoteIP := e.request.RemoteAddr
+	if host, _, err := net.SplitHostPort(remoteIP); err == nil {
+		remoteIP = host
+	}
+	logging.Request(e.request.Context(), line, logging.HTTPRequest{
+		RequestMethod: e.request.Method,
+		RequestURL:    e.request.URL.String(),
+		UserAgent:     e.request.UserAgent(),
+		RemoteIP:      remoteIP,
+		Referer:       e.request.Referer(),
+		Latency:       logging.Latency(e.duration),
+	}, map[string]string{"operationName": e.payload.OperationName, "requestId": e.requestID})
+}
+
+// queryHash 回傳查詢 sha256 的前 16 個字元，與 persisted query hash 的開頭相同，
+// 同一個查詢不論是否以 hash 送出都歸在一起
+func queryHash(p graphqlPayload) string {
+	if p.Query == "" && len(p.persistedQueryHash()) >= 16 {
+		return p.persistedQueryHash()[:16]
+	}
+	sum := sha256.Sum256([]byte(p.Query))
+	return hex.EncodeToString(sum[:8])
+}
+
+// redactVariables 複製 variables，名稱看起來是 token 或密碼的值改為 [REDACTED]
+func redactVariables(v map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(v))
+	for name, value := range v {
+		if isSensitiveName(name) {
+			out[name] = "[REDACTED]"
+			continue
+		}
+		out[name] = redactValue(value)
+	}
+	return out
+}
+
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return redactVariables(val)
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = redactValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func isSensitiveName(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range redactedVariableNames {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
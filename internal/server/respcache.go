@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go-story/internal/data"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// ResponseCache stores whole GraphQL responses of anonymous queries in Redis,
+// so hot operations such as the homepage bundle skip execution entirely.
+// Only operations listed in TTLs are cached. A nil ResponseCache caches
+// nothing.
+type ResponseCache struct {
+	Cache *data.Cache
+	// TTLs 為各 operationName 的快取時間
+	TTLs map[string]time.Duration
+}
+
+// cachedResponse 為快取的回應內容，連同 surrogate key 一起保存，命中時 CDN header 仍正確
+type cachedResponse struct {
+	Body json.RawMessage `json:"body"`
+	Keys []string        `json:"keys,omitempty"`
+	// CacheControl 為執行時依 @cacheControl 計算的 header
+	CacheControl string `json:"cacheControl,omitempty"`
+}
+
+// anonymousQuery 為未帶 Authorization 的具名 query；key 為正規化後查詢的 hash，
+// 供回應快取與 in-flight 合併共用
+type anonymousQuery struct {
+	key           string
+	operationName string
+}
+
+// parseAnonymousQuery 解析可共用結果的請求，不符合時回傳 false。
+// 查詢經 parse 後重新輸出，空白與縮排不同的相同查詢共用同一個 key
+func parseAnonymousQuery(r *http.Request, payload graphqlPayload) (anonymousQuery, bool) {
+	if r.Header.Get("Authorization") != "" {
+		return anonymousQuery{}, false
+	}
+	doc, err := parser.Parse(parser.ParseParams{Source: payload.Query})
+	if err != nil {
+		return anonymousQuery{}, false
+	}
+	op := selectedOperation(doc, payload.OperationName)
+	if op == nil || op.Operation != ast.OperationTypeQuery || op.Name == nil {
+		return anonymousQuery{}, false
+	}
+	// json.Marshal 會排序 map key，variables 順序不影響 key
+	key := data.GenerateCacheKey(data.ResponseCachePrefix, map[string]any{
+		"query":         printer.Print(doc),
+		"variables":     payload.Variables,
+		"operationName": op.Name.Value,
+	})
+	return anonymousQuery{key: key, operationName: op.Name.Value}, true
+}
+
+// ttl 回傳 query 的快取時間，不需快取時回傳 0
+func (c *ResponseCache) ttl(q anonymousQuery) time.Duration {
+	if c == nil || c.Cache == nil || !c.Cache.Enabled() {
+		return 0
+	}
+	return max(c.TTLs[q.operationName], 0)
+}
+
+// get 讀取快取的回應，沒有或讀取失敗時回傳 false
+func (c *ResponseCache) get(ctx context.Context, key string) (cachedResponse, bool) {
+	var resp cachedResponse
+	found, err := c.Cache.Get(ctx, key, &resp)
+	if err != nil || !found || len(resp.Body) == 0 {
+		return cachedResponse{}, false
+	}
+	return resp, true
+}
+
+// set 寫入回應並以 entityKeys 建立索引，CMS 修改 entity 時只清除包含它的回應；失敗時略過
+func (c *ResponseCache) set(ctx context.Context, key string, ttl time.Duration, resp cachedResponse, entityKeys []string) {
+	_ = c.Cache.SetWithKeys(ctx, key, resp, ttl, entityKeys)
+}
+
+// selectedOperation 回傳要執行的 operation；未指定 operationName 時文件中只能有一個 operation
+func selectedOperation(doc *ast.Document, operationName string) *ast.OperationDefinition {
+	var found *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName != "" {
+			if op.Name != nil && op.Name.Value == operationName {
+				return op
+			}
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = op
+	}
+	return found
+}
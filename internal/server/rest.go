@@ -0,0 +1,295 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+
+	"go-story/internal/data"
+	"go-story/internal/errreport"
+)
+
+// RESTOptions limits the REST read API; zero values keep the defaults
+// (take up to 100, skip up to 10000).
+type RESTOptions struct {
+	MaxTake int
+	MaxSkip int
+}
+
+// restRoute 描述一個 REST 端點；handler 與 /api/openapi.json 都由這份定義產生
+type restRoute struct {
+	method      string
+	path        string
+	operationID string
+	summary     string
+	params      []restParam
+	// result 回應的型別，list 為 true 時回應為 {"items": [result...]}
+	result reflect.Type
+	list   bool
+	handle func(r *http.Request, q restQuery) (any, error)
+}
+
+// restParam 為 path 或 query 參數
+type restParam struct {
+	name        string
+	in          string
+	kind        string
+	description string
+}
+
+// restError 帶有 HTTP status 的錯誤，其他錯誤一律回應 500
+type restError struct {
+	status  int
+	message string
+}
+
+func (e *restError) Error() string { return e.message }
+
+func notFound(what string) error {
+	return &restError{status: http.StatusNotFound, message: what + " not found"}
+}
+
+// publishedFilter 為列表端點固定套用的狀態條件；REST API 只公開已發布的內容
+func publishedFilter() *data.StringFilter {
+	published := "published"
+	return &data.StringFilter{Equals: &published}
+}
+
+// restQuery 為解析後的參數
+type restQuery struct {
+	take, skip int
+}
+
+// restListResponse 列表端點的回應格式
+type restListResponse struct {
+	Items any `json:"items"`
+}
+
+var paginationParams = []restParam{
+	{name: "take", in: "query", kind: "integer", description: "Number of items to return; defaults to the maximum."},
+	{name: "skip", in: "query", kind: "integer", description: "Number of items to skip."},
+}
+
+// restRoutes 為所有 REST 端點
+func restRoutes(repo *data.Repo) []restRoute {
+	return []restRoute{
+		{
+			method: http.MethodGet, path: "/api/v1/posts", operationID: "listPosts",
+			summary: "List published posts, newest first.",
+			params: append([]restParam{
+				{name: "section", in: "query", kind: "string", description: "Section slug."},
+				{name: "category", in: "query", kind: "string", description: "Category slug."},
+				{name: "topic", in: "query", kind: "string", description: "Topic id."},
+			}, paginationParams...),
+			result: reflect.TypeOf(data.Post{}), list: true,
+			handle: func(r *http.Request, q restQuery) (any, error) {
+				where := &data.PostWhereInput{State: publishedFilter()}
+				if slug := r.URL.Query().Get("section"); slug != "" {
+					where.Sections = &data.SectionManyRelationFilter{Some: &data.SectionWhereInput{Slug: &data.StringFilter{Equals: &slug}}}
+				}
+				if slug := r.URL.Query().Get("category"); slug != "" {
+					where.Categories = &data.CategoryManyRelationFilter{Some: &data.CategoryWhereInput{Slug: &data.StringFilter{Equals: &slug}}}
+				}
+				if id := r.URL.Query().Get("topic"); id != "" {
+					where.Topics = &data.PostTopicsWhereInput{ID: &data.IDFilter{Equals: &id}}
+				}
+				return repo.QueryPosts(r.Context(), where, []data.OrderRule{{Field: "publishedDate", Direction: "desc"}}, q.take, q.skip)
+			},
+		},
+		{
+			method: http.MethodGet, path: "/api/v1/posts/{slug}", operationID: "getPost",
+			summary: "Get a published post by slug.",
+			params:  []restParam{{name: "slug", in: "path", kind: "string", description: "Post slug."}},
+			result:  reflect.TypeOf(data.Post{}),
+			handle: func(r *http.Request, _ restQuery) (any, error) {
+				slug := r.PathValue("slug")
+				p, err := repo.QueryPostByUnique(r.Context(), &data.PostWhereUniqueInput{Slug: &slug})
+				if err != nil {
+					return nil, err
+				}
+				if p == nil || p.State != "published" {
+					return nil, notFound("post")
+				}
+				return p, nil
+			},
+		},
+		{
+			method: http.MethodGet, path: "/api/v1/topics", operationID: "listTopics",
+			summary: "List published topics ordered by sortOrder.",
+			params: append([]restParam{
+				{name: "featured", in: "query", kind: "boolean", description: "Only featured topics."},
+			}, paginationParams...),
+			result: reflect.TypeOf(data.Topic{}), list: true,
+			handle: func(r *http.Request, q restQuery) (any, error) {
+				where := &data.TopicWhereInput{State: publishedFilter()}
+				if raw := r.URL.Query().Get("featured"); raw != "" {
+					featured, err := strconv.ParseBool(raw)
+					if err != nil {
+						return nil, &restError{status: http.StatusBadRequest, message: fmt.Sprintf("invalid featured %q", raw)}
+					}
+					where.IsFeatured = &data.BooleanFilter{Equals: &featured}
+				}
+				return repo.QueryTopics(r.Context(), where, nil, q.take, q.skip)
+			},
+		},
+		{
+			method: http.MethodGet, path: "/api/v1/topics/{slug}", operationID: "getTopic",
+			summary: "Get a published topic by slug.",
+			params:  []restParam{{name: "slug", in: "path", kind: "string", description: "Topic slug."}},
+			result:  reflect.TypeOf(data.Topic{}),
+			handle: func(r *http.Request, _ restQuery) (any, error) {
+				slug := r.PathValue("slug")
+				t, err := repo.QueryTopicByUnique(r.Context(), &data.TopicWhereUniqueInput{Slug: &slug})
+				if err != nil {
+					return nil, err
+				}
+				if t == nil || t.State != "published" {
+					return nil, notFound("topic")
+				}
+				return t, nil
+			},
+		},
+		{
+			method: http.MethodGet, path: "/api/v1/externals", operationID: "listExternals",
+			summary: "List published externals, newest first.",
+			params: append([]restParam{
+				{name: "partner", in: "query", kind: "string", description: "Partner slug."},
+			}, paginationParams...),
+			result: reflect.TypeOf(data.External{}), list: true,
+			handle: func(r *http.Request, q restQuery) (any, error) {
+				where := &data.ExternalWhereInput{State: publishedFilter()}
+				if slug := r.URL.Query().Get("partner"); slug != "" {
+					where.Partner = &data.PartnerWhereInput{Slug: &data.StringFilter{Equals: &slug}}
+				}
+				return repo.QueryExternals(r.Context(), where, nil, q.take, q.skip)
+			},
+		},
+		{
+			method: http.MethodGet, path: "/api/v1/externals/{slug}", operationID: "getExternal",
+			summary: "Get a published external by slug.",
+			params:  []restParam{{name: "slug", in: "path", kind: "string", description: "External slug."}},
+			result:  reflect.TypeOf(data.External{}),
+			handle: func(r *http.Request, _ restQuery) (any, error) {
+				slug := r.PathValue("slug")
+				externals, err := repo.QueryExternals(r.Context(), &data.ExternalWhereInput{Slug: &data.StringFilter{Equals: &slug}, State: publishedFilter()}, nil, 1, 0)
+				if err != nil {
+					return nil, err
+				}
+				if len(externals) == 0 || externals[0].State != "published" {
+					return nil, notFound("external")
+				}
+				return &externals[0], nil
+			},
+		},
+	}
+}
+
+// NewRESTHandler serves the read-only REST API under /api/v1 and its
+// OpenAPI 3 description at /api/openapi.json. Both are generated from the
+// same route table, so the spec always matches the handlers.
+func NewRESTHandler(repo *data.Repo, reporter *errreport.Reporter, opts RESTOptions) http.Handler {
+	if opts.MaxTake <= 0 {
+		opts.MaxTake = 100
+	}
+	if opts.MaxSkip <= 0 {
+		opts.MaxSkip = 10000
+	}
+	routes := restRoutes(repo)
+	spec, err := json.Marshal(openAPISpec(routes, opts))
+	if err != nil {
+		// 型別都在編譯時決定，無法序列化代表程式錯誤
+		panic(fmt.Sprintf("marshal openapi spec: %v", err))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		_, _ = w.Write(spec)
+	})
+	for _, route := range routes {
+		mux.Handle(route.method+" "+route.path, restHandler(route, reporter, opts))
+	}
+	return mux
+}
+
+func restHandler(route restRoute, reporter *errreport.Reporter, opts RESTOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := requestIDFrom(r)
+		w.Header().Set("X-Request-Id", requestID)
+		ctx := errreport.WithRequest(r.Context(), errreport.RequestInfo{
+			RequestID:     requestID,
+			OperationName: route.operationID,
+			Method:        r.Method,
+			URL:           r.URL.String(),
+			UserAgent:     r.UserAgent(),
+		})
+		ctx = data.WithRequestID(ctx, requestID)
+		ctx, trace := data.WithCacheTrace(ctx)
+		r = r.WithContext(ctx)
+
+		defer func() {
+			if rec := recover(); rec != nil {
+				reporter.Report(ctx, fmt.Errorf("panic: %v", rec), debug.Stack())
+				writeRESTError(w, http.StatusInternalServerError, "internal server error")
+			}
+		}()
+
+		var q restQuery
+		if route.list {
+			var err error
+			if q.take, err = queryInt(r, "take", opts.MaxTake, 0, opts.MaxTake); err != nil {
+				writeRESTError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			// 與 gRPC 一致，take 為 0 時使用上限
+			if q.take == 0 {
+				q.take = opts.MaxTake
+			}
+			if q.skip, err = queryInt(r, "skip", 0, 0, opts.MaxSkip); err != nil {
+				writeRESTError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		result, err := route.handle(r, q)
+		if err != nil {
+			if re, ok := err.(*restError); ok {
+				writeRESTError(w, re.status, re.message)
+				return
+			}
+			reporter.Report(ctx, fmt.Errorf("%s: %w", route.operationID, err), nil)
+			writeRESTError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+		if route.list {
+			result = restListResponse{Items: result}
+		}
+		writeCacheHeaders(w, trace.Status(), trace.Prefixes())
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	})
+}
+
+// queryInt 讀取整數 query 參數，未帶時回傳 def
+func queryInt(r *http.Request, name string, def, min, max int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return def, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("invalid %s %q: must be between %d and %d", name, raw, min, max)
+	}
+	return v, nil
+}
+
+// writeRESTError 以 {"error": "..."} 回應錯誤
+func writeRESTError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
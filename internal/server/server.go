@@ -1,18 +1,40 @@
 package server
 
 import (
-	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"reflect"
+	"runtime/debug"
+	"strings"
 	"time"
 
+	"go-story/internal/cachecontrol"
+	"go-story/internal/data"
+	"go-story/internal/errreport"
+	"go-story/internal/persisted"
+	"go-story/internal/probe"
+	"go-story/internal/schema"
+	"go-story/internal/surrogate"
+	"go-story/internal/tracecontext"
+
 	"github.com/graphql-go/graphql"
 )
 
-func NewGraphQLHandler(schema graphql.Schema) http.Handler {
+// NewGraphQLHandler serves GraphQL requests. Panics are recovered and sent
+// to reporter (which may be nil) together with operationName and requestId.
+// shedder (which may be nil) rejects list queries while the DB is saturated.
+// allowlist (which may be nil) restricts the executable operations of
+// requests not marked by MarkAdmin. respCache (which may be nil) serves
+// cached responses of anonymous queries, coalescer (which may be nil)
+// executes identical concurrent anonymous queries once, and documents
+// (which may be nil) skips parsing and validation of repeated queries.
+// reqLog (which may be nil) logs every request. hideHints strips field
+// suggestions and query excerpts from validation and syntax errors, for
+// production.
+func NewGraphQLHandler(gqlSchema graphql.Schema, reporter *errreport.Reporter, shedder *LoadShedder, allowlist *persisted.Allowlist, respCache *ResponseCache, coalescer *Coalescer, documents *DocumentCache, shadow *Shadow, reqLog *RequestLog, hideHints bool) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -20,37 +42,234 @@ func NewGraphQLHandler(schema graphql.Schema) http.Handler {
 			return
 		}
 
-		var payload struct {
-			Query         string                 `json:"query"`
-			Variables     map[string]interface{} `json:"variables"`
-			OperationName string                 `json:"operationName"`
-		}
-
+		var payload graphqlPayload
 		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
 			return
 		}
 
-		result := graphql.Do(graphql.Params{
-			Schema:         schema,
-			RequestString:  payload.Query,
-			VariableValues: payload.Variables,
-			OperationName:  payload.OperationName,
-			Context:        r.Context(),
+		requestID := requestIDFrom(r)
+		w.Header().Set("X-Request-Id", requestID)
+
+		// 請求結束時記錄 operation、查詢 hash、entity 數量與耗時；被拒絕的請求沒有 entity
+		var entities string
+		if reqLog != nil {
+			start := time.Now()
+			defer func() {
+				span, _ := tracecontext.FromContext(r.Context())
+				reqLog.log(requestLogEntry{
+					request:   r,
+					requestID: requestID,
+					traceID:   span.TraceID,
+					payload:   payload,
+					entities:  entities,
+					cache:     w.Header().Get("X-Cache"),
+					duration:  time.Since(start),
+				})
+			}()
+		}
+
+		// admin 請求（例如 refreshPost）不受 allowlist 限制
+		if !schema.IsAdmin(r.Context()) {
+			query, err := allowlist.Resolve(payload.Query, payload.persistedQueryHash())
+			if err != nil {
+				writeGraphQLError(w, http.StatusForbidden, "PERSISTED_QUERY_NOT_ALLOWED", err.Error())
+				return
+			}
+			payload.Query = query
+		}
+
+		// @defer 分段回傳的請求不使用回應快取與合併
+		deferred := wantsDeferred(r, payload.Query)
+		var anon anonymousQuery
+		var cacheTTL time.Duration
+		if !deferred && (respCache != nil || coalescer != nil) {
+			anon, _ = parseAnonymousQuery(r, payload)
+		}
+		if anon.key != "" {
+			cacheTTL = respCache.ttl(anon)
+		}
+
+		// 命中回應快取時不執行查詢，也不受 load shedding 影響
+		if cacheTTL > 0 {
+			if cached, ok := respCache.get(r.Context(), anon.key); ok {
+				w.Header().Set("X-Response-Cache", "HIT")
+				writeCacheHeaders(w, "HIT", data.ResponseCachePrefix+"=HIT")
+				if cached.CacheControl != "" {
+					w.Header().Set("Cache-Control", cached.CacheControl)
+				}
+				writeGraphQLResponse(w, cached.Body, cached.Keys)
+				shadow.mirror(r, payload, requestID, cached.Body)
+				return
+			}
+			w.Header().Set("X-Response-Cache", "MISS")
+		}
+
+		if shedder.shed(w, r, gqlSchema, payload.Query, payload.OperationName) {
+			return
+		}
+
+		ctx := errreport.WithRequest(r.Context(), errreport.RequestInfo{
+			RequestID:     requestID,
+			OperationName: payload.OperationName,
+			Method:        r.Method,
+			URL:           r.URL.String(),
+			UserAgent:     r.UserAgent(),
 		})
+		ctx = data.WithRequestID(ctx, requestID)
+		ctx, keys := surrogate.NewContext(ctx)
+		ctx, policy := cachecontrol.NewContext(ctx)
+		ctx, trace := data.WithCacheTrace(ctx)
+		ctx, counts := schema.WithEntityCounts(ctx)
+
+		defer func() {
+			if rec := recover(); rec != nil {
+				reporter.Report(ctx, fmt.Errorf("panic: %v", rec), debug.Stack())
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+			}
+		}()
+
+		if deferred {
+			serveDeferred(w, ctx, gqlSchema, payload, hideHints)
+			entities = counts.String()
+			return
+		}
 
-		w.Header().Set("Content-Type", "application/json")
-		if err := json.NewEncoder(w).Encode(result); err != nil {
+		execute := func() (executedResponse, error) {
+			result := documents.execute(graphql.Params{
+				Schema:         gqlSchema,
+				RequestString:  payload.Query,
+				VariableValues: payload.Variables,
+				OperationName:  payload.OperationName,
+				Context:        ctx,
+			}, hideHints)
+			body, err := json.Marshal(result)
+			if err != nil {
+				return executedResponse{}, err
+			}
+			hint, hinted := policy.Result()
+			return executedResponse{
+				body:          body,
+				keys:          keys.Values(),
+				allKeys:       keys.All(),
+				hasErrors:     len(result.Errors) > 0,
+				hint:          hint,
+				hinted:        hinted,
+				cacheStatus:   trace.Status(),
+				cachePrefixes: trace.Prefixes(),
+				entities:      counts.String(),
+			}, nil
+		}
+		var resp executedResponse
+		var err error
+		if anon.key != "" && coalescer != nil {
+			// 合併的請求共用第一個請求的執行結果，第一個請求中斷時不影響其他請求
+			ctx = context.WithoutCancel(ctx)
+			var shared bool
+			resp, shared, err = coalescer.do(anon.key, execute)
+			if shared {
+				coalescedCounter.Inc()
+			}
+		} else {
+			resp, err = execute()
+		}
+		if err != nil {
 			http.Error(w, fmt.Sprintf("failed to encode response: %v", err), http.StatusInternalServerError)
+			return
 		}
+		// 依回應中欄位的 @cacheControl 決定 Cache-Control 與回應快取的 TTL；帶 Authorization 的回應不給共用快取保存
+		var cacheControl string
+		if resp.hinted && !resp.hasErrors {
+			hint := resp.hint
+			if r.Header.Get("Authorization") != "" {
+				hint.Scope = cachecontrol.Private
+			}
+			cacheControl = hint.Header()
+			if hint.Scope == cachecontrol.Private {
+				cacheTTL = 0
+			}
+			cacheTTL = min(cacheTTL, time.Duration(hint.MaxAge)*time.Second)
+		}
+		if cacheControl != "" {
+			w.Header().Set("Cache-Control", cacheControl)
+		}
+		// 只快取沒有錯誤的回應，避免暫時性的 DB 錯誤被保留到 TTL 結束
+		if cacheTTL > 0 && !resp.hasErrors {
+			respCache.set(ctx, anon.key, cacheTTL, cachedResponse{Body: resp.body, Keys: resp.keys, CacheControl: cacheControl}, resp.allKeys)
+		}
+		entities = resp.entities
+		writeCacheHeaders(w, resp.cacheStatus, resp.cachePrefixes)
+		writeGraphQLResponse(w, resp.body, resp.keys)
+		// 回應送出後才排入比對，不影響 client
+		shadow.mirror(r, payload, requestID, resp.body)
+	})
+}
+
+// writeGraphQLResponse 輸出 JSON 回應；surrogate keys 供 CDN 依 entity 精準 purge，
+// Surrogate-Key 以空白分隔（Fastly），Cache-Tag 以逗號分隔（Cloudflare）
+func writeGraphQLResponse(w http.ResponseWriter, body []byte, keys []string) {
+	w.Header().Set("Content-Type", "application/json")
+	if len(keys) > 0 {
+		w.Header().Set("Surrogate-Key", strings.Join(keys, " "))
+		w.Header().Set("Cache-Tag", strings.Join(keys, ","))
+	}
+	// body 可能由合併的請求共用，不可 append
+	_, _ = w.Write(body)
+	_, _ = w.Write([]byte("\n"))
+}
+
+// writeCacheHeaders 輸出 Redis cache 的使用情形，X-Cache 為整體結果（HIT / MISS / BYPASS），
+// X-Cache-Prefix 為各 key prefix 的結果，前端與 CDN 不需查 log 即可確認快取行為
+func writeCacheHeaders(w http.ResponseWriter, status, prefixes string) {
+	w.Header().Set("X-Cache", status)
+	if prefixes != "" {
+		w.Header().Set("X-Cache-Prefix", prefixes)
+	}
+}
+
+// graphqlPayload 為 GraphQL 請求內容，extensions.persistedQuery 與 Apollo persisted queries 相同
+type graphqlPayload struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+	Extensions    struct {
+		PersistedQuery *struct {
+			Sha256Hash string `json:"sha256Hash"`
+		} `json:"persistedQuery"`
+	} `json:"extensions"`
+}
+
+func (p graphqlPayload) persistedQueryHash() string {
+	if p.Extensions.PersistedQuery == nil {
+		return ""
+	}
+	return p.Extensions.PersistedQuery.Sha256Hash
+}
+
+// writeGraphQLError 以 GraphQL 的 errors 格式回應，code 放在 extensions.code
+func writeGraphQLError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"errors": []map[string]any{{
+			"message":    message,
+			"extensions": map[string]string{"code": code},
+		}},
 	})
 }
 
-type ProbeResult struct {
-	Name       string          `json:"name"`
-	StatusCode int             `json:"statusCode"`
-	Body       json.RawMessage `json:"body,omitempty"`
-	Error      string          `json:"error,omitempty"`
+// requestIDFrom 優先使用上游帶入的 X-Request-Id 或 trace id（traceparent 或 Cloud Run 的
+// X-Cloud-Trace-Context），沒有時自行產生
+func requestIDFrom(r *http.Request) string {
+	if id := r.Header.Get("X-Request-Id"); id != "" {
+		return id
+	}
+	if span, ok := tracecontext.FromRequest(r); ok {
+		return span.TraceID
+	}
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
 }
 
 // ProbeHandler runs a set of built-in GQL queries against target URL.
@@ -61,6 +280,8 @@ func ProbeHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	var payload struct {
 		URL string `json:"url"`
+		// Headers 會同時帶到 target 與 self 的請求，用來測試需要會員身分的查詢
+		Headers map[string]string `json:"headers"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.URL == "" {
 		http.Error(w, "invalid payload, need {\"url\": \"https://original-gql\"}", http.StatusBadRequest)
@@ -73,38 +294,18 @@ func ProbeHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	selfURL := fmt.Sprintf("%s://%s/api/graphql", scheme, r.Host)
 
-	targetResults := runProbeTests(payload.URL)
-	selfResults := runProbeTests(selfURL)
-
-	selfMap := map[string]ProbeResult{}
-	for _, r := range selfResults {
-		selfMap[r.Name] = r
+	// 對 target 與 self 的請求帶上呼叫端的 trace，呼叫端指定的 header 優先
+	headers := map[string]string{}
+	if span, ok := tracecontext.FromContext(r.Context()); ok {
+		for k, v := range span.Headers() {
+			headers[http.CanonicalHeaderKey(k)] = v
+		}
 	}
-
-	type compare struct {
-		Name         string `json:"name"`
-		Match        bool   `json:"match"`
-		TargetStatus int    `json:"targetStatus"`
-		SelfStatus   int    `json:"selfStatus"`
-		TargetError  string `json:"targetError,omitempty"`
-		SelfError    string `json:"selfError,omitempty"`
-		Note         string `json:"note,omitempty"`
+	for k, v := range payload.Headers {
+		headers[http.CanonicalHeaderKey(k)] = v
 	}
 
-	results := []compare{}
-	for _, tr := range targetResults {
-		sr := selfMap[tr.Name]
-		match, note := compareBodies(tr, sr)
-		results = append(results, compare{
-			Name:         tr.Name,
-			Match:        match,
-			TargetStatus: tr.StatusCode,
-			SelfStatus:   sr.StatusCode,
-			TargetError:  tr.Error,
-			SelfError:    sr.Error,
-			Note:         note,
-		})
-	}
+	results := probe.RunComparison(payload.URL, selfURL, probe.DefaultSuite(), headers)
 
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(map[string]any{
@@ -113,219 +314,3 @@ func ProbeHandler(w http.ResponseWriter, r *http.Request) {
 		"results": results,
 	})
 }
-
-func runProbeTests(target string) []ProbeResult {
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	tests := []struct {
-		name string
-		body map[string]any
-	}{
-		{
-			name: "posts_list",
-			body: map[string]any{
-				"query": `query ($take:Int,$skip:Int,$orderBy:[PostOrderByInput!]!,$filter:PostWhereInput!){
-					postsCount(where:$filter)
-					posts(take:$take,skip:$skip,orderBy:$orderBy,where:$filter){
-						id slug title publishedDate state
-					}
-				}`,
-				"variables": map[string]any{
-					"take":    3,
-					"skip":    0,
-					"orderBy": []map[string]string{{"publishedDate": "desc"}},
-					"filter": map[string]any{
-						"state": map[string]any{"equals": "published"},
-					},
-				},
-			},
-		},
-		{
-			name: "post_by_slug",
-			body: map[string]any{
-				"query": `query ($slug:String){ post(where:{slug:$slug}){ id slug title state } }`,
-				"variables": map[string]any{
-					"slug": "20251212-4-173036",
-				},
-			},
-		},
-		{
-			name: "externals_list",
-			body: map[string]any{
-				"query": `query ($take:Int,$skip:Int,$orderBy:[ExternalOrderByInput!]!,$filter:ExternalWhereInput!){
-					externals(take:$take,skip:$skip,orderBy:$orderBy,where:$filter){
-						id slug title thumb brief publishedDate partner{ id slug name showOnIndex }
-					}
-				}`,
-				"variables": map[string]any{
-					"take":    3,
-					"skip":    0,
-					"orderBy": []map[string]string{{"publishedDate": "desc"}},
-					"filter": map[string]any{
-						"state":         map[string]any{"equals": "published"},
-						"publishedDate": map[string]any{"not": map[string]any{"equals": nil}},
-					},
-				},
-			},
-		},
-		{
-			name: "external_by_slug",
-			body: map[string]any{
-				"query": `query ($slug:String){
-					externals(where:{slug:{equals:$slug},state:{equals:"published"}}){
-						id slug title thumb brief content publishedDate extend_byline thumbCaption
-						partner{ id slug name showOnIndex showThumb showBrief }
-						updatedAt
-					}
-				}`,
-				"variables": map[string]any{
-					"slug": "mirrordaily_35695",
-				},
-			},
-		},
-		{
-			name: "topics_list",
-			body: map[string]any{
-				"query": `query ($take:Int,$skip:Int,$orderBy:[TopicOrderByInput!]!,$filter:TopicWhereInput!){
-					topicsCount(where:$filter)
-					topics(take:$take,skip:$skip,orderBy:$orderBy,where:$filter){
-						id slug name brief createdAt style
-						heroImage{ id imageFile{ width height } resized{ original w480 w800 w1200 w1600 w2400 } resizedWebp{ original w480 w800 w1200 w1600 w2400 } }
-						og_image{ id imageFile{ width height } resized{ original w480 w800 w1200 w1600 w2400 } resizedWebp{ original w480 w800 w1200 w1600 w2400 } }
-					}
-				}`,
-				"variables": map[string]any{
-					"take":    3,
-					"skip":    0,
-					"orderBy": []map[string]string{{"sortOrder": "asc"}},
-					"filter": map[string]any{
-						"state": map[string]any{"equals": "published"},
-					},
-				},
-			},
-		},
-		{
-			name: "topic_by_slug",
-			body: map[string]any{
-				"query": `query ($topicFilter:TopicWhereInput!,$postsFilter:PostWhereInput!,$featuredPostsCountFilter:PostWhereInput,$postsOrderBy:[PostOrderByInput!]!,$postsTake:Int,$postsSkip:Int!){
-					topics(where:$topicFilter){
-						id slug name brief createdAt style heroUrl leading type
-						heroImage{ id imageFile{ width height } resized{ original w480 w800 w1200 w1600 w2400 } resizedWebp{ original w480 w800 w1200 w1600 w2400 } }
-						og_image{ id imageFile{ width height } resized{ original w480 w800 w1200 w1600 w2400 } resizedWebp{ original w480 w800 w1200 w1600 w2400 } }
-						og_description
-						postsCount(where:$postsFilter)
-						featuredPostsCount: postsCount(where:$featuredPostsCountFilter)
-						tags{ id name slug }
-						slideshow_images{ id name topicKeywords resized{ original w480 w800 w1200 w1600 w2400 } }
-						manualOrderOfSlideshowImages
-						dfp
-						posts(where:$postsFilter,orderBy:$postsOrderBy,take:$postsTake,skip:$postsSkip){
-							id slug title publishedDate updatedAt brief state
-							categories(where:{state:{equals:"active"}}){ id name slug state }
-							sections(where:{state:{equals:"active"}}){ id name slug state }
-							heroImage{ id imageFile{ width height } resized{ original w480 w800 w1200 w1600 w2400 } resizedWebp{ original w480 w800 w1200 w1600 w2400 } }
-							tags{ id name slug }
-							isFeatured
-						}
-					}
-				}`,
-				"variables": map[string]any{
-					"topicFilter": map[string]any{
-						"slug": map[string]any{"equals": "test-topic"},
-					},
-					"postsFilter": map[string]any{
-						"state": map[string]any{"equals": "published"},
-					},
-					"featuredPostsCountFilter": map[string]any{
-						"state":      map[string]any{"equals": "published"},
-						"isFeatured": map[string]any{"equals": true},
-					},
-					"postsOrderBy": []map[string]string{{"publishedDate": "desc"}},
-					"postsTake":    10,
-					"postsSkip":    0,
-				},
-			},
-		},
-		{
-			name: "topic_post_count",
-			body: map[string]any{
-				"query": `query ($topicFilter:TopicWhereUniqueInput!,$postsCountFilter:PostWhereInput){
-					topic(where:$topicFilter){
-						postsCount(where:$postsCountFilter)
-					}
-				}`,
-				"variables": map[string]any{
-					"topicFilter": map[string]any{
-						"slug": "test-topic",
-					},
-					"postsCountFilter": map[string]any{
-						"state": map[string]any{"equals": "published"},
-					},
-				},
-			},
-		},
-	}
-
-	results := make([]ProbeResult, 0, len(tests))
-	for _, t := range tests {
-		res := ProbeResult{Name: t.name}
-		b, _ := json.Marshal(t.body)
-		req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(b))
-		if err != nil {
-			res.Error = err.Error()
-			results = append(results, res)
-			continue
-		}
-		req.Header.Set("Content-Type", "application/json")
-
-		resp, err := client.Do(req)
-		if err != nil {
-			res.Error = err.Error()
-			results = append(results, res)
-			continue
-		}
-		res.StatusCode = resp.StatusCode
-		body, err := io.ReadAll(resp.Body)
-		resp.Body.Close()
-		if err != nil {
-			res.Error = err.Error()
-		} else {
-			res.Body = json.RawMessage(body)
-		}
-		results = append(results, res)
-	}
-	return results
-}
-
-func compareBodies(target ProbeResult, self ProbeResult) (bool, string) {
-	// If either has transport error
-	if target.Error != "" || self.Error != "" {
-		return target.Error == "" && self.Error == "", "transport error"
-	}
-	if target.StatusCode != self.StatusCode {
-		return false, "status code differ"
-	}
-
-	tObj, tErr := normalizeJSON(target.Body)
-	sObj, sErr := normalizeJSON(self.Body)
-	if tErr == nil && sErr == nil {
-		if reflect.DeepEqual(tObj, sObj) {
-			return true, ""
-		}
-		return false, "body JSON differ"
-	}
-
-	// fallback raw compare
-	if bytes.Equal(target.Body, self.Body) {
-		return true, ""
-	}
-	return false, "body differ"
-}
-
-func normalizeJSON(raw []byte) (interface{}, error) {
-	var v interface{}
-	if err := json.Unmarshal(raw, &v); err != nil {
-		return nil, err
-	}
-	return v, nil
-}
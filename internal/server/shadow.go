@@ -0,0 +1,163 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"go-story/internal/metrics"
+	"go-story/internal/probe"
+	"go-story/internal/tracecontext"
+
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// defaultShadowWorkers Shadow 未設定 Workers 時同時送往 reference 的請求數
+const defaultShadowWorkers = 4
+
+var shadowCounter = metrics.NewCounter(
+	"go_story_shadow_requests_total",
+	"GraphQL requests mirrored to the reference endpoint, by comparison result (match, mismatch, error, dropped).",
+	"operation", "result",
+)
+
+// Shadow mirrors a sample of live GraphQL queries to a reference endpoint in
+// the background and compares its responses with ours, so parity is checked
+// against real traffic. Clients never wait for the mirror. A nil Shadow
+// mirrors nothing.
+type Shadow struct {
+	// ReferenceURL 比對用的 GQL endpoint
+	ReferenceURL string
+	// SamplePercent 送出比對的請求百分比（1–100）
+	SamplePercent int
+	// Ignore 比對前從兩邊移除的 JSON 路徑，格式與 probe 的 ignore 相同
+	Ignore []string
+	// Workers 同時送往 reference 的請求數，0 表示 4
+	Workers int
+
+	client *http.Client
+	queue  chan shadowJob
+}
+
+type shadowJob struct {
+	payload   graphqlPayload
+	requestID string
+	body      []byte
+	// span 為原始請求的 trace，送往 reference 時沿用
+	span   tracecontext.Span
+	traced bool
+}
+
+// Start launches the workers sending mirrored requests until ctx is done.
+func (s *Shadow) Start(ctx context.Context) {
+	if s == nil || s.ReferenceURL == "" {
+		return
+	}
+	workers := s.Workers
+	if workers <= 0 {
+		workers = defaultShadowWorkers
+	}
+	s.client = &http.Client{Timeout: 10 * time.Second}
+	// 佇列滿時直接丟棄，reference 變慢不會累積記憶體
+	s.queue = make(chan shadowJob, workers*16)
+	log.Printf("[Shadow] Mirroring %d%% of GraphQL queries to %s", s.SamplePercent, s.ReferenceURL)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-s.queue:
+					s.compare(ctx, job)
+				}
+			}
+		}()
+	}
+}
+
+// mirror 抽樣後將請求與我們的回應排入佇列；帶 Authorization 的請求回應因人而異，不送出
+func (s *Shadow) mirror(r *http.Request, payload graphqlPayload, requestID string, body []byte) {
+	if s == nil || s.queue == nil || r.Header.Get("Authorization") != "" {
+		return
+	}
+	if s.SamplePercent < 100 && rand.Intn(100) >= s.SamplePercent {
+		return
+	}
+	job := shadowJob{payload: payload, requestID: requestID, body: body}
+	job.span, job.traced = tracecontext.FromContext(r.Context())
+	select {
+	case s.queue <- job:
+	default:
+		shadowCounter.Inc(operationLabel(payload.OperationName), "dropped")
+	}
+}
+
+// compare 將請求送往 reference 並比對回應；mutation 與 subscription 不送出，避免重複寫入
+func (s *Shadow) compare(ctx context.Context, job shadowJob) {
+	doc, err := parser.Parse(parser.ParseParams{Source: job.payload.Query})
+	if err != nil {
+		return
+	}
+	op := selectedOperation(doc, job.payload.OperationName)
+	if op == nil || op.Operation != "query" {
+		return
+	}
+	operation := operationLabel(job.payload.OperationName)
+
+	reqBody, _ := json.Marshal(map[string]any{
+		"query":         job.payload.Query,
+		"variables":     job.payload.Variables,
+		"operationName": job.payload.OperationName,
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.ReferenceURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Id", job.requestID)
+	if job.traced {
+		for k, v := range job.span.Headers() {
+			req.Header.Set(k, v)
+		}
+	}
+	target := probe.Result{Name: operation}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		target.Error = err.Error()
+	} else {
+		target.StatusCode = resp.StatusCode
+		target.Body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			target.Error = err.Error()
+		}
+	}
+	if target.Error != "" {
+		shadowCounter.Inc(operation, "error")
+		log.Printf("[Shadow] %s (request %s): reference error: %s", operation, job.requestID, target.Error)
+		return
+	}
+
+	self := probe.Result{Name: operation, StatusCode: http.StatusOK, Body: job.body}
+	if match, note := probe.Compare(target, self, s.Ignore); !match {
+		shadowCounter.Inc(operation, "mismatch")
+		diffs := probe.Diff(target, self, s.Ignore, 5)
+		log.Printf("[Shadow] Mismatch in %s (request %s): %s: %s", operation, job.requestID, note, strings.Join(diffs, "; "))
+		return
+	}
+	shadowCounter.Inc(operation, "match")
+}
+
+// operationLabel 未命名的查詢歸為 anonymous，避免 metric label 為空
+func operationLabel(name string) string {
+	if name == "" {
+		return "anonymous"
+	}
+	return name
+}
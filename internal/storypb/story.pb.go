@@ -0,0 +1,2722 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: story/v1/story.proto
+
+// story.v1 exposes the read operations of go-story for internal consumers
+// (e.g. the recommendation pipeline). Messages mirror the structs in
+// internal/data; times are formatted like the GraphQL API.
+
+package storypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetPostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Key:
+	//	*GetPostRequest_Id
+	//	*GetPostRequest_Slug
+	Key isGetPostRequest_Key `protobuf_oneof:"key"`
+}
+
+func (x *GetPostRequest) Reset() {
+	*x = GetPostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostRequest) ProtoMessage() {}
+
+func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostRequest.ProtoReflect.Descriptor instead.
+func (*GetPostRequest) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *GetPostRequest) GetKey() isGetPostRequest_Key {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (x *GetPostRequest) GetId() string {
+	if x, ok := x.GetKey().(*GetPostRequest_Id); ok {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetPostRequest) GetSlug() string {
+	if x, ok := x.GetKey().(*GetPostRequest_Slug); ok {
+		return x.Slug
+	}
+	return ""
+}
+
+type isGetPostRequest_Key interface {
+	isGetPostRequest_Key()
+}
+
+type GetPostRequest_Id struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3,oneof"`
+}
+
+type GetPostRequest_Slug struct {
+	Slug string `protobuf:"bytes,2,opt,name=slug,proto3,oneof"`
+}
+
+func (*GetPostRequest_Id) isGetPostRequest_Key() {}
+
+func (*GetPostRequest_Slug) isGetPostRequest_Key() {}
+
+type ListPostsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// take 0 uses the server default (the GraphQL GQL_MAX_TAKE).
+	Take         int32    `protobuf:"varint,1,opt,name=take,proto3" json:"take,omitempty"`
+	Skip         int32    `protobuf:"varint,2,opt,name=skip,proto3" json:"skip,omitempty"`
+	SectionSlug  string   `protobuf:"bytes,3,opt,name=section_slug,json=sectionSlug,proto3" json:"section_slug,omitempty"`
+	CategorySlug string   `protobuf:"bytes,4,opt,name=category_slug,json=categorySlug,proto3" json:"category_slug,omitempty"`
+	TopicId      string   `protobuf:"bytes,5,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+	Ids          []string `protobuf:"bytes,6,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *ListPostsRequest) Reset() {
+	*x = ListPostsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostsRequest) ProtoMessage() {}
+
+func (x *ListPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostsRequest.ProtoReflect.Descriptor instead.
+func (*ListPostsRequest) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListPostsRequest) GetTake() int32 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+func (x *ListPostsRequest) GetSkip() int32 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *ListPostsRequest) GetSectionSlug() string {
+	if x != nil {
+		return x.SectionSlug
+	}
+	return ""
+}
+
+func (x *ListPostsRequest) GetCategorySlug() string {
+	if x != nil {
+		return x.CategorySlug
+	}
+	return ""
+}
+
+func (x *ListPostsRequest) GetTopicId() string {
+	if x != nil {
+		return x.TopicId
+	}
+	return ""
+}
+
+func (x *ListPostsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type ListPostsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Posts []*Post `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+}
+
+func (x *ListPostsResponse) Reset() {
+	*x = ListPostsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostsResponse) ProtoMessage() {}
+
+func (x *ListPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostsResponse.ProtoReflect.Descriptor instead.
+func (*ListPostsResponse) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPostsResponse) GetPosts() []*Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+type ListTopicsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Take         int32  `protobuf:"varint,1,opt,name=take,proto3" json:"take,omitempty"`
+	Skip         int32  `protobuf:"varint,2,opt,name=skip,proto3" json:"skip,omitempty"`
+	State        string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	FeaturedOnly bool   `protobuf:"varint,4,opt,name=featured_only,json=featuredOnly,proto3" json:"featured_only,omitempty"`
+}
+
+func (x *ListTopicsRequest) Reset() {
+	*x = ListTopicsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTopicsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopicsRequest) ProtoMessage() {}
+
+func (x *ListTopicsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopicsRequest.ProtoReflect.Descriptor instead.
+func (*ListTopicsRequest) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTopicsRequest) GetTake() int32 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+func (x *ListTopicsRequest) GetSkip() int32 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *ListTopicsRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ListTopicsRequest) GetFeaturedOnly() bool {
+	if x != nil {
+		return x.FeaturedOnly
+	}
+	return false
+}
+
+type ListTopicsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Topics []*Topic `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+}
+
+func (x *ListTopicsResponse) Reset() {
+	*x = ListTopicsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTopicsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopicsResponse) ProtoMessage() {}
+
+func (x *ListTopicsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopicsResponse.ProtoReflect.Descriptor instead.
+func (*ListTopicsResponse) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListTopicsResponse) GetTopics() []*Topic {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+type ListExternalsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Take        int32  `protobuf:"varint,1,opt,name=take,proto3" json:"take,omitempty"`
+	Skip        int32  `protobuf:"varint,2,opt,name=skip,proto3" json:"skip,omitempty"`
+	PartnerSlug string `protobuf:"bytes,3,opt,name=partner_slug,json=partnerSlug,proto3" json:"partner_slug,omitempty"`
+}
+
+func (x *ListExternalsRequest) Reset() {
+	*x = ListExternalsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExternalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExternalsRequest) ProtoMessage() {}
+
+func (x *ListExternalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExternalsRequest.ProtoReflect.Descriptor instead.
+func (*ListExternalsRequest) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListExternalsRequest) GetTake() int32 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+func (x *ListExternalsRequest) GetSkip() int32 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *ListExternalsRequest) GetPartnerSlug() string {
+	if x != nil {
+		return x.PartnerSlug
+	}
+	return ""
+}
+
+type ListExternalsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Externals []*External `protobuf:"bytes,1,rep,name=externals,proto3" json:"externals,omitempty"`
+}
+
+func (x *ListExternalsResponse) Reset() {
+	*x = ListExternalsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExternalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExternalsResponse) ProtoMessage() {}
+
+func (x *ListExternalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExternalsResponse.ProtoReflect.Descriptor instead.
+func (*ListExternalsResponse) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListExternalsResponse) GetExternals() []*External {
+	if x != nil {
+		return x.Externals
+	}
+	return nil
+}
+
+type ImageFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Width  int32 `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (x *ImageFile) Reset() {
+	*x = ImageFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImageFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageFile) ProtoMessage() {}
+
+func (x *ImageFile) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageFile.ProtoReflect.Descriptor instead.
+func (*ImageFile) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ImageFile) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ImageFile) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type Resized struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Original string `protobuf:"bytes,1,opt,name=original,proto3" json:"original,omitempty"`
+	W480     string `protobuf:"bytes,2,opt,name=w480,proto3" json:"w480,omitempty"`
+	W800     string `protobuf:"bytes,3,opt,name=w800,proto3" json:"w800,omitempty"`
+	W1200    string `protobuf:"bytes,4,opt,name=w1200,proto3" json:"w1200,omitempty"`
+	W1600    string `protobuf:"bytes,5,opt,name=w1600,proto3" json:"w1600,omitempty"`
+	W2400    string `protobuf:"bytes,6,opt,name=w2400,proto3" json:"w2400,omitempty"`
+}
+
+func (x *Resized) Reset() {
+	*x = Resized{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Resized) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resized) ProtoMessage() {}
+
+func (x *Resized) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resized.ProtoReflect.Descriptor instead.
+func (*Resized) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Resized) GetOriginal() string {
+	if x != nil {
+		return x.Original
+	}
+	return ""
+}
+
+func (x *Resized) GetW480() string {
+	if x != nil {
+		return x.W480
+	}
+	return ""
+}
+
+func (x *Resized) GetW800() string {
+	if x != nil {
+		return x.W800
+	}
+	return ""
+}
+
+func (x *Resized) GetW1200() string {
+	if x != nil {
+		return x.W1200
+	}
+	return ""
+}
+
+func (x *Resized) GetW1600() string {
+	if x != nil {
+		return x.W1600
+	}
+	return ""
+}
+
+func (x *Resized) GetW2400() string {
+	if x != nil {
+		return x.W2400
+	}
+	return ""
+}
+
+type Photo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TopicKeywords string     `protobuf:"bytes,3,opt,name=topic_keywords,json=topicKeywords,proto3" json:"topic_keywords,omitempty"`
+	ImageFile     *ImageFile `protobuf:"bytes,4,opt,name=image_file,json=imageFile,proto3" json:"image_file,omitempty"`
+	Resized       *Resized   `protobuf:"bytes,5,opt,name=resized,proto3" json:"resized,omitempty"`
+	ResizedWebp   *Resized   `protobuf:"bytes,6,opt,name=resized_webp,json=resizedWebp,proto3" json:"resized_webp,omitempty"`
+	// Unset unless AVIF variants are generated (IMAGE_AVIF).
+	ResizedAvif *Resized `protobuf:"bytes,7,opt,name=resized_avif,json=resizedAvif,proto3" json:"resized_avif,omitempty"`
+}
+
+func (x *Photo) Reset() {
+	*x = Photo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Photo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Photo) ProtoMessage() {}
+
+func (x *Photo) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Photo.ProtoReflect.Descriptor instead.
+func (*Photo) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Photo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Photo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Photo) GetTopicKeywords() string {
+	if x != nil {
+		return x.TopicKeywords
+	}
+	return ""
+}
+
+func (x *Photo) GetImageFile() *ImageFile {
+	if x != nil {
+		return x.ImageFile
+	}
+	return nil
+}
+
+func (x *Photo) GetResized() *Resized {
+	if x != nil {
+		return x.Resized
+	}
+	return nil
+}
+
+func (x *Photo) GetResizedWebp() *Resized {
+	if x != nil {
+		return x.ResizedWebp
+	}
+	return nil
+}
+
+func (x *Photo) GetResizedAvif() *Resized {
+	if x != nil {
+		return x.ResizedAvif
+	}
+	return nil
+}
+
+type Section struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug  string `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	State string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+}
+
+func (x *Section) Reset() {
+	*x = Section{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Section) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Section) ProtoMessage() {}
+
+func (x *Section) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Section.ProtoReflect.Descriptor instead.
+func (*Section) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Section) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Section) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Section) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Section) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type Category struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug         string     `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	State        string     `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	IsMemberOnly bool       `protobuf:"varint,5,opt,name=is_member_only,json=isMemberOnly,proto3" json:"is_member_only,omitempty"`
+	Sections     []*Section `protobuf:"bytes,6,rep,name=sections,proto3" json:"sections,omitempty"`
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Category) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Category) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Category) GetIsMemberOnly() bool {
+	if x != nil {
+		return x.IsMemberOnly
+	}
+	return false
+}
+
+func (x *Category) GetSections() []*Section {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+type Contact struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *Contact) Reset() {
+	*x = Contact{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Contact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contact) ProtoMessage() {}
+
+func (x *Contact) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contact.ProtoReflect.Descriptor instead.
+func (*Contact) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Contact) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Contact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Tag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug string `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Tag) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tag) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type Video struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	State     string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	VideoSrc  string `protobuf:"bytes,4,opt,name=video_src,json=videoSrc,proto3" json:"video_src,omitempty"`
+	Mp4Src    string `protobuf:"bytes,5,opt,name=mp4_src,json=mp4Src,proto3" json:"mp4_src,omitempty"`
+	HlsSrc    string `protobuf:"bytes,6,opt,name=hls_src,json=hlsSrc,proto3" json:"hls_src,omitempty"`
+	Duration  int32  `protobuf:"varint,7,opt,name=duration,proto3" json:"duration,omitempty"`
+	HeroImage *Photo `protobuf:"bytes,8,opt,name=hero_image,json=heroImage,proto3" json:"hero_image,omitempty"`
+}
+
+func (x *Video) Reset() {
+	*x = Video{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Video) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Video) ProtoMessage() {}
+
+func (x *Video) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Video.ProtoReflect.Descriptor instead.
+func (*Video) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Video) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Video) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Video) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Video) GetVideoSrc() string {
+	if x != nil {
+		return x.VideoSrc
+	}
+	return ""
+}
+
+func (x *Video) GetMp4Src() string {
+	if x != nil {
+		return x.Mp4Src
+	}
+	return ""
+}
+
+func (x *Video) GetHlsSrc() string {
+	if x != nil {
+		return x.HlsSrc
+	}
+	return ""
+}
+
+func (x *Video) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *Video) GetHeroImage() *Photo {
+	if x != nil {
+		return x.HeroImage
+	}
+	return nil
+}
+
+type AudioFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Filesize int32  `protobuf:"varint,2,opt,name=filesize,proto3" json:"filesize,omitempty"`
+	Url      string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *AudioFile) Reset() {
+	*x = AudioFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AudioFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AudioFile) ProtoMessage() {}
+
+func (x *AudioFile) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AudioFile.ProtoReflect.Descriptor instead.
+func (*AudioFile) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AudioFile) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *AudioFile) GetFilesize() int32 {
+	if x != nil {
+		return x.Filesize
+	}
+	return 0
+}
+
+func (x *AudioFile) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type Audio struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	File      *AudioFile `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	HeroImage *Photo     `protobuf:"bytes,4,opt,name=hero_image,json=heroImage,proto3" json:"hero_image,omitempty"`
+}
+
+func (x *Audio) Reset() {
+	*x = Audio{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Audio) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Audio) ProtoMessage() {}
+
+func (x *Audio) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Audio.ProtoReflect.Descriptor instead.
+func (*Audio) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Audio) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Audio) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Audio) GetFile() *AudioFile {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *Audio) GetHeroImage() *Photo {
+	if x != nil {
+		return x.HeroImage
+	}
+	return nil
+}
+
+type Partner struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug        string `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name        string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ShowOnIndex bool   `protobuf:"varint,4,opt,name=show_on_index,json=showOnIndex,proto3" json:"show_on_index,omitempty"`
+	ShowThumb   bool   `protobuf:"varint,5,opt,name=show_thumb,json=showThumb,proto3" json:"show_thumb,omitempty"`
+	ShowBrief   bool   `protobuf:"varint,6,opt,name=show_brief,json=showBrief,proto3" json:"show_brief,omitempty"`
+}
+
+func (x *Partner) Reset() {
+	*x = Partner{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Partner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Partner) ProtoMessage() {}
+
+func (x *Partner) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Partner.ProtoReflect.Descriptor instead.
+func (*Partner) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Partner) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Partner) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Partner) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Partner) GetShowOnIndex() bool {
+	if x != nil {
+		return x.ShowOnIndex
+	}
+	return false
+}
+
+func (x *Partner) GetShowThumb() bool {
+	if x != nil {
+		return x.ShowThumb
+	}
+	return false
+}
+
+func (x *Partner) GetShowBrief() bool {
+	if x != nil {
+		return x.ShowBrief
+	}
+	return false
+}
+
+type Post struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug          string      `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Title         string      `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Subtitle      string      `protobuf:"bytes,4,opt,name=subtitle,proto3" json:"subtitle,omitempty"`
+	State         string      `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	Style         string      `protobuf:"bytes,6,opt,name=style,proto3" json:"style,omitempty"`
+	PublishedDate string      `protobuf:"bytes,7,opt,name=published_date,json=publishedDate,proto3" json:"published_date,omitempty"`
+	UpdatedAt     string      `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	IsMember      bool        `protobuf:"varint,9,opt,name=is_member,json=isMember,proto3" json:"is_member,omitempty"`
+	IsAdult       bool        `protobuf:"varint,10,opt,name=is_adult,json=isAdult,proto3" json:"is_adult,omitempty"`
+	Sections      []*Section  `protobuf:"bytes,11,rep,name=sections,proto3" json:"sections,omitempty"`
+	Categories    []*Category `protobuf:"bytes,12,rep,name=categories,proto3" json:"categories,omitempty"`
+	Writers       []*Contact  `protobuf:"bytes,13,rep,name=writers,proto3" json:"writers,omitempty"`
+	Photographers []*Contact  `protobuf:"bytes,14,rep,name=photographers,proto3" json:"photographers,omitempty"`
+	CameraMan     []*Contact  `protobuf:"bytes,15,rep,name=camera_man,json=cameraMan,proto3" json:"camera_man,omitempty"`
+	Designers     []*Contact  `protobuf:"bytes,16,rep,name=designers,proto3" json:"designers,omitempty"`
+	Engineers     []*Contact  `protobuf:"bytes,17,rep,name=engineers,proto3" json:"engineers,omitempty"`
+	Vocals        []*Contact  `protobuf:"bytes,18,rep,name=vocals,proto3" json:"vocals,omitempty"`
+	ExtendByline  string      `protobuf:"bytes,19,opt,name=extend_byline,json=extendByline,proto3" json:"extend_byline,omitempty"`
+	Tags          []*Tag      `protobuf:"bytes,20,rep,name=tags,proto3" json:"tags,omitempty"`
+	TagsAlgo      []*Tag      `protobuf:"bytes,21,rep,name=tags_algo,json=tagsAlgo,proto3" json:"tags_algo,omitempty"`
+	HeroVideo     *Video      `protobuf:"bytes,22,opt,name=hero_video,json=heroVideo,proto3" json:"hero_video,omitempty"`
+	HeroAudio     *Audio      `protobuf:"bytes,23,opt,name=hero_audio,json=heroAudio,proto3" json:"hero_audio,omitempty"`
+	Audio         *Audio      `protobuf:"bytes,24,opt,name=audio,proto3" json:"audio,omitempty"`
+	HeroImage     *Photo      `protobuf:"bytes,25,opt,name=hero_image,json=heroImage,proto3" json:"hero_image,omitempty"`
+	HeroCaption   string      `protobuf:"bytes,26,opt,name=hero_caption,json=heroCaption,proto3" json:"hero_caption,omitempty"`
+	// Draft.js content, as in the GraphQL JSON fields.
+	Brief          *structpb.Struct `protobuf:"bytes,27,opt,name=brief,proto3" json:"brief,omitempty"`
+	TrimmedContent *structpb.Struct `protobuf:"bytes,28,opt,name=trimmed_content,json=trimmedContent,proto3" json:"trimmed_content,omitempty"`
+	Content        *structpb.Struct `protobuf:"bytes,29,opt,name=content,proto3" json:"content,omitempty"`
+	// relateds only carry the fields loaded for related posts.
+	Relateds         []*Post `protobuf:"bytes,30,rep,name=relateds,proto3" json:"relateds,omitempty"`
+	Redirect         string  `protobuf:"bytes,31,opt,name=redirect,proto3" json:"redirect,omitempty"`
+	OgTitle          string  `protobuf:"bytes,32,opt,name=og_title,json=ogTitle,proto3" json:"og_title,omitempty"`
+	OgImage          *Photo  `protobuf:"bytes,33,opt,name=og_image,json=ogImage,proto3" json:"og_image,omitempty"`
+	OgDescription    string  `protobuf:"bytes,34,opt,name=og_description,json=ogDescription,proto3" json:"og_description,omitempty"`
+	HiddenAdvertised bool    `protobuf:"varint,35,opt,name=hidden_advertised,json=hiddenAdvertised,proto3" json:"hidden_advertised,omitempty"`
+	IsAdvertised     bool    `protobuf:"varint,36,opt,name=is_advertised,json=isAdvertised,proto3" json:"is_advertised,omitempty"`
+	IsFeatured       bool    `protobuf:"varint,37,opt,name=is_featured,json=isFeatured,proto3" json:"is_featured,omitempty"`
+	TopicId          string  `protobuf:"bytes,38,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+	ReadingTime      int32   `protobuf:"varint,39,opt,name=reading_time,json=readingTime,proto3" json:"reading_time,omitempty"`
+	WordCount        int32   `protobuf:"varint,40,opt,name=word_count,json=wordCount,proto3" json:"word_count,omitempty"`
+}
+
+func (x *Post) Reset() {
+	*x = Post{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Post) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Post) ProtoMessage() {}
+
+func (x *Post) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Post.ProtoReflect.Descriptor instead.
+func (*Post) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Post) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Post) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Post) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Post) GetSubtitle() string {
+	if x != nil {
+		return x.Subtitle
+	}
+	return ""
+}
+
+func (x *Post) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Post) GetStyle() string {
+	if x != nil {
+		return x.Style
+	}
+	return ""
+}
+
+func (x *Post) GetPublishedDate() string {
+	if x != nil {
+		return x.PublishedDate
+	}
+	return ""
+}
+
+func (x *Post) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Post) GetIsMember() bool {
+	if x != nil {
+		return x.IsMember
+	}
+	return false
+}
+
+func (x *Post) GetIsAdult() bool {
+	if x != nil {
+		return x.IsAdult
+	}
+	return false
+}
+
+func (x *Post) GetSections() []*Section {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+func (x *Post) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Post) GetWriters() []*Contact {
+	if x != nil {
+		return x.Writers
+	}
+	return nil
+}
+
+func (x *Post) GetPhotographers() []*Contact {
+	if x != nil {
+		return x.Photographers
+	}
+	return nil
+}
+
+func (x *Post) GetCameraMan() []*Contact {
+	if x != nil {
+		return x.CameraMan
+	}
+	return nil
+}
+
+func (x *Post) GetDesigners() []*Contact {
+	if x != nil {
+		return x.Designers
+	}
+	return nil
+}
+
+func (x *Post) GetEngineers() []*Contact {
+	if x != nil {
+		return x.Engineers
+	}
+	return nil
+}
+
+func (x *Post) GetVocals() []*Contact {
+	if x != nil {
+		return x.Vocals
+	}
+	return nil
+}
+
+func (x *Post) GetExtendByline() string {
+	if x != nil {
+		return x.ExtendByline
+	}
+	return ""
+}
+
+func (x *Post) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Post) GetTagsAlgo() []*Tag {
+	if x != nil {
+		return x.TagsAlgo
+	}
+	return nil
+}
+
+func (x *Post) GetHeroVideo() *Video {
+	if x != nil {
+		return x.HeroVideo
+	}
+	return nil
+}
+
+func (x *Post) GetHeroAudio() *Audio {
+	if x != nil {
+		return x.HeroAudio
+	}
+	return nil
+}
+
+func (x *Post) GetAudio() *Audio {
+	if x != nil {
+		return x.Audio
+	}
+	return nil
+}
+
+func (x *Post) GetHeroImage() *Photo {
+	if x != nil {
+		return x.HeroImage
+	}
+	return nil
+}
+
+func (x *Post) GetHeroCaption() string {
+	if x != nil {
+		return x.HeroCaption
+	}
+	return ""
+}
+
+func (x *Post) GetBrief() *structpb.Struct {
+	if x != nil {
+		return x.Brief
+	}
+	return nil
+}
+
+func (x *Post) GetTrimmedContent() *structpb.Struct {
+	if x != nil {
+		return x.TrimmedContent
+	}
+	return nil
+}
+
+func (x *Post) GetContent() *structpb.Struct {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *Post) GetRelateds() []*Post {
+	if x != nil {
+		return x.Relateds
+	}
+	return nil
+}
+
+func (x *Post) GetRedirect() string {
+	if x != nil {
+		return x.Redirect
+	}
+	return ""
+}
+
+func (x *Post) GetOgTitle() string {
+	if x != nil {
+		return x.OgTitle
+	}
+	return ""
+}
+
+func (x *Post) GetOgImage() *Photo {
+	if x != nil {
+		return x.OgImage
+	}
+	return nil
+}
+
+func (x *Post) GetOgDescription() string {
+	if x != nil {
+		return x.OgDescription
+	}
+	return ""
+}
+
+func (x *Post) GetHiddenAdvertised() bool {
+	if x != nil {
+		return x.HiddenAdvertised
+	}
+	return false
+}
+
+func (x *Post) GetIsAdvertised() bool {
+	if x != nil {
+		return x.IsAdvertised
+	}
+	return false
+}
+
+func (x *Post) GetIsFeatured() bool {
+	if x != nil {
+		return x.IsFeatured
+	}
+	return false
+}
+
+func (x *Post) GetTopicId() string {
+	if x != nil {
+		return x.TopicId
+	}
+	return ""
+}
+
+func (x *Post) GetReadingTime() int32 {
+	if x != nil {
+		return x.ReadingTime
+	}
+	return 0
+}
+
+func (x *Post) GetWordCount() int32 {
+	if x != nil {
+		return x.WordCount
+	}
+	return 0
+}
+
+type Topic struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string           `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug            string           `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	SortOrder       *int32           `protobuf:"varint,4,opt,name=sort_order,json=sortOrder,proto3,oneof" json:"sort_order,omitempty"`
+	State           string           `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	Brief           *structpb.Struct `protobuf:"bytes,6,opt,name=brief,proto3" json:"brief,omitempty"`
+	HeroImage       *Photo           `protobuf:"bytes,7,opt,name=hero_image,json=heroImage,proto3" json:"hero_image,omitempty"`
+	HeroUrl         string           `protobuf:"bytes,8,opt,name=hero_url,json=heroUrl,proto3" json:"hero_url,omitempty"`
+	Leading         string           `protobuf:"bytes,9,opt,name=leading,proto3" json:"leading,omitempty"`
+	OgTitle         string           `protobuf:"bytes,10,opt,name=og_title,json=ogTitle,proto3" json:"og_title,omitempty"`
+	OgDescription   string           `protobuf:"bytes,11,opt,name=og_description,json=ogDescription,proto3" json:"og_description,omitempty"`
+	OgImage         *Photo           `protobuf:"bytes,12,opt,name=og_image,json=ogImage,proto3" json:"og_image,omitempty"`
+	IsFeatured      bool             `protobuf:"varint,13,opt,name=is_featured,json=isFeatured,proto3" json:"is_featured,omitempty"`
+	TitleStyle      string           `protobuf:"bytes,14,opt,name=title_style,json=titleStyle,proto3" json:"title_style,omitempty"`
+	Type            string           `protobuf:"bytes,15,opt,name=type,proto3" json:"type,omitempty"`
+	Style           string           `protobuf:"bytes,16,opt,name=style,proto3" json:"style,omitempty"`
+	Tags            []*Tag           `protobuf:"bytes,17,rep,name=tags,proto3" json:"tags,omitempty"`
+	SlideshowImages []*Photo         `protobuf:"bytes,18,rep,name=slideshow_images,json=slideshowImages,proto3" json:"slideshow_images,omitempty"`
+	CreatedAt       string           `protobuf:"bytes,19,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       string           `protobuf:"bytes,20,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *Topic) Reset() {
+	*x = Topic{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Topic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Topic) ProtoMessage() {}
+
+func (x *Topic) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Topic.ProtoReflect.Descriptor instead.
+func (*Topic) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Topic) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Topic) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Topic) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Topic) GetSortOrder() int32 {
+	if x != nil && x.SortOrder != nil {
+		return *x.SortOrder
+	}
+	return 0
+}
+
+func (x *Topic) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Topic) GetBrief() *structpb.Struct {
+	if x != nil {
+		return x.Brief
+	}
+	return nil
+}
+
+func (x *Topic) GetHeroImage() *Photo {
+	if x != nil {
+		return x.HeroImage
+	}
+	return nil
+}
+
+func (x *Topic) GetHeroUrl() string {
+	if x != nil {
+		return x.HeroUrl
+	}
+	return ""
+}
+
+func (x *Topic) GetLeading() string {
+	if x != nil {
+		return x.Leading
+	}
+	return ""
+}
+
+func (x *Topic) GetOgTitle() string {
+	if x != nil {
+		return x.OgTitle
+	}
+	return ""
+}
+
+func (x *Topic) GetOgDescription() string {
+	if x != nil {
+		return x.OgDescription
+	}
+	return ""
+}
+
+func (x *Topic) GetOgImage() *Photo {
+	if x != nil {
+		return x.OgImage
+	}
+	return nil
+}
+
+func (x *Topic) GetIsFeatured() bool {
+	if x != nil {
+		return x.IsFeatured
+	}
+	return false
+}
+
+func (x *Topic) GetTitleStyle() string {
+	if x != nil {
+		return x.TitleStyle
+	}
+	return ""
+}
+
+func (x *Topic) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Topic) GetStyle() string {
+	if x != nil {
+		return x.Style
+	}
+	return ""
+}
+
+func (x *Topic) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Topic) GetSlideshowImages() []*Photo {
+	if x != nil {
+		return x.SlideshowImages
+	}
+	return nil
+}
+
+func (x *Topic) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Topic) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type External struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug          string   `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Partner       *Partner `protobuf:"bytes,3,opt,name=partner,proto3" json:"partner,omitempty"`
+	Title         string   `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	State         string   `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	PublishedDate string   `protobuf:"bytes,6,opt,name=published_date,json=publishedDate,proto3" json:"published_date,omitempty"`
+	ExtendByline  string   `protobuf:"bytes,7,opt,name=extend_byline,json=extendByline,proto3" json:"extend_byline,omitempty"`
+	Thumb         string   `protobuf:"bytes,8,opt,name=thumb,proto3" json:"thumb,omitempty"`
+	ThumbCaption  string   `protobuf:"bytes,9,opt,name=thumb_caption,json=thumbCaption,proto3" json:"thumb_caption,omitempty"`
+	Brief         string   `protobuf:"bytes,10,opt,name=brief,proto3" json:"brief,omitempty"`
+	Content       string   `protobuf:"bytes,11,opt,name=content,proto3" json:"content,omitempty"`
+	UpdatedAt     string   `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Tags          []*Tag   `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`
+	Relateds      []*Post  `protobuf:"bytes,14,rep,name=relateds,proto3" json:"relateds,omitempty"`
+}
+
+func (x *External) Reset() {
+	*x = External{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_story_v1_story_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *External) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*External) ProtoMessage() {}
+
+func (x *External) ProtoReflect() protoreflect.Message {
+	mi := &file_story_v1_story_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use External.ProtoReflect.Descriptor instead.
+func (*External) Descriptor() ([]byte, []int) {
+	return file_story_v1_story_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *External) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *External) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *External) GetPartner() *Partner {
+	if x != nil {
+		return x.Partner
+	}
+	return nil
+}
+
+func (x *External) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *External) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *External) GetPublishedDate() string {
+	if x != nil {
+		return x.PublishedDate
+	}
+	return ""
+}
+
+func (x *External) GetExtendByline() string {
+	if x != nil {
+		return x.ExtendByline
+	}
+	return ""
+}
+
+func (x *External) GetThumb() string {
+	if x != nil {
+		return x.Thumb
+	}
+	return ""
+}
+
+func (x *External) GetThumbCaption() string {
+	if x != nil {
+		return x.ThumbCaption
+	}
+	return ""
+}
+
+func (x *External) GetBrief() string {
+	if x != nil {
+		return x.Brief
+	}
+	return ""
+}
+
+func (x *External) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *External) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *External) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *External) GetRelateds() []*Post {
+	if x != nil {
+		return x.Relateds
+	}
+	return nil
+}
+
+var File_story_v1_story_proto protoreflect.FileDescriptor
+
+var file_story_v1_story_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31,
+	0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3f,
+	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x14, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x42, 0x05, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x22,
+	0xaf, 0x01, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x6b, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x74, 0x61, 0x6b, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6b, 0x69, 0x70,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x12, 0x21, 0x0a, 0x0c,
+	0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6c, 0x75, 0x67, 0x12,
+	0x23, 0x0a, 0x0d, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x5f, 0x73, 0x6c, 0x75, 0x67,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x53, 0x6c, 0x75, 0x67, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x5f, 0x69, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x49, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64,
+	0x73, 0x22, 0x39, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x05, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x05, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x76, 0x0a, 0x11,
+	0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x6b, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x74, 0x61, 0x6b, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x64, 0x5f, 0x6f, 0x6e, 0x6c, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x64,
+	0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x3d, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x70, 0x69,
+	0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x74, 0x6f,
+	0x70, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x06, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x73, 0x22, 0x61, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x61, 0x6b, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x61, 0x6b, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73,
+	0x6b, 0x69, 0x70, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x6e, 0x65, 0x72, 0x5f, 0x73,
+	0x6c, 0x75, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x6e,
+	0x65, 0x72, 0x53, 0x6c, 0x75, 0x67, 0x22, 0x49, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x30, 0x0a, 0x09, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x09, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x73, 0x22, 0x39, 0x0a, 0x09, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77,
+	0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x8f, 0x01, 0x0a,
+	0x07, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x34, 0x38, 0x30, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x77, 0x34, 0x38, 0x30, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x38, 0x30, 0x30,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x77, 0x38, 0x30, 0x30, 0x12, 0x14, 0x0a, 0x05,
+	0x77, 0x31, 0x32, 0x30, 0x30, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x77, 0x31, 0x32,
+	0x30, 0x30, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x31, 0x36, 0x30, 0x30, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x77, 0x31, 0x36, 0x30, 0x30, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x32, 0x34, 0x30,
+	0x30, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x77, 0x32, 0x34, 0x30, 0x30, 0x22, 0x9f,
+	0x02, 0x0a, 0x05, 0x50, 0x68, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e,
+	0x74, 0x6f, 0x70, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x77, 0x6f,
+	0x72, 0x64, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x09, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x69, 0x7a,
+	0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x52, 0x07, 0x72, 0x65, 0x73,
+	0x69, 0x7a, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x5f,
+	0x77, 0x65, 0x62, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x52, 0x0b, 0x72,
+	0x65, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x57, 0x65, 0x62, 0x70, 0x12, 0x34, 0x0a, 0x0c, 0x72, 0x65,
+	0x73, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x61, 0x76, 0x69, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x69,
+	0x7a, 0x65, 0x64, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x41, 0x76, 0x69, 0x66,
+	0x22, 0x57, 0x0a, 0x07, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73,
+	0x6c, 0x75, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0xad, 0x01, 0x0a, 0x08, 0x43, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c,
+	0x75, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x69, 0x73, 0x5f, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x2d, 0x0a, 0x08, 0x73, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x08, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x2d, 0x0a, 0x07, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x63, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3d, 0x0a, 0x03, 0x54, 0x61, 0x67, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x22, 0xdc, 0x01, 0x0a, 0x05, 0x56, 0x69, 0x64, 0x65,
+	0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x76,
+	0x69, 0x64, 0x65, 0x6f, 0x5f, 0x73, 0x72, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x76, 0x69, 0x64, 0x65, 0x6f, 0x53, 0x72, 0x63, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x70, 0x34, 0x5f,
+	0x73, 0x72, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x70, 0x34, 0x53, 0x72,
+	0x63, 0x12, 0x17, 0x0a, 0x07, 0x68, 0x6c, 0x73, 0x5f, 0x73, 0x72, 0x63, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x68, 0x6c, 0x73, 0x53, 0x72, 0x63, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x0a, 0x68, 0x65, 0x72, 0x6f, 0x5f, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x68, 0x6f, 0x74, 0x6f, 0x52, 0x09, 0x68, 0x65, 0x72,
+	0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x22, 0x55, 0x0a, 0x09, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x46,
+	0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x84, 0x01,
+	0x0a, 0x05, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x66,
+	0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x0a, 0x68, 0x65, 0x72, 0x6f, 0x5f, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x68, 0x6f, 0x74, 0x6f, 0x52, 0x09, 0x68, 0x65, 0x72, 0x6f, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x22, 0xa3, 0x01, 0x0a, 0x07, 0x50, 0x61, 0x72, 0x74, 0x6e, 0x65, 0x72,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x73, 0x6c, 0x75, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x68, 0x6f, 0x77,
+	0x5f, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x73, 0x68, 0x6f, 0x77, 0x4f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x68, 0x6f, 0x77, 0x5f, 0x74, 0x68, 0x75, 0x6d, 0x62, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x73, 0x68, 0x6f, 0x77, 0x54, 0x68, 0x75, 0x6d, 0x62, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
+	0x68, 0x6f, 0x77, 0x5f, 0x62, 0x72, 0x69, 0x65, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x73, 0x68, 0x6f, 0x77, 0x42, 0x72, 0x69, 0x65, 0x66, 0x22, 0x86, 0x0c, 0x0a, 0x04, 0x50,
+	0x6f, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x75, 0x62, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x73, 0x75, 0x62, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x73, 0x74, 0x79, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
+	0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x69,
+	0x73, 0x5f, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x69, 0x73, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x61,
+	0x64, 0x75, 0x6c, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x41, 0x64,
+	0x75, 0x6c, 0x74, 0x12, 0x2d, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x52, 0x0a, 0x63, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x07, 0x77, 0x72, 0x69, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x07, 0x77, 0x72, 0x69, 0x74,
+	0x65, 0x72, 0x73, 0x12, 0x37, 0x0a, 0x0d, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x65, 0x72, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0d, 0x70,
+	0x68, 0x6f, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x65, 0x72, 0x73, 0x12, 0x30, 0x0a, 0x0a,
+	0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x5f, 0x6d, 0x61, 0x6e, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x63, 0x74, 0x52, 0x09, 0x63, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x4d, 0x61, 0x6e, 0x12, 0x2f,
+	0x0a, 0x09, 0x64, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x63, 0x74, 0x52, 0x09, 0x64, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x12,
+	0x2f, 0x0a, 0x09, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x65, 0x72, 0x73, 0x18, 0x11, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x09, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x65, 0x72, 0x73,
+	0x12, 0x29, 0x0a, 0x06, 0x76, 0x6f, 0x63, 0x61, 0x6c, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x63, 0x74, 0x52, 0x06, 0x76, 0x6f, 0x63, 0x61, 0x6c, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x5f, 0x62, 0x79, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x42, 0x79, 0x6c, 0x69, 0x6e, 0x65,
+	0x12, 0x21, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x04, 0x74,
+	0x61, 0x67, 0x73, 0x12, 0x2a, 0x0a, 0x09, 0x74, 0x61, 0x67, 0x73, 0x5f, 0x61, 0x6c, 0x67, 0x6f,
+	0x18, 0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x08, 0x74, 0x61, 0x67, 0x73, 0x41, 0x6c, 0x67, 0x6f, 0x12,
+	0x2e, 0x0a, 0x0a, 0x68, 0x65, 0x72, 0x6f, 0x5f, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x18, 0x16, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x69, 0x64, 0x65, 0x6f, 0x52, 0x09, 0x68, 0x65, 0x72, 0x6f, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x12,
+	0x2e, 0x0a, 0x0a, 0x68, 0x65, 0x72, 0x6f, 0x5f, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x18, 0x17, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x75, 0x64, 0x69, 0x6f, 0x52, 0x09, 0x68, 0x65, 0x72, 0x6f, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x12,
+	0x25, 0x0a, 0x05, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x52,
+	0x05, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x12, 0x2e, 0x0a, 0x0a, 0x68, 0x65, 0x72, 0x6f, 0x5f, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x68, 0x6f, 0x74, 0x6f, 0x52, 0x09, 0x68, 0x65, 0x72,
+	0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x65, 0x72, 0x6f, 0x5f, 0x63,
+	0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x68, 0x65,
+	0x72, 0x6f, 0x43, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x05, 0x62, 0x72, 0x69,
+	0x65, 0x66, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x52, 0x05, 0x62, 0x72, 0x69, 0x65, 0x66, 0x12, 0x40, 0x0a, 0x0f, 0x74, 0x72, 0x69, 0x6d,
+	0x6d, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x1c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x0e, 0x74, 0x72, 0x69, 0x6d,
+	0x6d, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a,
+	0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x73, 0x18, 0x1e, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52,
+	0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x64,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x64,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x67, 0x5f, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x18, 0x20, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x67, 0x54, 0x69, 0x74, 0x6c, 0x65,
+	0x12, 0x2a, 0x0a, 0x08, 0x6f, 0x67, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x21, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x68,
+	0x6f, 0x74, 0x6f, 0x52, 0x07, 0x6f, 0x67, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x25, 0x0a, 0x0e,
+	0x6f, 0x67, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x22,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6f, 0x67, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x61, 0x64,
+	0x76, 0x65, 0x72, 0x74, 0x69, 0x73, 0x65, 0x64, 0x18, 0x23, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
+	0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x41, 0x64, 0x76, 0x65, 0x72, 0x74, 0x69, 0x73, 0x65, 0x64,
+	0x12, 0x23, 0x0a, 0x0d, 0x69, 0x73, 0x5f, 0x61, 0x64, 0x76, 0x65, 0x72, 0x74, 0x69, 0x73, 0x65,
+	0x64, 0x18, 0x24, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73, 0x41, 0x64, 0x76, 0x65, 0x72,
+	0x74, 0x69, 0x73, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x66, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x64, 0x18, 0x25, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x46, 0x65,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x5f,
+	0x69, 0x64, 0x18, 0x26, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x49,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x27, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x28, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x6f, 0x72, 0x64, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x93, 0x05, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x22, 0x0a, 0x0a, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x09, 0x73, 0x6f, 0x72,
+	0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x2d, 0x0a, 0x05, 0x62, 0x72, 0x69, 0x65, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x05, 0x62, 0x72, 0x69, 0x65, 0x66, 0x12, 0x2e,
+	0x0a, 0x0a, 0x68, 0x65, 0x72, 0x6f, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x68,
+	0x6f, 0x74, 0x6f, 0x52, 0x09, 0x68, 0x65, 0x72, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x68, 0x65, 0x72, 0x6f, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x68, 0x65, 0x72, 0x6f, 0x55, 0x72, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x65, 0x61,
+	0x64, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x67, 0x5f, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x67, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x25,
+	0x0a, 0x0e, 0x6f, 0x67, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6f, 0x67, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x08, 0x6f, 0x67, 0x5f, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x68, 0x6f, 0x74, 0x6f, 0x52, 0x07, 0x6f, 0x67, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x64,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x5f, 0x73, 0x74, 0x79, 0x6c,
+	0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x53, 0x74,
+	0x79, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x12, 0x21, 0x0a,
+	0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x12, 0x3a, 0x0a, 0x10, 0x73, 0x6c, 0x69, 0x64, 0x65, 0x73, 0x68, 0x6f, 0x77, 0x5f, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x68, 0x6f, 0x74, 0x6f, 0x52, 0x0f, 0x73, 0x6c, 0x69,
+	0x64, 0x65, 0x73, 0x68, 0x6f, 0x77, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x73,
+	0x6f, 0x72, 0x74, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x22, 0xac, 0x03, 0x0a, 0x08, 0x45, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x61,
+	0x72, 0x74, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x6e, 0x65, 0x72, 0x52, 0x07,
+	0x70, 0x61, 0x72, 0x74, 0x6e, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64,
+	0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x5f, 0x62, 0x79, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x42, 0x79, 0x6c, 0x69, 0x6e, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x68, 0x75, 0x6d, 0x62, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x68, 0x75, 0x6d, 0x62, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x68, 0x75, 0x6d, 0x62, 0x5f, 0x63,
+	0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x68,
+	0x75, 0x6d, 0x62, 0x43, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x72,
+	0x69, 0x65, 0x66, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x72, 0x69, 0x65, 0x66,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x21, 0x0a, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x2a, 0x0a, 0x08,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x08,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x73, 0x32, 0xa4, 0x02, 0x0a, 0x0c, 0x53, 0x74, 0x6f,
+	0x72, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x47, 0x65, 0x74,
+	0x50, 0x6f, 0x73, 0x74, 0x12, 0x18, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x44,
+	0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x1a, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x70, 0x69,
+	0x63, 0x73, 0x12, 0x1b, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54,
+	0x6f, 0x70, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a,
+	0x0d, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x73, 0x12, 0x1e,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x23, 0x5a, 0x21, 0x67, 0x6f, 0x2d, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x70, 0x62, 0x3b, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_story_v1_story_proto_rawDescOnce sync.Once
+	file_story_v1_story_proto_rawDescData = file_story_v1_story_proto_rawDesc
+)
+
+func file_story_v1_story_proto_rawDescGZIP() []byte {
+	file_story_v1_story_proto_rawDescOnce.Do(func() {
+		file_story_v1_story_proto_rawDescData = protoimpl.X.CompressGZIP(file_story_v1_story_proto_rawDescData)
+	})
+	return file_story_v1_story_proto_rawDescData
+}
+
+var file_story_v1_story_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_story_v1_story_proto_goTypes = []any{
+	(*GetPostRequest)(nil),        // 0: story.v1.GetPostRequest
+	(*ListPostsRequest)(nil),      // 1: story.v1.ListPostsRequest
+	(*ListPostsResponse)(nil),     // 2: story.v1.ListPostsResponse
+	(*ListTopicsRequest)(nil),     // 3: story.v1.ListTopicsRequest
+	(*ListTopicsResponse)(nil),    // 4: story.v1.ListTopicsResponse
+	(*ListExternalsRequest)(nil),  // 5: story.v1.ListExternalsRequest
+	(*ListExternalsResponse)(nil), // 6: story.v1.ListExternalsResponse
+	(*ImageFile)(nil),             // 7: story.v1.ImageFile
+	(*Resized)(nil),               // 8: story.v1.Resized
+	(*Photo)(nil),                 // 9: story.v1.Photo
+	(*Section)(nil),               // 10: story.v1.Section
+	(*Category)(nil),              // 11: story.v1.Category
+	(*Contact)(nil),               // 12: story.v1.Contact
+	(*Tag)(nil),                   // 13: story.v1.Tag
+	(*Video)(nil),                 // 14: story.v1.Video
+	(*AudioFile)(nil),             // 15: story.v1.AudioFile
+	(*Audio)(nil),                 // 16: story.v1.Audio
+	(*Partner)(nil),               // 17: story.v1.Partner
+	(*Post)(nil),                  // 18: story.v1.Post
+	(*Topic)(nil),                 // 19: story.v1.Topic
+	(*External)(nil),              // 20: story.v1.External
+	(*structpb.Struct)(nil),       // 21: google.protobuf.Struct
+}
+var file_story_v1_story_proto_depIdxs = []int32{
+	18, // 0: story.v1.ListPostsResponse.posts:type_name -> story.v1.Post
+	19, // 1: story.v1.ListTopicsResponse.topics:type_name -> story.v1.Topic
+	20, // 2: story.v1.ListExternalsResponse.externals:type_name -> story.v1.External
+	7,  // 3: story.v1.Photo.image_file:type_name -> story.v1.ImageFile
+	8,  // 4: story.v1.Photo.resized:type_name -> story.v1.Resized
+	8,  // 5: story.v1.Photo.resized_webp:type_name -> story.v1.Resized
+	8,  // 6: story.v1.Photo.resized_avif:type_name -> story.v1.Resized
+	10, // 7: story.v1.Category.sections:type_name -> story.v1.Section
+	9,  // 8: story.v1.Video.hero_image:type_name -> story.v1.Photo
+	15, // 9: story.v1.Audio.file:type_name -> story.v1.AudioFile
+	9,  // 10: story.v1.Audio.hero_image:type_name -> story.v1.Photo
+	10, // 11: story.v1.Post.sections:type_name -> story.v1.Section
+	11, // 12: story.v1.Post.categories:type_name -> story.v1.Category
+	12, // 13: story.v1.Post.writers:type_name -> story.v1.Contact
+	12, // 14: story.v1.Post.photographers:type_name -> story.v1.Contact
+	12, // 15: story.v1.Post.camera_man:type_name -> story.v1.Contact
+	12, // 16: story.v1.Post.designers:type_name -> story.v1.Contact
+	12, // 17: story.v1.Post.engineers:type_name -> story.v1.Contact
+	12, // 18: story.v1.Post.vocals:type_name -> story.v1.Contact
+	13, // 19: story.v1.Post.tags:type_name -> story.v1.Tag
+	13, // 20: story.v1.Post.tags_algo:type_name -> story.v1.Tag
+	14, // 21: story.v1.Post.hero_video:type_name -> story.v1.Video
+	16, // 22: story.v1.Post.hero_audio:type_name -> story.v1.Audio
+	16, // 23: story.v1.Post.audio:type_name -> story.v1.Audio
+	9,  // 24: story.v1.Post.hero_image:type_name -> story.v1.Photo
+	21, // 25: story.v1.Post.brief:type_name -> google.protobuf.Struct
+	21, // 26: story.v1.Post.trimmed_content:type_name -> google.protobuf.Struct
+	21, // 27: story.v1.Post.content:type_name -> google.protobuf.Struct
+	18, // 28: story.v1.Post.relateds:type_name -> story.v1.Post
+	9,  // 29: story.v1.Post.og_image:type_name -> story.v1.Photo
+	21, // 30: story.v1.Topic.brief:type_name -> google.protobuf.Struct
+	9,  // 31: story.v1.Topic.hero_image:type_name -> story.v1.Photo
+	9,  // 32: story.v1.Topic.og_image:type_name -> story.v1.Photo
+	13, // 33: story.v1.Topic.tags:type_name -> story.v1.Tag
+	9,  // 34: story.v1.Topic.slideshow_images:type_name -> story.v1.Photo
+	17, // 35: story.v1.External.partner:type_name -> story.v1.Partner
+	13, // 36: story.v1.External.tags:type_name -> story.v1.Tag
+	18, // 37: story.v1.External.relateds:type_name -> story.v1.Post
+	0,  // 38: story.v1.StoryService.GetPost:input_type -> story.v1.GetPostRequest
+	1,  // 39: story.v1.StoryService.ListPosts:input_type -> story.v1.ListPostsRequest
+	3,  // 40: story.v1.StoryService.ListTopics:input_type -> story.v1.ListTopicsRequest
+	5,  // 41: story.v1.StoryService.ListExternals:input_type -> story.v1.ListExternalsRequest
+	18, // 42: story.v1.StoryService.GetPost:output_type -> story.v1.Post
+	2,  // 43: story.v1.StoryService.ListPosts:output_type -> story.v1.ListPostsResponse
+	4,  // 44: story.v1.StoryService.ListTopics:output_type -> story.v1.ListTopicsResponse
+	6,  // 45: story.v1.StoryService.ListExternals:output_type -> story.v1.ListExternalsResponse
+	42, // [42:46] is the sub-list for method output_type
+	38, // [38:42] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
+}
+
+func init() { file_story_v1_story_proto_init() }
+func file_story_v1_story_proto_init() {
+	if File_story_v1_story_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_story_v1_story_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ListPostsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ListPostsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTopicsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTopicsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ListExternalsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ListExternalsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*ImageFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*Resized); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*Photo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*Section); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*Category); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*Contact); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*Tag); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*Video); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*AudioFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*Audio); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*Partner); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*Post); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*Topic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_story_v1_story_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*External); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_story_v1_story_proto_msgTypes[0].OneofWrappers = []any{
+		(*GetPostRequest_Id)(nil),
+		(*GetPostRequest_Slug)(nil),
+	}
+	file_story_v1_story_proto_msgTypes[19].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_story_v1_story_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_story_v1_story_proto_goTypes,
+		DependencyIndexes: file_story_v1_story_proto_depIdxs,
+		MessageInfos:      file_story_v1_story_proto_msgTypes,
+	}.Build()
+	File_story_v1_story_proto = out.File
+	file_story_v1_story_proto_rawDesc = nil
+	file_story_v1_story_proto_goTypes = nil
+	file_story_v1_story_proto_depIdxs = nil
+}
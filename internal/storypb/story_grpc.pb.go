@@ -0,0 +1,236 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: story/v1/story.proto
+
+// story.v1 exposes the read operations of go-story for internal consumers
+// (e.g. the recommendation pipeline). Messages mirror the structs in
+// internal/data; times are formatted like the GraphQL API.
+
+package storypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	StoryService_GetPost_FullMethodName       = "/story.v1.StoryService/GetPost"
+	StoryService_ListPosts_FullMethodName     = "/story.v1.StoryService/ListPosts"
+	StoryService_ListTopics_FullMethodName    = "/story.v1.StoryService/ListTopics"
+	StoryService_ListExternals_FullMethodName = "/story.v1.StoryService/ListExternals"
+)
+
+// StoryServiceClient is the client API for StoryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StoryServiceClient interface {
+	// GetPost returns a published post by id or slug.
+	GetPost(ctx context.Context, in *GetPostRequest, opts ...grpc.CallOption) (*Post, error)
+	// ListPosts returns published posts, newest first.
+	ListPosts(ctx context.Context, in *ListPostsRequest, opts ...grpc.CallOption) (*ListPostsResponse, error)
+	// ListTopics returns topics ordered by sortOrder.
+	ListTopics(ctx context.Context, in *ListTopicsRequest, opts ...grpc.CallOption) (*ListTopicsResponse, error)
+	// ListExternals returns published externals, newest first.
+	ListExternals(ctx context.Context, in *ListExternalsRequest, opts ...grpc.CallOption) (*ListExternalsResponse, error)
+}
+
+type storyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStoryServiceClient(cc grpc.ClientConnInterface) StoryServiceClient {
+	return &storyServiceClient{cc}
+}
+
+func (c *storyServiceClient) GetPost(ctx context.Context, in *GetPostRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, StoryService_GetPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storyServiceClient) ListPosts(ctx context.Context, in *ListPostsRequest, opts ...grpc.CallOption) (*ListPostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPostsResponse)
+	err := c.cc.Invoke(ctx, StoryService_ListPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storyServiceClient) ListTopics(ctx context.Context, in *ListTopicsRequest, opts ...grpc.CallOption) (*ListTopicsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTopicsResponse)
+	err := c.cc.Invoke(ctx, StoryService_ListTopics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storyServiceClient) ListExternals(ctx context.Context, in *ListExternalsRequest, opts ...grpc.CallOption) (*ListExternalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExternalsResponse)
+	err := c.cc.Invoke(ctx, StoryService_ListExternals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StoryServiceServer is the server API for StoryService service.
+// All implementations must embed UnimplementedStoryServiceServer
+// for forward compatibility
+type StoryServiceServer interface {
+	// GetPost returns a published post by id or slug.
+	GetPost(context.Context, *GetPostRequest) (*Post, error)
+	// ListPosts returns published posts, newest first.
+	ListPosts(context.Context, *ListPostsRequest) (*ListPostsResponse, error)
+	// ListTopics returns topics ordered by sortOrder.
+	ListTopics(context.Context, *ListTopicsRequest) (*ListTopicsResponse, error)
+	// ListExternals returns published externals, newest first.
+	ListExternals(context.Context, *ListExternalsRequest) (*ListExternalsResponse, error)
+	mustEmbedUnimplementedStoryServiceServer()
+}
+
+// UnimplementedStoryServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedStoryServiceServer struct {
+}
+
+func (UnimplementedStoryServiceServer) GetPost(context.Context, *GetPostRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPost not implemented")
+}
+func (UnimplementedStoryServiceServer) ListPosts(context.Context, *ListPostsRequest) (*ListPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPosts not implemented")
+}
+func (UnimplementedStoryServiceServer) ListTopics(context.Context, *ListTopicsRequest) (*ListTopicsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTopics not implemented")
+}
+func (UnimplementedStoryServiceServer) ListExternals(context.Context, *ListExternalsRequest) (*ListExternalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExternals not implemented")
+}
+func (UnimplementedStoryServiceServer) mustEmbedUnimplementedStoryServiceServer() {}
+
+// UnsafeStoryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StoryServiceServer will
+// result in compilation errors.
+type UnsafeStoryServiceServer interface {
+	mustEmbedUnimplementedStoryServiceServer()
+}
+
+func RegisterStoryServiceServer(s grpc.ServiceRegistrar, srv StoryServiceServer) {
+	s.RegisterService(&StoryService_ServiceDesc, srv)
+}
+
+func _StoryService_GetPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoryServiceServer).GetPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoryService_GetPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoryServiceServer).GetPost(ctx, req.(*GetPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoryService_ListPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoryServiceServer).ListPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoryService_ListPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoryServiceServer).ListPosts(ctx, req.(*ListPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoryService_ListTopics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopicsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoryServiceServer).ListTopics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoryService_ListTopics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoryServiceServer).ListTopics(ctx, req.(*ListTopicsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoryService_ListExternals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExternalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoryServiceServer).ListExternals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoryService_ListExternals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoryServiceServer).ListExternals(ctx, req.(*ListExternalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StoryService_ServiceDesc is the grpc.ServiceDesc for StoryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StoryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "story.v1.StoryService",
+	HandlerType: (*StoryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPost",
+			Handler:    _StoryService_GetPost_Handler,
+		},
+		{
+			MethodName: "ListPosts",
+			Handler:    _StoryService_ListPosts_Handler,
+		},
+		{
+			MethodName: "ListTopics",
+			Handler:    _StoryService_ListTopics_Handler,
+		},
+		{
+			MethodName: "ListExternals",
+			Handler:    _StoryService_ListExternals_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "story/v1/story.proto",
+}
@@ -0,0 +1,101 @@
+// Package surrogate collects the CDN surrogate keys (entity ids) contained
+// in a response, so a CDN can purge exactly the responses showing an entity.
+package surrogate
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maxHeaderLength 保守的 header 長度上限（Fastly 與 Cloudflare 皆為 16KB）
+const maxHeaderLength = 8000
+
+// Keys is the set of surrogate keys of one response.
+type Keys struct {
+	mu   sync.Mutex
+	list map[string]bool
+	ids  map[string]bool
+}
+
+type keysContextKey struct{}
+
+// NewContext returns a context collecting keys into the returned Keys.
+func NewContext(ctx context.Context) (context.Context, *Keys) {
+	k := &Keys{list: map[string]bool{}, ids: map[string]bool{}}
+	return context.WithValue(ctx, keysContextKey{}, k), k
+}
+
+// FromContext returns the Keys attached by NewContext, or nil.
+func FromContext(ctx context.Context) *Keys {
+	if ctx == nil {
+		return nil
+	}
+	k, _ := ctx.Value(keysContextKey{}).(*Keys)
+	return k
+}
+
+// AddEntity records an entity key such as "post-123".
+func (k *Keys) AddEntity(typeName, id string) {
+	if k == nil || id == "" {
+		return
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.ids[strings.ToLower(typeName)+"-"+id] = true
+}
+
+// AddList records a list key such as "post-list", used to purge every
+// list response of a type when an entity of that type is created.
+func (k *Keys) AddList(typeName string) {
+	if k == nil {
+		return
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.list[strings.ToLower(typeName)+"-list"] = true
+}
+
+// Values returns the list keys followed by the entity keys, sorted. When
+// the keys would exceed the header size limit, the remaining entity keys
+// are dropped; list keys are always kept.
+func (k *Keys) Values() []string {
+	if k == nil {
+		return nil
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	lists := sortedKeys(k.list)
+	ids := sortedKeys(k.ids)
+	result := append([]string{}, lists...)
+	length := len(strings.Join(lists, " "))
+	for _, id := range ids {
+		if length+len(id)+1 > maxHeaderLength {
+			break
+		}
+		result = append(result, id)
+		length += len(id) + 1
+	}
+	return result
+}
+
+// All returns the list keys followed by the entity keys, sorted, without
+// the header size limit of Values.
+func (k *Keys) All() []string {
+	if k == nil {
+		return nil
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return append(sortedKeys(k.list), sortedKeys(k.ids)...)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,153 @@
+// Package tracecontext reads the trace of an incoming request from the
+// W3C traceparent or Google Cloud X-Cloud-Trace-Context header and passes
+// it on to outbound requests, so logs and traces join up across services.
+package tracecontext
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Span identifies the caller's span of an incoming request. Outbound
+// requests are sent as its children.
+type Span struct {
+	// TraceID 為 32 個小寫十六進位字元
+	TraceID string
+	// SpanID 為 16 個小寫十六進位字元，X-Cloud-Trace-Context 沒有帶時為空
+	SpanID  string
+	Sampled bool
+}
+
+type spanKey struct{}
+
+// NewContext attaches s to ctx.
+func NewContext(ctx context.Context, s Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, s)
+}
+
+// FromContext returns the span attached by NewContext or Middleware.
+func FromContext(ctx context.Context) (Span, bool) {
+	if ctx == nil {
+		return Span{}, false
+	}
+	s, ok := ctx.Value(spanKey{}).(Span)
+	return s, ok
+}
+
+// Middleware attaches the span found in the request headers to the request
+// context. Requests without a valid trace header are passed through as is.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s, ok := FromRequest(r); ok {
+			r = r.WithContext(NewContext(r.Context(), s))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// FromRequest parses the traceparent header, falling back to
+// X-Cloud-Trace-Context.
+func FromRequest(r *http.Request) (Span, bool) {
+	if s, ok := parseTraceparent(r.Header.Get("traceparent")); ok {
+		return s, true
+	}
+	return parseCloudTrace(r.Header.Get("X-Cloud-Trace-Context"))
+}
+
+// LogFields returns the Cloud Logging fields linking a log entry to the
+// trace in ctx. Without project, Cloud Logging cannot resolve the trace, so
+// only the bare trace id is returned as the "traceId" field.
+func LogFields(ctx context.Context, project string) map[string]any {
+	span, ok := FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if project == "" {
+		return map[string]any{"traceId": span.TraceID}
+	}
+	fields := map[string]any{
+		"logging.googleapis.com/trace":         "projects/" + project + "/traces/" + span.TraceID,
+		"logging.googleapis.com/trace_sampled": span.Sampled,
+	}
+	if span.SpanID != "" {
+		fields["logging.googleapis.com/spanId"] = span.SpanID
+	}
+	return fields
+}
+
+// Headers returns the trace headers to send on outbound requests.
+func (s Span) Headers() map[string]string {
+	flags, sampled := "00", "0"
+	if s.Sampled {
+		flags, sampled = "01", "1"
+	}
+	headers := map[string]string{}
+	cloud := s.TraceID
+	if s.SpanID != "" {
+		headers["traceparent"] = fmt.Sprintf("00-%s-%s-%s", s.TraceID, s.SpanID, flags)
+		// X-Cloud-Trace-Context 的 span id 為十進位
+		id, _ := strconv.ParseUint(s.SpanID, 16, 64)
+		cloud += "/" + strconv.FormatUint(id, 10)
+	}
+	headers["X-Cloud-Trace-Context"] = cloud + ";o=" + sampled
+	return headers
+}
+
+// parseTraceparent 解析 W3C traceparent：version-traceid-parentid-flags
+func parseTraceparent(v string) (Span, bool) {
+	parts := strings.Split(strings.TrimSpace(v), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return Span{}, false
+	}
+	// version 00 只能有四段，之後的版本可能在後面加欄位
+	if parts[0] == "00" && len(parts) != 4 {
+		return Span{}, false
+	}
+	traceID, spanID, flags := strings.ToLower(parts[1]), strings.ToLower(parts[2]), parts[3]
+	if !isHexID(traceID, 32) || !isHexID(spanID, 16) || len(flags) != 2 {
+		return Span{}, false
+	}
+	f, err := strconv.ParseUint(flags, 16, 8)
+	if err != nil {
+		return Span{}, false
+	}
+	return Span{TraceID: traceID, SpanID: spanID, Sampled: f&1 == 1}, true
+}
+
+// parseCloudTrace 解析 X-Cloud-Trace-Context：TRACE_ID/SPAN_ID;o=OPTIONS，span id 為十進位
+func parseCloudTrace(v string) (Span, bool) {
+	v, options, _ := strings.Cut(strings.TrimSpace(v), ";")
+	traceID, spanPart, hasSpan := strings.Cut(v, "/")
+	traceID = strings.ToLower(traceID)
+	if !isHexID(traceID, 32) {
+		return Span{}, false
+	}
+	s := Span{TraceID: traceID, Sampled: strings.TrimSpace(options) == "o=1"}
+	if hasSpan {
+		if id, err := strconv.ParseUint(spanPart, 10, 64); err == nil && id != 0 {
+			s.SpanID = fmt.Sprintf("%016x", id)
+		}
+	}
+	return s, true
+}
+
+// isHexID 檢查是否為指定長度、不全為 0 的十六進位字串
+func isHexID(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return false
+	}
+	for _, c := range b {
+		if c != 0 {
+			return true
+		}
+	}
+	return false
+}
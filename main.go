@@ -1,26 +1,79 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"sync"
+	"time"
 
+	"go-story/internal/cachepurge"
+	"go-story/internal/changefeed"
 	"go-story/internal/config"
 	"go-story/internal/data"
+	"go-story/internal/errreport"
+	"go-story/internal/grpcapi"
+	"go-story/internal/logging"
+	"go-story/internal/metrics"
+	"go-story/internal/persisted"
+	"go-story/internal/probe"
+	"go-story/internal/pubsub"
 	"go-story/internal/schema"
 	"go-story/internal/server"
+	"go-story/internal/tracecontext"
 )
 
 func main() {
-	cfg, err := config.Load()
+	// 子命令：go-story probe --target <url> --self <url> --suite file.json
+	if len(os.Args) > 1 && os.Args[1] == "probe" {
+		os.Exit(runProbeCommand(os.Args[2:], os.Stdout))
+	}
+	// 子命令：go-story seed --database-url <dsn>，建立本機開發用的資料表與示範資料
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		os.Exit(runSeedCommand(os.Args[2:], os.Stdout))
+	}
+	// 子命令：go-story crawl --target <url> --self <url>，以資料庫中最近的 slug 產生查詢並比對兩邊回應
+	if len(os.Args) > 1 && os.Args[1] == "crawl" {
+		os.Exit(runCrawlCommand(os.Args[2:], os.Stdout))
+	}
+
+	// 命令列參數優先於環境變數與設定檔，例如 --port 9090 --redis-url redis://...
+	cfg, err := config.LoadWithOverrides(parseFlags(os.Args[1:]))
 	if err != nil {
 		log.Fatalf("config error: %v", err)
 	}
+	logging.Setup(cfg.LogFormat, cfg.GoogleCloudProject)
 
-	db, err := data.NewDB(cfg.DatabaseURL)
-	if err != nil {
-		log.Fatalf("failed to connect db: %v", err)
+	// 依容器的 CPU quota 與記憶體上限調整 GOMAXPROCS 與 GC 目標
+	tuneRuntime(cfg.MemoryLimit, cfg.GoEnv != "prod")
+
+	// sm:// secret 輪替時，新的 DB 連線會改用最新的 DATABASE_URL
+	var dsnMu sync.RWMutex
+	currentDSN := cfg.DatabaseURL
+	dbOpts := data.DBOptions{
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: time.Duration(cfg.DBConnMaxLifetime) * time.Second,
+		PingTimeout:     time.Duration(cfg.DBPingTimeout) * time.Second,
+		DSNFunc: func() string {
+			dsnMu.RLock()
+			defer dsnMu.RUnlock()
+			return currentDSN
+		},
+	}
+
+	// MOCK_MODE 不連線 DB，db 為 nil
+	var db *sql.DB
+	if !cfg.MockMode {
+		db, err = data.NewDB(cfg.DatabaseURL, dbOpts)
+		if err != nil {
+			log.Fatalf("failed to connect db: %v", err)
+		}
+		defer db.Close()
 	}
-	defer db.Close()
 
 	// 初始化 Redis cache
 	cache, err := data.NewCache(cfg.RedisURL, cfg.RedisEnabled, cfg.RedisTTL, cfg.GoEnv)
@@ -28,6 +81,7 @@ func main() {
 		log.Printf("warning: failed to initialize cache: %v", err)
 	}
 	defer cache.Close()
+	cache.SetTTLJitter(cfg.RedisTTLJitter)
 
 	if cache.Enabled() {
 		if cfg.GoEnv != "prod" {
@@ -39,19 +93,255 @@ func main() {
 		}
 	}
 
-	repo := data.NewRepo(db, cfg.StaticsHost, cache)
-	gqlSchema, err := schema.Build(repo)
+	// Error Reporting：未啟用時 reporter 為 nil，不會輸出任何事件
+	var reporter *errreport.Reporter
+	if cfg.ErrorReportingEnabled {
+		// Cloud Run 會以 K_REVISION 提供目前的 revision
+		reporter = errreport.New(cfg.ErrorReportingService, os.Getenv("K_REVISION"), cfg.GoogleCloudProject)
+	}
+
+	// 時區已在 config 驗證過
+	outputLocation, _ := time.LoadLocation(cfg.OutputTimezone)
+	repoOpts := data.RepoOptions{
+		QueryTimeout: time.Duration(cfg.DBQueryTimeout) * time.Second,
+		Location:     outputLocation,
+		TimeLayout:   cfg.OutputTimeLayout,
+		SQLComments:  cfg.SQLTraceComments,
+
+		AvifImages:         cfg.ImageAvif,
+		ImageFormatColumns: cfg.ImageFormatColumns,
+	}
+	var repo *data.Repo
+	if cfg.MockMode {
+		log.Printf("MOCK_MODE enabled: serving fixture data without a database")
+		repo = data.NewMockRepo(cfg.StaticsHost, repoOpts)
+	} else {
+		repo = data.NewRepo(db, cfg.StaticsHost, cache, repoOpts)
+	}
+	schemaOpts := schema.Options{
+		MaxTake:        cfg.GQLMaxTake,
+		MaxSkip:        cfg.GQLMaxSkip,
+		KeystoneParity: cfg.GQLKeystoneParity,
+
+		SanitizeExternalHTML: cfg.ExternalSanitizeHTML,
+		EmbedHosts:           cfg.ExternalEmbedHosts,
+		OgImageFallback:      cfg.OgImageFallback,
+
+		HomepageSections:        cfg.HomepageSections,
+		HomepagePostsPerSection: cfg.HomepagePostsPerSection,
+		SectionPartners:         cfg.SectionPartners,
+
+		Reporter:        reporter,
+		ResolverMetrics: cfg.GQLResolverMetrics,
+		SurrogateKeys:   cfg.SurrogateKeys,
+		EntityCounts:    cfg.GQLRequestLog,
+		AdminMutations:  cfg.AdminToken != "",
+		ViewCounts:      cfg.ViewCounts,
+
+		CacheControl:  cfg.GQLCacheControl,
+		DefaultMaxAge: cfg.GQLDefaultMaxAge,
+	}
+	gqlSchema, err := schema.Build(repo, schemaOpts)
 	if err != nil {
 		log.Fatalf("failed to build schema: %v", err)
 	}
 
-	http.Handle("/api/graphql", server.NewGraphQLHandler(gqlSchema))
-	http.HandleFunc("/probe", server.ProbeHandler)
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	// Persisted query allowlist：只在 prod 拒絕清單外的查詢，其他環境只記錄
+	var allowlist *persisted.Allowlist
+	if cfg.PersistedQueriesFile != "" {
+		enforce := cfg.PersistedQueriesOnly && cfg.GoEnv == "prod"
+		if cfg.PersistedQueriesOnly && !enforce {
+			log.Printf("PERSISTED_QUERIES_ONLY only applies when GO_ENV=prod; logging unlisted operations instead")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		allowlist, err = persisted.Load(ctx, cfg.PersistedQueriesFile, []byte(cfg.PersistedQueriesKey), enforce)
+		cancel()
+		if err != nil {
+			if enforce {
+				log.Fatalf("failed to load persisted query allowlist: %v", err)
+			}
+			log.Printf("warning: failed to load persisted query allowlist: %v", err)
+		}
+		allowlist.Watch(context.Background(), time.Duration(cfg.PersistedQueriesRefreshMinutes)*time.Minute)
+	}
+
+	// 抽樣的線上查詢送往舊 GQL 比對，未設定 SHADOW_REFERENCE_URL 時為 nil
+	var shadow *server.Shadow
+	if cfg.ShadowReferenceURL != "" {
+		shadow = &server.Shadow{
+			ReferenceURL:  cfg.ShadowReferenceURL,
+			SamplePercent: cfg.ShadowSamplePercent,
+			Ignore:        cfg.ShadowIgnorePaths,
+		}
+		shadow.Start(context.Background())
+	}
+
+	// 公開的 API；設定 ADMIN_ADDR 時管理端點改由另一個 listener 提供，不會出現在公開的 port 上
+	mux := http.NewServeMux()
+	adminMux := mux
+	// 管理端點與 /probe 只接受 ADMIN_ALLOW_CIDRS 內的來源，未設定時不限制
+	ipAllow := &server.IPAllowlist{Allowed: cfg.AdminAllowCIDRs, TrustedProxies: cfg.TrustedProxyCIDRs}
+	if cfg.AdminAddr != "" {
+		adminMux = http.NewServeMux()
+		adminMux.Handle("/debug/pprof/", server.RequireIP(ipAllow, server.PprofHandler()))
+	}
+	var tenants []site
+	// 快取清除依站台套用，主站為 ""、tenant 為其 cache_prefix
+	purgeCaches := cachepurge.Caches{"": cache}
+	// 設定 CDN_PURGE_URL 時，清除 Redis 後再依 surrogate key 清除 CDN
+	var purgeCDN *cachepurge.CDN
+	if cfg.CDNPurgeURL != "" {
+		purgeCDN = &cachepurge.CDN{URL: cfg.CDNPurgeURL, Token: cfg.CDNPurgeToken}
+	}
+	// 帶 X-API-Key 的合作夥伴請求依 API_QUOTA_* 限制次數，計數存於主站的 Redis；WebSocket 上的每個 operation 也各算一次
+	var quota *server.ClientQuota
+	if len(cfg.APIKeys) > 0 {
+		quota = &server.ClientQuota{Cache: cache, Clients: cfg.APIKeys, Hourly: cfg.APIQuotaHourly, Daily: cfg.APIQuotaDaily}
+	}
+	var api http.Handler = newAPIHandler(cfg, site{repo: repo, cache: cache, schema: gqlSchema}, reporter, allowlist, shadow, quota)
+	// TENANTS_FILE 中的站台依 Host header 切換；快取清除與內容變更事件涵蓋所有站台，其他背景工作與管理端點只處理主站
+	if len(cfg.Tenants) > 0 {
+		tenants, err = newTenantSites(cfg, dbOpts, repoOpts, schemaOpts)
+		if err != nil {
+			log.Fatalf("failed to set up tenants: %v", err)
+		}
+		router := &server.HostRouter{Default: api, Hosts: map[string]http.Handler{}}
+		for _, t := range tenants {
+			handler := newAPIHandler(cfg, t, reporter, allowlist, nil, quota)
+			for _, host := range t.hosts {
+				router.Hosts[host] = handler
+			}
+			purgeCaches[t.name] = t.cache
+		}
+		api = router
+	}
+	mux.Handle("/api/", server.RequireQuota(quota, api))
+	mux.Handle("/images/", api)
+	// /probe 會對呼叫端指定的 URL 發出請求，可另外要求 ADMIN_TOKEN
+	var probeHandler http.Handler = http.HandlerFunc(server.ProbeHandler)
+	if cfg.ProbeRequireToken {
+		probeHandler = server.RequireAdmin(cfg.AdminToken, probeHandler)
+	}
+	mux.Handle("/probe", server.RequireIP(ipAllow, probeHandler))
+	// prod 的 /metrics 與 API 共用公開的 port 且未限制來源 IP 時，改為需要 ADMIN_TOKEN
+	var metricsHandler http.Handler = metrics.Handler()
+	if cfg.GoEnv == "prod" && cfg.AdminAddr == "" && len(cfg.AdminAllowCIDRs) == 0 {
+		metricsHandler = server.RequireAdmin(cfg.AdminToken, metricsHandler)
+	}
+	adminMux.Handle("/metrics", server.RequireIP(ipAllow, metricsHandler))
+	if db != nil {
+		adminMux.Handle("/debug/db", server.RequireIP(ipAllow, server.RequireAdmin(cfg.AdminToken, server.DBStatsHandler(db, repo.DBLoad))))
+	}
+	adminMux.Handle("/debug/cache", server.RequireIP(ipAllow, server.RequireAdmin(cfg.AdminToken, server.CacheStatsHandler(cache))))
+	adminMux.Handle("/export/posts", server.RequireIP(ipAllow, server.RequireAdmin(cfg.AdminToken, server.ExportPostsHandler(repo))))
+	adminMux.Handle("POST /purge", server.RequireIP(ipAllow, server.RequireAdmin(cfg.AdminToken, cachepurge.Handler(purgeCaches, purgeCDN))))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte("GraphQL endpoint is available at POST /api/graphql"))
 	})
 
-	addr := ":" + cfg.Port
-	log.Printf("GraphQL server listening on %s (POST /api/graphql)", addr)
-	log.Fatal(http.ListenAndServe(addr, nil))
+	// tenant 的 cache 共用 REDIS_URL，輪替時一併更新
+	config.WatchSecrets(context.Background(), cfg, func(key, value string) {
+		switch key {
+		case "DATABASE_URL":
+			dsnMu.Lock()
+			currentDSN = value
+			dsnMu.Unlock()
+		case "REDIS_URL":
+			if err := cache.UpdateCredentials(value); err != nil {
+				log.Printf("warning: failed to apply rotated REDIS_URL: %v", err)
+			}
+			for _, t := range tenants {
+				_ = t.cache.UpdateCredentials(value)
+			}
+		}
+	})
+
+	// 背景定期 parity 檢查
+	if cfg.ProbeReferenceURL != "" {
+		scheduler := &probe.Scheduler{
+			ReferenceURL: cfg.ProbeReferenceURL,
+			SelfURL:      cfg.ProbeSelfURL,
+			Interval:     time.Duration(cfg.ProbeIntervalMinutes) * time.Minute,
+		}
+		scheduler.Start(context.Background())
+	}
+
+	// 偵測內容變更並發送到 Pub/Sub；每個 instance 都會各自發送，建議只在單一 instance 的服務上設定
+	// tenant 的事件發送到同一個 topic，以 tenant attribute 區分
+	if cfg.PubSubChangeTopic != "" {
+		client := pubsub.NewClient()
+		watchers := []*changefeed.Watcher{{Repo: repo}}
+		for _, t := range tenants {
+			watchers = append(watchers, &changefeed.Watcher{Repo: t.repo, Tenant: t.name})
+		}
+		for _, watcher := range watchers {
+			watcher.Client = client
+			watcher.Topic = cfg.PubSubChangeTopic
+			watcher.Interval = time.Duration(cfg.ChangePollSeconds) * time.Second
+			watcher.Start(context.Background())
+		}
+	}
+
+	// 瀏覽次數先累積在 Redis，定期寫入 PostViews
+	if cfg.ViewCounts {
+		flusher := &data.ViewFlusher{Repo: repo, Interval: time.Duration(cfg.ViewFlushSeconds) * time.Second}
+		flusher.Start(context.Background())
+	}
+
+	// CMS 發送的 cache purge；Redis 由所有 instance 共用，共用同一個 subscription 即可。訊息未指定 tenant 時清除所有站台
+	if cfg.PubSubPurgeSubscription != "" {
+		subscriber := &cachepurge.Subscriber{
+			Client:       pubsub.NewClient(),
+			Subscription: cfg.PubSubPurgeSubscription,
+			Caches:       purgeCaches,
+			CDN:          purgeCDN,
+		}
+		subscriber.Start(context.Background())
+	}
+
+	// 預熱完成前 /readyz 回應 503，可作為 Cloud Run startup probe 或 k8s readiness probe
+	readiness := &server.Readiness{}
+	mux.Handle("/readyz", readiness)
+	go func() {
+		warmConns := cfg.DBWarmConns
+		if db == nil {
+			warmConns = 0
+		}
+		if warmConns > 0 || cfg.WarmupCache {
+			warmUp(db, gqlSchema, warmConns, cfg.WarmupCache, time.Duration(cfg.WarmupTimeout)*time.Second)
+		}
+		readiness.SetReady()
+	}()
+
+	// gRPC 讀取服務，與 HTTP 使用不同的 port
+	if cfg.GRPCPort != "" {
+		lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			log.Fatalf("failed to listen on gRPC port: %v", err)
+		}
+		grpcServer := grpcapi.NewServer(repo, grpcapi.Options{
+			MaxTake:  cfg.GQLMaxTake,
+			MaxSkip:  cfg.GQLMaxSkip,
+			Reporter: reporter,
+		})
+		go func() {
+			log.Printf("gRPC server listening on %s (story.v1.StoryService)", lis.Addr())
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Fatalf("gRPC server error: %v", err)
+			}
+		}()
+	}
+
+	// 管理端點只應綁定在內部網路的位址上
+	if cfg.AdminAddr != "" {
+		go func() {
+			log.Printf("admin server listening on %s (/metrics, /debug/*, /export/*, /purge)", cfg.AdminAddr)
+			if err := http.ListenAndServe(cfg.AdminAddr, adminMux); err != nil {
+				log.Fatalf("admin server error: %v", err)
+			}
+		}()
+	}
+
+	// 上游帶入的 trace 附加到 request context，日誌與對外請求沿用同一個 trace
+	log.Fatal(listenAndServe(cfg, tracecontext.Middleware(mux)))
 }
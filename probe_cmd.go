@@ -0,0 +1,114 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go-story/internal/probe"
+)
+
+// headerFlags collects repeated --header "Key: Value" flags.
+type headerFlags map[string]string
+
+func (h headerFlags) String() string {
+	parts := make([]string, 0, len(h))
+	for k, v := range h {
+		parts = append(parts, k+": "+v)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (h headerFlags) Set(value string) error {
+	k, v, ok := strings.Cut(value, ":")
+	if !ok || strings.TrimSpace(k) == "" {
+		return fmt.Errorf("header must be in \"Key: Value\" form")
+	}
+	h[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	return nil
+}
+
+// runProbeCommand implements `go-story probe`, returning the process exit code.
+func runProbeCommand(args []string, stdout io.Writer) int {
+	fs := flag.NewFlagSet("probe", flag.ContinueOnError)
+	target := fs.String("target", "", "reference GQL endpoint (required unless --snapshot-dir is set)")
+	self := fs.String("self", "http://127.0.0.1:8080/api/graphql", "go-story GQL endpoint")
+	suitePath := fs.String("suite", "", "JSON file with [{name, query, variables, ignore}] (default: built-in suite)")
+	snapshotDir := fs.String("snapshot-dir", "", "directory of golden snapshots; compares self against them when --target is empty")
+	updateSnapshots := fs.Bool("update-snapshots", false, "store self responses as golden snapshots in --snapshot-dir")
+	headers := headerFlags{}
+	fs.Var(headers, "header", "header forwarded to both endpoints, e.g. \"Authorization: Bearer ...\" (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *updateSnapshots && *snapshotDir == "" {
+		fmt.Fprintln(os.Stderr, "probe: --update-snapshots requires --snapshot-dir")
+		return 2
+	}
+	if *target == "" && *snapshotDir == "" {
+		fmt.Fprintln(os.Stderr, "probe: --target or --snapshot-dir is required")
+		fs.Usage()
+		return 2
+	}
+
+	suite := probe.DefaultSuite()
+	if *suitePath != "" {
+		loaded, err := probe.LoadSuite(*suitePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "probe: %v\n", err)
+			return 2
+		}
+		suite = loaded
+	}
+
+	selfResults := probe.Run(*self, suite, headers)
+
+	// 更新 golden snapshot 後直接結束
+	if *updateSnapshots {
+		if err := probe.SaveSnapshots(*snapshotDir, selfResults); err != nil {
+			fmt.Fprintf(os.Stderr, "probe: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "%d snapshots written to %s\n", len(selfResults), *snapshotDir)
+		return 0
+	}
+
+	// 沒有 target 時以 golden snapshot 作為比對基準
+	var targetResults []probe.Result
+	targetLabel := *target
+	if *target != "" {
+		targetResults = probe.Run(*target, suite, headers)
+	} else {
+		targetResults = probe.LoadSnapshots(*snapshotDir, suite)
+		targetLabel = "snapshots:" + *snapshotDir
+	}
+
+	failed := 0
+	for i, tr := range targetResults {
+		sr := selfResults[i]
+		match, note := probe.Compare(tr, sr, suite[i].Ignore)
+		if match {
+			fmt.Fprintf(stdout, "[PASS] %s\n", tr.Name)
+			continue
+		}
+		failed++
+		fmt.Fprintf(stdout, "[FAIL] %s: %s (target status %d, self status %d)\n", tr.Name, note, tr.StatusCode, sr.StatusCode)
+		if tr.Error != "" {
+			fmt.Fprintf(stdout, "    target error: %s\n", tr.Error)
+		}
+		if sr.Error != "" {
+			fmt.Fprintf(stdout, "    self error: %s\n", sr.Error)
+		}
+		for _, d := range probe.Diff(tr, sr, suite[i].Ignore, 20) {
+			fmt.Fprintf(stdout, "    %s\n", d)
+		}
+	}
+	fmt.Fprintf(stdout, "\n%d/%d tests matched (target %s, self %s)\n", len(targetResults)-failed, len(targetResults), targetLabel, *self)
+
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"log"
+	"math"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"go.uber.org/automaxprocs/maxprocs"
+)
+
+// memoryLimitRatio 未設定 GOMEMLIMIT 時取容器記憶體上限的比例，保留空間給非 heap 的記憶體
+const memoryLimitRatio = 0.9
+
+// cgroupMemoryLimitFiles 依序為 cgroup v2 與 v1 的記憶體上限檔案
+var cgroupMemoryLimitFiles = []string{
+	"/sys/fs/cgroup/memory.max",
+	"/sys/fs/cgroup/memory/memory.limit_in_bytes",
+}
+
+// tuneRuntime sets GOMAXPROCS from the container CPU quota and the soft
+// memory limit from memoryLimit (bytes), falling back to a share of the
+// container memory limit when memoryLimit is 0.
+func tuneRuntime(memoryLimit int64, verbose bool) {
+	logf := func(string, ...interface{}) {}
+	if verbose {
+		logf = log.Printf
+	}
+	if _, err := maxprocs.Set(maxprocs.Logger(logf)); err != nil {
+		log.Printf("warning: failed to set GOMAXPROCS: %v", err)
+	}
+
+	if memoryLimit == 0 {
+		limit, ok := cgroupMemoryLimit()
+		if !ok {
+			return
+		}
+		memoryLimit = int64(float64(limit) * memoryLimitRatio)
+	}
+	debug.SetMemoryLimit(memoryLimit)
+	if memoryLimit == math.MaxInt64 {
+		logf("memory limit: off")
+	} else {
+		logf("memory limit: %d MiB", memoryLimit>>20)
+	}
+}
+
+// cgroupMemoryLimit 讀取容器的記憶體上限，沒有限制（max 或極大值）時回傳 false
+func cgroupMemoryLimit() (int64, bool) {
+	for _, path := range cgroupMemoryLimitFiles {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		v, err := strconv.ParseInt(strings.TrimSpace(string(raw)), 10, 64)
+		// cgroup v1 沒有限制時會回傳接近 int64 上限的值
+		if err != nil || v <= 0 || v >= math.MaxInt64/2 {
+			return 0, false
+		}
+		return v, true
+	}
+	return 0, false
+}
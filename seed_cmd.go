@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"go-story/internal/data"
+	"go-story/internal/seed"
+)
+
+// runSeedCommand implements `go-story seed`, returning the process exit code.
+func runSeedCommand(args []string, stdout io.Writer) int {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	dsn := fs.String("database-url", os.Getenv("DATABASE_URL"), "Postgres connection string (default: $DATABASE_URL)")
+	reset := fs.Bool("reset", false, "truncate the seeded tables before loading (deletes existing rows)")
+	schemaOnly := fs.Bool("schema-only", false, "create the tables without loading fixtures")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *dsn == "" {
+		fmt.Fprintln(os.Stderr, "seed: --database-url or DATABASE_URL is required")
+		fs.Usage()
+		return 2
+	}
+
+	db, err := data.NewDB(*dsn, data.DBOptions{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "seed: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := seed.Run(ctx, db, seed.Options{Reset: *reset, SchemaOnly: *schemaOnly}); err != nil {
+		fmt.Fprintf(os.Stderr, "seed: %v\n", err)
+		return 1
+	}
+	if *schemaOnly {
+		fmt.Fprintln(stdout, "tables created")
+	} else {
+		fmt.Fprintln(stdout, "tables created and fixtures loaded")
+	}
+	return 0
+}
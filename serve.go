@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"go-story/internal/config"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// listenAndServe serves handler on PORT (or LISTEN, which may
+// be a Unix domain socket), over HTTPS when a certificate pair or autocert
+// domains are configured. With TLS_REDIRECT_PORT, a second plain HTTP
+// listener redirects to HTTPS and answers ACME HTTP-01 challenges.
+func listenAndServe(cfg config.Config, handler http.Handler) error {
+	ln, err := listen(cfg)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: handler}
+	if !cfg.TLSEnabled() {
+		log.Printf("GraphQL server listening on %s (POST /api/graphql)", ln.Addr())
+		return srv.Serve(ln)
+	}
+
+	redirect := httpsRedirect(cfg.Port)
+	certFile, keyFile := cfg.TLSCertFile, cfg.TLSKeyFile
+	if len(cfg.TLSAutocertDomains) > 0 {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.TLSAutocertDomains...),
+			Cache:      autocert.DirCache(cfg.TLSAutocertCacheDir),
+			Email:      cfg.TLSAutocertEmail,
+		}
+		srv.TLSConfig = manager.TLSConfig()
+		redirect = manager.HTTPHandler(redirect)
+		// 憑證由 TLSConfig.GetCertificate 提供
+		certFile, keyFile = "", ""
+		log.Printf("TLS certificates from Let's Encrypt for %s (cache %s)", strings.Join(cfg.TLSAutocertDomains, ", "), cfg.TLSAutocertCacheDir)
+	}
+
+	if cfg.TLSRedirectPort != "" {
+		go func() {
+			addr := ":" + cfg.TLSRedirectPort
+			log.Printf("HTTP redirect listening on %s", addr)
+			if err := http.ListenAndServe(addr, redirect); err != nil {
+				log.Fatalf("HTTP redirect server error: %v", err)
+			}
+		}()
+	}
+
+	log.Printf("GraphQL server listening on %s (HTTPS, POST /api/graphql)", ln.Addr())
+	return srv.ServeTLS(ln, certFile, keyFile)
+}
+
+// listen 依 LISTEN 建立 TCP 或 Unix domain socket listener；socket 檔已存在時
+// （上次未正常結束留下的）先移除，建立後依 LISTEN_SOCKET_MODE 設定權限
+func listen(cfg config.Config) (net.Listener, error) {
+	network, address := cfg.ListenAddr()
+	if network != "unix" {
+		return net.Listen(network, address)
+	}
+	if info, err := os.Stat(address); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("listen %s: file exists and is not a socket", address)
+		}
+		if err := os.Remove(address); err != nil {
+			return nil, fmt.Errorf("remove stale socket: %w", err)
+		}
+	}
+	ln, err := net.Listen("unix", address)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(address, cfg.ListenSocketMode); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("chmod socket: %w", err)
+	}
+	return ln, nil
+}
+
+// httpsRedirect 將請求以 301 轉址到同一 host 的 HTTPS；PORT 不是 443 時保留埠號
+func httpsRedirect(port string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if port != "443" {
+			host = net.JoinHostPort(host, port)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
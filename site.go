@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"go-story/internal/config"
+	"go-story/internal/data"
+	"go-story/internal/errreport"
+	"go-story/internal/persisted"
+	"go-story/internal/schema"
+	"go-story/internal/server"
+
+	"github.com/graphql-go/graphql"
+)
+
+// site is the data source and schema of one site: the main configuration
+// or a tenant from TENANTS_FILE.
+type site struct {
+	repo   *data.Repo
+	cache  *data.Cache
+	schema graphql.Schema
+	// hosts 為 tenant 對應的 Host header，主站為空
+	hosts []string
+	// name 為 tenant 的 cache_prefix，主站為空；用於快取清除與內容變更事件
+	name string
+}
+
+// newAPIHandler builds the public API routes of a site: GraphQL over HTTP
+// and WebSocket, the REST API and image redirects. Load shedding, response
+// caching, coalescing and parsed documents are kept per site; shadow and
+// quota may be nil.
+func newAPIHandler(cfg config.Config, s site, reporter *errreport.Reporter, allowlist *persisted.Allowlist, shadow *server.Shadow, quota *server.ClientQuota) http.Handler {
+	// DB 飽和時拒絕未驗證的列表查詢，未設定門檻時 shedder 為 nil
+	var shedder *server.LoadShedder
+	if cfg.LoadShedMaxInFlight > 0 || cfg.LoadShedMaxLatencyMS > 0 {
+		shedder = &server.LoadShedder{
+			Load:        s.repo.DBLoad,
+			MaxInFlight: cfg.LoadShedMaxInFlight,
+			MaxLatency:  time.Duration(cfg.LoadShedMaxLatencyMS) * time.Millisecond,
+			RetryAfter:  time.Duration(cfg.LoadShedRetryAfter) * time.Second,
+		}
+	}
+
+	// 匿名熱門查詢的整個回應快取，未設定 GQL_RESPONSE_CACHE 時為 nil
+	var respCache *server.ResponseCache
+	if len(cfg.GQLResponseCache) > 0 {
+		respCache = &server.ResponseCache{Cache: s.cache, TTLs: map[string]time.Duration{}}
+		for name, seconds := range cfg.GQLResponseCache {
+			respCache.TTLs[name] = time.Duration(seconds) * time.Second
+		}
+	}
+
+	var coalescer *server.Coalescer
+	if cfg.GQLCoalesce {
+		coalescer = &server.Coalescer{}
+	}
+
+	// HTTP 與 WebSocket 共用已解析的查詢
+	var documents *server.DocumentCache
+	if cfg.GQLDocumentCacheSize > 0 {
+		documents = &server.DocumentCache{MaxEntries: cfg.GQLDocumentCacheSize}
+	}
+
+	var reqLog *server.RequestLog
+	if cfg.GQLRequestLog {
+		reqLog = &server.RequestLog{VariablesPercent: cfg.GQLRequestLogVariablesPercent}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/graphql", server.MarkAdmin(cfg.AdminToken, server.NewGraphQLHandler(s.schema, reporter, shedder, allowlist, respCache, coalescer, documents, shadow, reqLog, cfg.GoEnv == "prod")))
+	mux.Handle("/api/graphql/ws", server.NewGraphQLWSHandler(s.schema, reporter, server.GraphQLWSOptions{
+		MaxOperations: cfg.WSMaxOperations,
+		KeepAlive:     time.Duration(cfg.WSKeepAlive) * time.Second,
+		Allowlist:     allowlist,
+		Documents:     documents,
+		HideHints:     cfg.GoEnv == "prod",
+		Shedder:       shedder,
+		Quota:         quota,
+	}))
+	// REST 讀取 API 與其 OpenAPI 文件，由同一份路由表產生
+	restHandler := server.NewRESTHandler(s.repo, reporter, server.RESTOptions{
+		MaxTake: cfg.GQLMaxTake,
+		MaxSkip: cfg.GQLMaxSkip,
+	})
+	mux.Handle("/api/v1/", restHandler)
+	mux.Handle("/api/openapi.json", restHandler)
+	mux.Handle("GET /images/{id}", server.ImageRedirectHandler(s.repo, reporter))
+	return mux
+}
+
+// newTenantSites opens the database and cache of every tenant and builds
+// its repo and schema. Each tenant gets its own DB connection pool (sized
+// like the main one) and Redis client with keys prefixed by cache_prefix.
+func newTenantSites(cfg config.Config, dbOpts data.DBOptions, repoOpts data.RepoOptions, schemaOpts schema.Options) ([]site, error) {
+	sites := make([]site, 0, len(cfg.Tenants))
+	for _, t := range cfg.Tenants {
+		cache, err := data.NewCache(cfg.RedisURL, cfg.RedisEnabled, cfg.RedisTTL, cfg.GoEnv)
+		if err != nil {
+			log.Printf("warning: failed to initialize cache for tenant %s: %v", t.CachePrefix, err)
+		}
+		cache.SetKeyPrefix(t.CachePrefix)
+		cache.SetTTLJitter(cfg.RedisTTLJitter)
+
+		var repo *data.Repo
+		if cfg.MockMode {
+			repo = data.NewMockRepo(t.StaticsHost, repoOpts)
+		} else {
+			opts := dbOpts
+			dsn := t.DatabaseURL
+			opts.DSNFunc = func() string { return dsn }
+			db, err := data.NewDB(dsn, opts)
+			if err != nil {
+				return nil, fmt.Errorf("tenant %s: connect db: %w", t.CachePrefix, err)
+			}
+			repo = data.NewRepo(db, t.StaticsHost, cache, repoOpts)
+		}
+		gqlSchema, err := schema.Build(repo, schemaOpts)
+		if err != nil {
+			return nil, fmt.Errorf("tenant %s: build schema: %w", t.CachePrefix, err)
+		}
+
+		// 瀏覽次數依站台分別寫入各自的 PostViews
+		if cfg.ViewCounts {
+			flusher := &data.ViewFlusher{Repo: repo, Interval: time.Duration(cfg.ViewFlushSeconds) * time.Second}
+			flusher.Start(context.Background())
+		}
+		log.Printf("tenant %s serving hosts %v", t.CachePrefix, t.Hosts)
+		sites = append(sites, site{repo: repo, cache: cache, schema: gqlSchema, hosts: t.Hosts, name: t.CachePrefix})
+	}
+	return sites, nil
+}
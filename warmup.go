@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"go-story/internal/data"
+	"go-story/internal/probe"
+
+	"github.com/graphql-go/graphql"
+)
+
+// warmUp pre-opens DB connections and, when warmCache is set, runs the
+// built-in probe queries so their results land in Redis. Failures are
+// logged only; the service becomes ready either way once timeout passes.
+func warmUp(db *sql.DB, gqlSchema graphql.Schema, conns int, warmCache bool, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	if conns > 0 {
+		if err := data.WarmPool(ctx, db, conns); err != nil {
+			log.Printf("warning: db warm-up: %v", err)
+		}
+	}
+	if warmCache {
+		for _, t := range probe.DefaultSuite() {
+			result := graphql.Do(graphql.Params{
+				Schema:         gqlSchema,
+				RequestString:  t.Query,
+				VariableValues: t.Variables,
+				Context:        ctx,
+			})
+			if result.HasErrors() {
+				log.Printf("warning: cache warm-up %s: %v", t.Name, result.Errors[0])
+			}
+		}
+	}
+	log.Printf("warm-up finished in %s", time.Since(start).Round(time.Millisecond))
+}